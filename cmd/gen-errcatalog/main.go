@@ -0,0 +1,129 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// gen-errcatalog 根据 errorsx 中注册的错误生成错误码目录（Markdown 和 JSON 格式），供前端等 API 调用方使用.
+// 通过 internal/pkg/errno 中的 go:generate 指令运行：
+//
+//	go generate ./internal/pkg/errno
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	httpstatus "github.com/go-kratos/kratos/v2/transport/http/status"
+
+	// 导入 errno 包以注册 OpsX 项目的所有错误和本地化错误信息
+	_ "github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// Entry 为错误码目录中的一个错误.
+type Entry struct {
+	// Code 为错误的 HTTP 状态码.
+	Code int `json:"code"`
+	// GRPCCode 为错误的 gRPC 状态码，例如 NotFound.
+	GRPCCode string `json:"grpcCode"`
+	// Reason 为错误原因.
+	Reason string `json:"reason"`
+	// Message 为错误信息.
+	Message string `json:"message"`
+	// LocalizedMessages 为本地化错误信息，键为语言.
+	LocalizedMessages map[string]string `json:"localizedMessages,omitempty"`
+}
+
+func main() {
+	outputDir := flag.String("output-dir", "api/errors", "Directory to write errors.md and errors.json to.")
+	flag.Parse()
+
+	entries := catalog()
+	md := renderMarkdown(entries)
+	js, err := renderJSON(entries)
+	if err != nil {
+		log.Fatalf("Failed to render JSON catalog: %v", err)
+	}
+
+	if err := os.MkdirAll(*outputDir, 0o755); err != nil {
+		log.Fatalf("Failed to create output directory: %v", err)
+	}
+	for name, data := range map[string][]byte{"errors.md": md, "errors.json": js} {
+		if err := os.WriteFile(filepath.Join(*outputDir, name), data, 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// catalog 返回所有已注册错误的目录，按照 HTTP 状态码和错误原因排序.
+func catalog() []Entry {
+	registered := errorsx.Registered()
+	entries := make([]Entry, 0, len(registered))
+	for _, errx := range registered {
+		entry := Entry{
+			Code:     errx.Code,
+			GRPCCode: httpstatus.ToGRPCCode(errx.Code).String(),
+			Reason:   errx.Reason,
+			Message:  errx.Message,
+		}
+		if messages := errorsx.LocalizedMessages(errx.Reason); len(messages) > 0 {
+			entry.LocalizedMessages = messages
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// renderJSON 以 JSON 格式输出错误码目录.
+func renderJSON(entries []Entry) ([]byte, error) {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// renderMarkdown 以 Markdown 表格输出错误码目录，每种语言的本地化错误信息占一列.
+func renderMarkdown(entries []Entry) []byte {
+	var locales []string
+	for _, entry := range entries {
+		for locale := range entry.LocalizedMessages {
+			if !slices.Contains(locales, locale) {
+				locales = append(locales, locale)
+			}
+		}
+	}
+	slices.Sort(locales)
+
+	var buf bytes.Buffer
+	buf.WriteString("<!-- Code generated by gen-errcatalog. DO NOT EDIT. -->\n\n")
+	buf.WriteString("# 错误码\n\n")
+	buf.WriteString("| HTTP 状态码 | gRPC 状态码 | 错误原因 | 错误信息 |")
+	for _, locale := range locales {
+		fmt.Fprintf(&buf, " %s |", locale)
+	}
+	buf.WriteString("\n| --- | --- | --- | --- |")
+	buf.WriteString(strings.Repeat(" --- |", len(locales)))
+	buf.WriteString("\n")
+
+	for _, entry := range entries {
+		fmt.Fprintf(&buf, "| %d | %s | `%s` | %s |", entry.Code, entry.GRPCCode, entry.Reason, escape(entry.Message))
+		for _, locale := range locales {
+			fmt.Fprintf(&buf, " %s |", escape(entry.LocalizedMessages[locale]))
+		}
+		buf.WriteString("\n")
+	}
+	return buf.Bytes()
+}
+
+// escape 转义 Markdown 表格单元格中的竖线.
+func escape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
@@ -0,0 +1,45 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+)
+
+func TestCatalog(t *testing.T) {
+	entries := catalog()
+	require.NotEmpty(t, entries)
+
+	var found bool
+	for _, entry := range entries {
+		if entry.Reason == errno.ErrUserNotFound.Reason {
+			found = true
+			assert.Equal(t, 404, entry.Code)
+			assert.Equal(t, "NotFound", entry.GRPCCode)
+			assert.NotEmpty(t, entry.LocalizedMessages["zh-CN"])
+		}
+	}
+	assert.True(t, found)
+}
+
+// TestCatalogUpToDate 验证提交的错误码目录与注册的错误一致. 失败时需要执行 go generate ./internal/pkg/errno.
+func TestCatalogUpToDate(t *testing.T) {
+	entries := catalog()
+	js, err := renderJSON(entries)
+	require.NoError(t, err)
+
+	for name, want := range map[string][]byte{"errors.md": renderMarkdown(entries), "errors.json": js} {
+		got, err := os.ReadFile("../../api/errors/" + name)
+		require.NoError(t, err)
+		assert.Equal(t, string(want), string(got), "api/errors/%s is out of date, run go generate ./internal/pkg/errno", name)
+	}
+}
@@ -8,6 +8,7 @@ package options
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"time"
 
 	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
@@ -24,13 +25,14 @@ var availableServerModes = sets.New(
 	usercenter.GRPCServerMode,
 	usercenter.GRPCGatewayServerMode,
 	usercenter.GinServerMode,
+	usercenter.SinglePortServerMode,
 )
 
 // ServerOptions 包含服务器配置选项.
 // mapstructure 标签用于将配置文件中的配置项与 Go 结构体字段进行映射，
 // 在调用 viper.Unmarshal 函数时，viper 会将配置文件中配置项的值赋值给对应的结构体字段
 type ServerOptions struct {
-	// ServerMode 定义服务器模式：gRPC、Gin HTTP、HTTP Reverse Proxy.
+	// ServerMode 定义服务器模式：gRPC、Gin HTTP、HTTP Reverse Proxy、单端口 gRPC + HTTP Reverse Proxy.
 	ServerMode string `json:"server-mode" mapstructure:"server-mode"`
 	// JWTKey 定义 JWT 密钥.
 	JWTKey string `json:"jwt-key" mapstructure:"jwt-key"`
@@ -40,19 +42,41 @@ type ServerOptions struct {
 	GRPCOptions *genericoptions.GRPCOptions `json:"grpc" mapstructure:"grpc"`
 	// HTTP 配置
 	HTTPOptions *genericoptions.HTTPOptions `json:"http" mapstructure:"http"`
+	// EnableMemoryStore 指示是否使用内存存储代替 SQL 数据库.
+	EnableMemoryStore bool `json:"enable-memory-store" mapstructure:"enable-memory-store"`
+	// SQL 数据库配置
+	SQLOptions *genericoptions.SQLOptions `json:"sql" mapstructure:"sql"`
+	// AdminUserIDs 定义拥有管理员角色的用户 ID 列表.
+	// 用户名可以在注册或者修改用户信息时任意指定，因此不能用于授予管理员角色.
+	AdminUserIDs []string `json:"admin-user-ids" mapstructure:"admin-user-ids"`
+	// Metrics 配置
+	MetricsOptions *genericoptions.MetricsOptions `json:"metrics" mapstructure:"metrics"`
+	// Tracing 配置
+	TracingOptions *genericoptions.TracingOptions `json:"tracing" mapstructure:"tracing"`
+	// 限流配置
+	RateLimitOptions *genericoptions.RateLimitOptions `json:"rate-limit" mapstructure:"rate-limit"`
 }
 
+// reloadableOptions 为支持热加载的配置项，对应 ServerOptions 字段的 mapstructure 标签.
+// 修改其他配置项需要重启服务才能生效.
+var reloadableOptions = sets.New("expiration", "rate-limit")
+
 // NewServerOptions 创建带有默认值的 ServerOptions 实例.
 func NewServerOptions() *ServerOptions {
 	opts := &ServerOptions{
-		ServerMode:  "grpc-gateway",
-		JWTKey:      "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iB31",
-		Expiration:  2 * time.Hour,
-		GRPCOptions: genericoptions.NewGRPCOptions(),
-		HTTPOptions: genericoptions.NewHTTPOptions(),
+		ServerMode:       "grpc-gateway",
+		JWTKey:           "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iB31",
+		Expiration:       2 * time.Hour,
+		GRPCOptions:      genericoptions.NewGRPCOptions(),
+		HTTPOptions:      genericoptions.NewHTTPOptions(),
+		SQLOptions:       genericoptions.NewSQLOptions(),
+		MetricsOptions:   genericoptions.NewMetricsOptions(),
+		TracingOptions:   genericoptions.NewTracingOptions(),
+		RateLimitOptions: genericoptions.NewRateLimitOptions(),
 	}
 	opts.GRPCOptions.Addr = ":7701"
 	opts.HTTPOptions.Addr = ":7700"
+	opts.MetricsOptions.Addr = ":7702"
 	return opts
 }
 
@@ -66,6 +90,12 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&o.Expiration, "expiration", o.Expiration, "The expiration duration of JWT tokens.")
 	o.GRPCOptions.AddFlags(fs)
 	o.HTTPOptions.AddFlags(fs)
+	fs.BoolVar(&o.EnableMemoryStore, "enable-memory-store", o.EnableMemoryStore, "Enable in-memory store instead of SQL database. Data will be lost after restart.")
+	o.SQLOptions.AddFlags(fs)
+	fs.StringSliceVar(&o.AdminUserIDs, "admin-user-ids", o.AdminUserIDs, "IDs of the users that are granted the admin role, which is allowed to call every API.")
+	o.MetricsOptions.AddFlags(fs)
+	o.TracingOptions.AddFlags(fs)
+	o.RateLimitOptions.AddFlags(fs)
 }
 
 // Validate 校验 ServerOptions 中的选项是否合法.
@@ -87,11 +117,25 @@ func (o *ServerOptions) Validate() error {
 		errs = append(errs, o.GRPCOptions.Validate()...)
 	}
 
-	// 如果是 gRPC-Gateway 模式或 Gin 模式，校验 HTTP 配置
-	if stringsutil.StringIn(o.ServerMode, []string{usercenter.GRPCGatewayServerMode, usercenter.GinServerMode}) {
+	// 如果是 gRPC-Gateway 模式、Gin 模式或单端口模式，校验 HTTP 配置
+	if stringsutil.StringIn(o.ServerMode, []string{usercenter.GRPCGatewayServerMode, usercenter.GinServerMode, usercenter.SinglePortServerMode}) {
 		errs = append(errs, o.HTTPOptions.Validate()...)
 	}
 
+	// 如果没有启用内存存储，校验 SQL 数据库配置
+	if !o.EnableMemoryStore {
+		errs = append(errs, o.SQLOptions.Validate()...)
+	}
+
+	// 校验指标配置
+	errs = append(errs, o.MetricsOptions.Validate()...)
+
+	// 校验链路追踪配置
+	errs = append(errs, o.TracingOptions.Validate()...)
+
+	// 校验限流配置
+	errs = append(errs, o.RateLimitOptions.Validate()...)
+
 	// 合并所有错误并返回
 	return utilerrors.NewAggregate(errs)
 }
@@ -99,10 +143,50 @@ func (o *ServerOptions) Validate() error {
 // Config 将初始化配置 ServerOptions 转换为运行时配置 core.Config.
 func (o *ServerOptions) Config() (*usercenter.Config, error) {
 	return &usercenter.Config{
-		ServerMode:  o.ServerMode,
-		JWTKey:      o.JWTKey,
-		Expiration:  o.Expiration,
-		GRPCOptions: o.GRPCOptions,
-		HTTPOptions: o.HTTPOptions,
+		ServerMode:        o.ServerMode,
+		JWTKey:            o.JWTKey,
+		Expiration:        o.Expiration,
+		GRPCOptions:       o.GRPCOptions,
+		HTTPOptions:       o.HTTPOptions,
+		EnableMemoryStore: o.EnableMemoryStore,
+		SQLOptions:        o.SQLOptions,
+		AdminUserIDs:      o.AdminUserIDs,
+		MetricsOptions:    o.MetricsOptions,
+		TracingOptions:    o.TracingOptions,
+		RateLimitOptions:  o.RateLimitOptions,
 	}, nil
 }
+
+// Clone 返回 ServerOptions 的深拷贝.
+func (o *ServerOptions) Clone() *ServerOptions {
+	clone := *o
+	clone.AdminUserIDs = append([]string(nil), o.AdminUserIDs...)
+
+	grpcOptions, httpOptions := *o.GRPCOptions, *o.HTTPOptions
+	grpcTLSOptions, httpTLSOptions := *o.GRPCOptions.TLSOptions, *o.HTTPOptions.TLSOptions
+	grpcOptions.TLSOptions, httpOptions.TLSOptions = &grpcTLSOptions, &httpTLSOptions
+	clone.GRPCOptions, clone.HTTPOptions = &grpcOptions, &httpOptions
+
+	sqlOptions, metricsOptions, tracingOptions, rateLimitOptions := *o.SQLOptions, *o.MetricsOptions, *o.TracingOptions, *o.RateLimitOptions
+	clone.SQLOptions, clone.MetricsOptions, clone.TracingOptions, clone.RateLimitOptions = &sqlOptions, &metricsOptions, &tracingOptions, &rateLimitOptions
+
+	return &clone
+}
+
+// RestartRequired 返回 newOpts 相比 o 发生了变化、但需要重启服务才能生效的配置项.
+func (o *ServerOptions) RestartRequired(newOpts *ServerOptions) []string {
+	var changed []string
+
+	oldValue, newValue := reflect.ValueOf(o).Elem(), reflect.ValueOf(newOpts).Elem()
+	for i := range oldValue.NumField() {
+		name := oldValue.Type().Field(i).Tag.Get("mapstructure")
+		if reloadableOptions.Has(name) {
+			continue
+		}
+		if !reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
@@ -0,0 +1,49 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerOptionsClone(t *testing.T) {
+	opts := NewServerOptions()
+	opts.SQLOptions.DSN = "file:opsx.db"
+	clone := opts.Clone()
+	assert.Equal(t, opts, clone)
+
+	clone.RateLimitOptions.QPS = 1
+	clone.HTTPOptions.TLSOptions.UseTLS = true
+	clone.AdminUserIDs = append(clone.AdminUserIDs, "user-alice")
+	assert.NotEqual(t, opts.RateLimitOptions.QPS, clone.RateLimitOptions.QPS)
+	assert.NotEqual(t, opts.AdminUserIDs, clone.AdminUserIDs)
+	assert.False(t, opts.HTTPOptions.TLSOptions.UseTLS)
+}
+
+func TestServerOptionsRestartRequired(t *testing.T) {
+	opts := NewServerOptions()
+
+	newOpts := opts.Clone()
+	newOpts.Expiration = time.Minute
+	newOpts.RateLimitOptions.Enabled = true
+	assert.Empty(t, opts.RestartRequired(newOpts))
+
+	newOpts.ServerMode = "gin"
+	newOpts.HTTPOptions.Addr = ":8080"
+	assert.Equal(t, []string{"server-mode", "http"}, opts.RestartRequired(newOpts))
+}
+
+func TestServerOptionsValidateWithoutMetricsAddr(t *testing.T) {
+	opts := NewServerOptions()
+	opts.ServerMode = "grpc"
+	opts.EnableMemoryStore = true
+
+	// 默认通过独立的指标服务器暴露指标
+	assert.True(t, opts.MetricsOptions.Enabled)
+	assert.Equal(t, ":7702", opts.MetricsOptions.Addr)
+
+	// 没有配置指标服务器地址时，只会禁用指标，不会校验失败
+	opts.MetricsOptions.Addr = ""
+	assert.NoError(t, opts.Validate())
+}
@@ -0,0 +1,204 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"syscall"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+
+	"github.com/ra1n6ow/opsx/cmd/opsx-usercenter/app/options"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/internal/usercenter"
+)
+
+// reloader 负责在运行时重新加载配置.
+//
+// 配置文件发生变化或者进程收到 SIGHUP 信号时，reloader 重新读取配置并使用 ServerOptions.Validate 校验，
+// 校验通过后在运行时应用支持热加载的配置：日志级别、JWT Token 的过期时间和限流配置.
+// 其他配置需要重启服务才能生效，发生变化时会被忽略并记录告警日志.
+//
+// viper 不是并发安全的. 服务启动后，所有对全局 viper 实例的读写（包括重新读取配置文件）都只在 run 所在的 goroutine 中进行，
+// 因此没有使用 viper.WatchConfig（它会在自己的 goroutine 中重新读取配置文件），而是由 watchConfigFile 监听配置文件变化.
+type reloader struct {
+	// opts 为当前生效的配置.
+	opts *options.ServerOptions
+	// logOpts 为当前生效的日志配置.
+	logOpts *log.Options
+	server  *usercenter.UnionServer
+
+	// triggers 用于串行处理所有的重新加载请求，值为触发重新加载的来源.
+	triggers chan string
+	signals  chan os.Signal
+	done     chan struct{}
+}
+
+// watchConfig 监听配置文件变化和 SIGHUP 信号，并在运行时重新加载配置. 返回的函数用于停止监听.
+func watchConfig(opts *options.ServerOptions, logOpts *log.Options, server *usercenter.UnionServer) func() {
+	r := &reloader{
+		opts:     opts,
+		logOpts:  logOpts,
+		server:   server,
+		triggers: make(chan string, 1),
+		signals:  make(chan os.Signal, 1),
+		done:     make(chan struct{}),
+	}
+
+	// 没有使用配置文件时，只能通过 SIGHUP 重新读取环境变量
+	if file := viper.ConfigFileUsed(); file != "" {
+		if err := watchConfigFile(file, func() { r.trigger("file") }, r.done); err != nil {
+			log.Errorw("Failed to watch configuration file, only SIGHUP can reload the configuration", "file", file, "err", err)
+		}
+	}
+
+	signal.Notify(r.signals, syscall.SIGHUP)
+	go r.run()
+
+	return func() {
+		signal.Stop(r.signals)
+		close(r.done)
+	}
+}
+
+// trigger 请求重新加载配置. 已经有待处理的请求时，本次请求会被合并.
+func (r *reloader) trigger(source string) {
+	select {
+	case r.triggers <- source:
+	default:
+	}
+}
+
+// run 串行处理配置重新加载请求，直到停止监听.
+func (r *reloader) run() {
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-r.signals:
+			r.reload("SIGHUP")
+		case source := <-r.triggers:
+			r.reload(source)
+		}
+	}
+}
+
+// watchConfigFile 监听配置文件 file 的变化，在配置文件被写入、重新创建，或者其符号链接指向的文件发生变化时
+// （例如 Kubernetes 更新挂载的 ConfigMap）调用 onChange，直到 done 被关闭.
+// 监听的是配置文件所在的目录，以便在编辑器通过重命名的方式保存文件时也能收到通知.
+func watchConfigFile(file string, onChange func(), done <-chan struct{}) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	configFile := filepath.Clean(file)
+	if err := watcher.Add(filepath.Dir(configFile)); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+	realConfigFile, _ := filepath.EvalSymlinks(configFile)
+
+	go func() {
+		defer watcher.Close()
+
+		for {
+			select {
+			case <-done:
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				currentConfigFile, _ := filepath.EvalSymlinks(configFile)
+				written := filepath.Clean(event.Name) == configFile && (event.Has(fsnotify.Write) || event.Has(fsnotify.Create))
+				relinked := currentConfigFile != "" && currentConfigFile != realConfigFile
+				if written || relinked {
+					realConfigFile = currentConfigFile
+					onChange()
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Errorw("Failed to watch configuration file", "file", file, "err", err)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reload 将 viper 中的配置解析为新的配置，校验通过后应用其中支持热加载的部分.
+func (r *reloader) reload(source string) {
+	log.Infow("Reloading configuration", "source", source, "file", viper.ConfigFileUsed())
+
+	// 没有使用配置文件时，只重新读取环境变量
+	if err := viper.ReadInConfig(); err != nil && viper.ConfigFileUsed() != "" {
+		log.Errorw("Failed to reload configuration, keeping the current configuration", "source", source, "err", err)
+		return
+	}
+
+	// 在当前配置的副本上解析，避免解析失败时污染当前配置
+	newOpts := r.opts.Clone()
+	if err := viper.Unmarshal(newOpts); err != nil {
+		log.Errorw("Failed to reload configuration, keeping the current configuration", "source", source, "err", err)
+		return
+	}
+	if err := newOpts.Validate(); err != nil {
+		log.Errorw("Invalid configuration, keeping the current configuration", "source", source, "err", err)
+		return
+	}
+
+	// 需要重启服务才能生效的配置不会被应用
+	restartRequired := r.opts.RestartRequired(newOpts)
+	newLogOpts, err := logOptions()
+	if err != nil {
+		log.Errorw("Invalid log configuration, keeping the current configuration", "source", source, "err", err)
+		return
+	}
+	if !logOptionsEqualExceptLevel(r.logOpts, newLogOpts) {
+		restartRequired = append(restartRequired, "log")
+	}
+	if len(restartRequired) > 0 {
+		log.Warnw("Ignoring configuration changes that require a restart", "source", source, "options", restartRequired)
+	}
+
+	cfg, err := newOpts.Config()
+	if err != nil {
+		log.Errorw("Failed to reload configuration, keeping the current configuration", "source", source, "err", err)
+		return
+	}
+
+	// 日志级别没有变化时不重新设置，避免取消通过管理接口临时修改的日志级别
+	if newLogOpts.Level != r.logOpts.Level {
+		if err := log.SetLevel(newLogOpts.Level); err != nil {
+			log.Errorw("Invalid log level, keeping the current configuration", "source", source, "err", err)
+			return
+		}
+	}
+	r.server.Reload(cfg)
+
+	// 只记录已经应用的配置，以便下次重新加载时仍能报告需要重启的配置变化
+	r.opts.Expiration = newOpts.Expiration
+	r.opts.RateLimitOptions = newOpts.RateLimitOptions
+	r.logOpts.Level = newLogOpts.Level
+
+	log.Infow("Configuration reloaded", "source", source, "log-level", log.Level(),
+		"expiration", newOpts.Expiration.String(), "rate-limit", newOpts.RateLimitOptions)
+}
+
+// logOptionsEqualExceptLevel 判断除日志级别外，a 和 b 的日志配置是否相同.
+func logOptionsEqualExceptLevel(a, b *log.Options) bool {
+	aCopy, bCopy := *a, *b
+	aCopy.Level, bCopy.Level = "", ""
+	return reflect.DeepEqual(aCopy, bCopy)
+}
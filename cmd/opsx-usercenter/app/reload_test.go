@@ -0,0 +1,58 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "opsx-usercenter.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("log:\n  level: info\n"), 0o600))
+
+	changes := make(chan struct{}, 16)
+	done := make(chan struct{})
+	defer close(done)
+	require.NoError(t, watchConfigFile(file, func() { changes <- struct{}{} }, done))
+
+	waitChange := func(msg string) {
+		t.Helper()
+		select {
+		case <-changes:
+		case <-time.After(5 * time.Second):
+			t.Fatal(msg)
+		}
+		// 丢弃同一次修改产生的多个事件
+		time.Sleep(50 * time.Millisecond)
+		for len(changes) > 0 {
+			<-changes
+		}
+	}
+
+	// 直接写入配置文件
+	require.NoError(t, os.WriteFile(file, []byte("log:\n  level: debug\n"), 0o600))
+	waitChange("writing the configuration file should be notified")
+
+	// 通过重命名的方式替换配置文件
+	tmp := filepath.Join(dir, "opsx-usercenter.yaml.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("log:\n  level: warn\n"), 0o600))
+	require.NoError(t, os.Rename(tmp, file))
+	waitChange("replacing the configuration file should be notified")
+
+	// 修改同一目录下的其他文件不会触发重新加载
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.yaml"), nil, 0o600))
+	select {
+	case <-changes:
+		t.Fatal("writing other files should not be notified")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -61,7 +61,11 @@ func run(opts *options.ServerOptions) error {
 	version.PrintAndExitIfRequested()
 
 	// 初始化日志
-	log.Init(logOptions())
+	logOpts, err := logOptions()
+	if err != nil {
+		return err
+	}
+	log.Init(logOpts)
 	// 确保日志在退出时被刷新到磁盘
 	defer log.Sync()
 
@@ -88,13 +92,17 @@ func run(opts *options.ServerOptions) error {
 		return err
 	}
 
+	// 监听配置文件变化和 SIGHUP 信号，在运行时重新加载配置
+	stop := watchConfig(opts, logOpts, server)
+	defer stop()
+
 	// 启动服务器
 	return server.Run()
 }
 
 // logOptions 从 viper 中读取日志配置，构建 *log.Options 并返回.
 // 注意：viper.Get<Type>() 中 key 的名字需要使用 . 分割，以跟 YAML 中保持相同的缩进.
-func logOptions() *log.Options {
+func logOptions() (*log.Options, error) {
 	opts := log.NewOptions()
 	if viper.IsSet("log.disable-caller") {
 		opts.DisableCaller = viper.GetBool("log.disable-caller")
@@ -114,5 +122,35 @@ func logOptions() *log.Options {
 	if viper.IsSet("log.output-paths") {
 		opts.OutputPaths = viper.GetStringSlice("log.output-paths")
 	}
-	return opts
+	if viper.IsSet("log.rotation.max-size") {
+		opts.Rotation.MaxSize = viper.GetInt("log.rotation.max-size")
+	}
+	if viper.IsSet("log.rotation.max-age") {
+		opts.Rotation.MaxAge = viper.GetInt("log.rotation.max-age")
+	}
+	if viper.IsSet("log.rotation.max-backups") {
+		opts.Rotation.MaxBackups = viper.GetInt("log.rotation.max-backups")
+	}
+	if viper.IsSet("log.rotation.local-time") {
+		opts.Rotation.LocalTime = viper.GetBool("log.rotation.local-time")
+	}
+	if viper.IsSet("log.rotation.compress") {
+		opts.Rotation.Compress = viper.GetBool("log.rotation.compress")
+	}
+	if viper.IsSet("log.sampling.initial") {
+		opts.Sampling.Initial = viper.GetInt("log.sampling.initial")
+	}
+	if viper.IsSet("log.sampling.thereafter") {
+		opts.Sampling.Thereafter = viper.GetInt("log.sampling.thereafter")
+	}
+	if viper.IsSet("log.sampling.tick") {
+		opts.Sampling.Tick = viper.GetDuration("log.sampling.tick")
+	}
+	// sinks 为列表，每一项包含 level、max-level、format、enable-color、output-paths 和 rotation
+	if viper.IsSet("log.sinks") {
+		if err := viper.UnmarshalKey("log.sinks", &opts.Sinks); err != nil {
+			return nil, err
+		}
+	}
+	return opts, nil
 }
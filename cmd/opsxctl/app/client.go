@@ -0,0 +1,246 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/ra1n6ow/opsx/cmd/opsxctl/app/options"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/client/usercenter"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+// newClient 根据 opts 创建访问 Usercenter 服务的客户端. 返回的函数用于释放客户端持有的连接.
+// 无论使用哪种协议，客户端都实现了 ucv1.UsercenterClient 接口，返回的错误均为 *errorsx.ErrorX.
+func newClient(opts *options.ClientOptions, server, token string) (ucv1.UsercenterClient, func() error, error) {
+	if opts.Protocol == options.ProtocolHTTP {
+		client, err := newHTTPClient(opts, server, token)
+		if err != nil {
+			return nil, nil, err
+		}
+		return client, func() error { return nil }, nil
+	}
+
+	grpcOptions := &genericoptions.GRPCOptions{
+		Network:    "tcp",
+		Addr:       server,
+		TLSOptions: opts.TLSOptions,
+	}
+	client, err := usercenter.NewClient(grpcOptions, usercenter.WithToken(token), usercenter.WithTimeout(opts.Timeout))
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, client.Close, nil
+}
+
+// httpClient 通过 HTTP 访问 Usercenter 服务，实现了 ucv1.UsercenterClient 接口.
+// 请求的路径和方法与 usercenter.proto 中的 google.api.http 注解保持一致，gRPC-Gateway 和 Gin 服务器均可访问.
+type httpClient struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// 确保 httpClient 实现了 ucv1.UsercenterClient 接口.
+var _ ucv1.UsercenterClient = (*httpClient)(nil)
+
+// 与服务端保持一致的序列化选项.
+var (
+	marshalOptions   = protojson.MarshalOptions{UseEnumNumbers: true}
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+)
+
+// newHTTPClient 创建一个 httpClient 实例. server 没有指定 scheme 时，根据是否开启 TLS 使用 http 或 https.
+func newHTTPClient(opts *options.ClientOptions, server, token string) (*httpClient, error) {
+	baseURL := server
+	if !strings.Contains(server, "://") {
+		scheme := "http"
+		if opts.TLSOptions.UseTLS {
+			scheme = "https"
+		}
+		baseURL = scheme + "://" + server
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := opts.TLSOptions.ClientTLSConfig(u.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	return &httpClient{
+		client:  &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		token:   token,
+	}, nil
+}
+
+// Healthz 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) Healthz(ctx context.Context, in *emptypb.Empty, _ ...grpc.CallOption) (*ucv1.HealthzResponse, error) {
+	out := &ucv1.HealthzResponse{}
+	return out, c.do(ctx, http.MethodGet, "/healthz", nil, out)
+}
+
+// Login 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) Login(ctx context.Context, in *ucv1.LoginRequest, _ ...grpc.CallOption) (*ucv1.LoginResponse, error) {
+	out := &ucv1.LoginResponse{}
+	return out, c.do(ctx, http.MethodPost, "/login", in, out)
+}
+
+// RefreshToken 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) RefreshToken(ctx context.Context, in *ucv1.RefreshTokenRequest, _ ...grpc.CallOption) (*ucv1.RefreshTokenResponse, error) {
+	out := &ucv1.RefreshTokenResponse{}
+	return out, c.do(ctx, http.MethodPut, "/refresh-token", in, out)
+}
+
+// CreateUser 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) CreateUser(ctx context.Context, in *ucv1.CreateUserRequest, _ ...grpc.CallOption) (*ucv1.CreateUserResponse, error) {
+	out := &ucv1.CreateUserResponse{}
+	return out, c.do(ctx, http.MethodPost, "/v1/users", in, out)
+}
+
+// UpdateUser 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) UpdateUser(ctx context.Context, in *ucv1.UpdateUserRequest, _ ...grpc.CallOption) (*ucv1.UpdateUserResponse, error) {
+	out := &ucv1.UpdateUserResponse{}
+	return out, c.do(ctx, http.MethodPut, "/v1/users/"+url.PathEscape(in.GetUserID()), in, out)
+}
+
+// DeleteUser 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) DeleteUser(ctx context.Context, in *ucv1.DeleteUserRequest, _ ...grpc.CallOption) (*ucv1.DeleteUserResponse, error) {
+	out := &ucv1.DeleteUserResponse{}
+	return out, c.do(ctx, http.MethodDelete, "/v1/users/"+url.PathEscape(in.GetUserID()), nil, out)
+}
+
+// GetUser 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) GetUser(ctx context.Context, in *ucv1.GetUserRequest, _ ...grpc.CallOption) (*ucv1.GetUserResponse, error) {
+	out := &ucv1.GetUserResponse{}
+	return out, c.do(ctx, http.MethodGet, "/v1/users/"+url.PathEscape(in.GetUserID()), nil, out)
+}
+
+// ListUsers 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) ListUsers(ctx context.Context, in *ucv1.ListUsersRequest, _ ...grpc.CallOption) (*ucv1.ListUsersResponse, error) {
+	query := url.Values{}
+	query.Set("offset", strconv.FormatInt(in.GetOffset(), 10))
+	query.Set("limit", strconv.FormatInt(in.GetLimit(), 10))
+
+	out := &ucv1.ListUsersResponse{}
+	return out, c.do(ctx, http.MethodGet, "/v1/users?"+query.Encode(), nil, out)
+}
+
+// AssignRole 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) AssignRole(ctx context.Context, in *ucv1.AssignRoleRequest, _ ...grpc.CallOption) (*ucv1.AssignRoleResponse, error) {
+	out := &ucv1.AssignRoleResponse{}
+	return out, c.do(ctx, http.MethodPost, "/v1/users/"+url.PathEscape(in.GetUserID())+"/roles", in, out)
+}
+
+// RevokeRole 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) RevokeRole(ctx context.Context, in *ucv1.RevokeRoleRequest, _ ...grpc.CallOption) (*ucv1.RevokeRoleResponse, error) {
+	out := &ucv1.RevokeRoleResponse{}
+	return out, c.do(ctx, http.MethodDelete, "/v1/users/"+url.PathEscape(in.GetUserID())+"/roles/"+url.PathEscape(in.GetRole()), nil, out)
+}
+
+// ListUserRoles 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) ListUserRoles(ctx context.Context, in *ucv1.ListUserRolesRequest, _ ...grpc.CallOption) (*ucv1.ListUserRolesResponse, error) {
+	out := &ucv1.ListUserRolesResponse{}
+	return out, c.do(ctx, http.MethodGet, "/v1/users/"+url.PathEscape(in.GetUserID())+"/roles", nil, out)
+}
+
+// GetLogLevel 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) GetLogLevel(ctx context.Context, in *ucv1.GetLogLevelRequest, _ ...grpc.CallOption) (*ucv1.GetLogLevelResponse, error) {
+	out := &ucv1.GetLogLevelResponse{}
+	return out, c.do(ctx, http.MethodGet, "/v1/admin/log-level", nil, out)
+}
+
+// UpdateLogLevel 实现 ucv1.UsercenterClient 接口.
+func (c *httpClient) UpdateLogLevel(ctx context.Context, in *ucv1.UpdateLogLevelRequest, _ ...grpc.CallOption) (*ucv1.UpdateLogLevelResponse, error) {
+	out := &ucv1.UpdateLogLevelResponse{}
+	return out, c.do(ctx, http.MethodPut, "/v1/admin/log-level", in, out)
+}
+
+// do 发送 HTTP 请求，并将响应解析到 out 中. in 不为 nil 时作为 JSON 请求体发送.
+func (c *httpClient) do(ctx context.Context, method, path string, in, out proto.Message) error {
+	var body io.Reader
+	if in != nil {
+		data, err := marshalOptions.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errorsx.FromError(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// 健康检查接口在服务不健康时返回 503，但响应体仍为 HealthzResponse
+	if resp.StatusCode/100 != 2 && !(resp.StatusCode == http.StatusServiceUnavailable && strings.HasPrefix(path, "/healthz")) {
+		return decodeHTTPError(resp.StatusCode, data)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	return unmarshalOptions.Unmarshal(data, out)
+}
+
+// decodeHTTPError 将 HTTP 错误响应转换为 *errorsx.ErrorX.
+// Gin 服务器和 gRPC-Gateway 均返回 core.ErrorResponse 格式的错误.
+func decodeHTTPError(code int, data []byte) *errorsx.ErrorX {
+	var body struct {
+		Reason           string                    `json:"reason"`
+		Message          string                    `json:"message"`
+		Metadata         map[string]string         `json:"metadata"`
+		FieldViolations  []errorsx.FieldViolation  `json:"fieldViolations"`
+		RetryDelay       string                    `json:"retryDelay"`
+		LocalizedMessage *errorsx.LocalizedMessage `json:"localizedMessage"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil || (body.Reason == "" && body.Message == "") {
+		return errorsx.New(code, errorsx.ErrInternal.Reason, "%s", strings.TrimSpace(fmt.Sprintf("%s %s", http.StatusText(code), data)))
+	}
+
+	errx := errorsx.New(code, body.Reason, "%s", body.Message).WithMetadata(body.Metadata)
+	if errx.Reason == "" {
+		errx.Reason = errorsx.ErrInternal.Reason
+	}
+	errx.FieldViolations = body.FieldViolations
+	errx.LocalizedMessage = body.LocalizedMessage
+	if delay, err := time.ParseDuration(body.RetryDelay); err == nil {
+		errx.RetryDelay = delay
+	}
+	return errx
+}
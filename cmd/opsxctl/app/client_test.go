@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/cmd/opsxctl/app/options"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+func TestHTTPClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/users/user-1/roles":
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"roles":["admin"]}`))
+		case "/v1/users":
+			assert.Equal(t, "5", r.URL.Query().Get("limit"))
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"reason":"PermissionDenied","message":"Permission denied."}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"code":404,"reason":"NotFound.UserNotFound","message":"User not found.","metadata":{"X-Request-ID":"request-1"}}`))
+		}
+	}))
+	defer srv.Close()
+
+	opts := options.NewClientOptions()
+	client, err := newHTTPClient(opts, srv.URL, "secret")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	roles, err := client.ListUserRoles(ctx, &ucv1.ListUserRolesRequest{UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"admin"}, roles.GetRoles())
+
+	_, err = client.ListUsers(ctx, &ucv1.ListUsersRequest{Limit: 5})
+	assert.ErrorIs(t, err, errorsx.ErrPermissionDenied)
+
+	_, err = client.GetUser(ctx, &ucv1.GetUserRequest{UserID: "user-2"})
+	errx := errorsx.FromError(err)
+	assert.Equal(t, http.StatusNotFound, errx.Code)
+	assert.Equal(t, "NotFound.UserNotFound", errx.Reason)
+	assert.Equal(t, "User not found.", errx.Message)
+	assert.Equal(t, "request-1", errx.Metadata["X-Request-ID"])
+}
+
+func TestCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".opsx", "credentials")
+
+	creds, err := loadCredentials(path)
+	require.NoError(t, err)
+	assert.Empty(t, creds.Token)
+
+	want := &Credentials{Server: "localhost:7701", Protocol: "grpc", Username: "alice", Token: "token", ExpireAt: time.Now().Add(time.Hour).UTC()}
+	require.NoError(t, saveCredentials(path, want))
+
+	creds, err = loadCredentials(path)
+	require.NoError(t, err)
+	assert.Equal(t, want, creds)
+	assert.False(t, creds.Expired())
+
+	require.NoError(t, removeCredentials(path))
+	require.NoError(t, removeCredentials(path))
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultConfigDir 定义放置 opsx 项目配置的默认目录.
+	defaultConfigDir = ".opsx"
+
+	// defaultCredentialsName 指定 opsxctl 保存登录凭证的文件名.
+	defaultCredentialsName = "credentials"
+)
+
+// Credentials 是 opsxctl 登录后保存的凭证.
+type Credentials struct {
+	// Server 为登录时使用的服务器地址.
+	Server string `yaml:"server"`
+	// Protocol 为登录时使用的协议.
+	Protocol string `yaml:"protocol"`
+	// Username 为登录的用户名.
+	Username string `yaml:"username"`
+	// Token 为登录后获得的令牌.
+	Token string `yaml:"token"`
+	// ExpireAt 为令牌的过期时间.
+	ExpireAt time.Time `yaml:"expireAt"`
+}
+
+// Expired 判断令牌是否已经过期.
+func (c *Credentials) Expired() bool {
+	return !c.ExpireAt.IsZero() && time.Now().After(c.ExpireAt)
+}
+
+// credentialsFile 返回凭证文件的路径，默认为 ~/.opsx/credentials.
+func credentialsFile() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, defaultConfigDir, defaultCredentialsName), nil
+}
+
+// loadCredentials 从 path 读取凭证. 凭证文件不存在时返回空的凭证.
+func loadCredentials(path string) (*Credentials, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Credentials{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	creds := &Credentials{}
+	if err := yaml.Unmarshal(data, creds); err != nil {
+		return nil, err
+	}
+	return creds, nil
+}
+
+// saveCredentials 将凭证保存到 path. 凭证中包含令牌，文件仅当前用户可读写.
+func saveCredentials(path string, creds *Credentials) error {
+	data, err := yaml.Marshal(creds)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// removeCredentials 删除 path 处的凭证文件. 凭证文件不存在时不返回错误.
+func removeCredentials(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"context"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// newHealthCommand 创建 health 子命令，查询服务及其各组件的健康状态.
+func newHealthCommand(f *factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "health",
+		Short: "Show the health of the usercenter server and its components",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.Healthz(ctx, &emptypb.Empty{})
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					table.AddRow("COMPONENT", "STATUS", "MESSAGE")
+					table.AddRow("server", resp.GetStatus().String(), resp.GetMessage())
+					for _, component := range resp.GetComponents() {
+						table.AddRow(component.GetName(), component.GetStatus().String(), component.GetMessage())
+					}
+				})
+			})
+		},
+	}
+}
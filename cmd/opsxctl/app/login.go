@@ -0,0 +1,95 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// newLoginCommand 创建 login 子命令，登录成功后将令牌保存到凭证文件中.
+func newLoginCommand(f *factory) *cobra.Command {
+	var username, password string
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to the usercenter server and save the token",
+		Example: `  # 登录默认的 gRPC 服务器
+  opsxctl login -u admin -p <password>
+
+  # 通过 HTTP 登录，密码从标准输入读取
+  echo <password> | opsxctl login --protocol http -s localhost:7700 -u admin`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if username == "" {
+				return errors.New("username must be specified")
+			}
+			if password == "" {
+				fmt.Fprint(cmd.ErrOrStderr(), "Password: ")
+				line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if err != nil && line == "" {
+					return fmt.Errorf("failed to read password: %w", err)
+				}
+				password = strings.TrimRight(line, "\r\n")
+			}
+
+			// 登录时不携带之前保存的令牌
+			f.opts.Token, f.creds.Token = "", ""
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.Login(ctx, &ucv1.LoginRequest{Username: username, Password: password})
+				if err != nil {
+					return err
+				}
+
+				creds := &Credentials{
+					Server:   f.server(),
+					Protocol: f.opts.Protocol,
+					Username: username,
+					Token:    resp.GetToken(),
+					ExpireAt: resp.GetExpireAt().AsTime(),
+				}
+				if err := saveCredentials(f.credsFile, creds); err != nil {
+					return fmt.Errorf("failed to save credentials to %s: %w", f.credsFile, err)
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					table.AddRow("USERNAME", "SERVER", "EXPIRE AT")
+					table.AddRow(username, creds.Server, creds.ExpireAt.Local().Format(time.DateTime))
+				})
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&username, "username", "u", username, "Username to log in with.")
+	cmd.Flags().StringVarP(&password, "password", "p", password, "Password to log in with. Read from stdin if not specified.")
+
+	return cmd
+}
+
+// newLogoutCommand 创建 logout 子命令，删除保存的凭证.
+func newLogoutCommand(f *factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Remove the saved credentials",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := removeCredentials(f.credsFile); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Logged out")
+			return nil
+		},
+	}
+}
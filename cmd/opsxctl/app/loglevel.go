@@ -0,0 +1,111 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// newLogLevelCommand 创建 log-level 子命令，用于查询和修改服务运行时的日志级别.
+func newLogLevelCommand(f *factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "log-level",
+		Short: "Show or change the log level of the usercenter server",
+		Args:  cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newLogLevelGetCommand(f),
+		newLogLevelSetCommand(f),
+	)
+
+	return cmd
+}
+
+// newLogLevelGetCommand 创建 log-level get 子命令.
+func newLogLevelGetCommand(f *factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get",
+		Short: "Show the global and module log levels",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.GetLogLevel(ctx, &ucv1.GetLogLevelRequest{})
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					addLogLevelRows(table, resp.GetLevel(), resp.GetExpireAt(), resp.GetModules())
+				})
+			})
+		},
+	}
+}
+
+// newLogLevelSetCommand 创建 log-level set 子命令.
+func newLogLevelSetCommand(f *factory) *cobra.Command {
+	var (
+		module string
+		ttl    time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "set [LEVEL]",
+		Short: "Change the global or module log level",
+		Example: `  # Set the global log level to debug for 10 minutes
+  opsxctl log-level set debug --ttl 10m
+
+  # Set the log level of the middleware module to debug
+  opsxctl log-level set debug --module middleware
+
+  # Remove the log level of the middleware module
+  opsxctl log-level set --module middleware`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rq := &ucv1.UpdateLogLevelRequest{Module: module}
+			if len(args) > 0 {
+				rq.Level = args[0]
+			}
+			if ttl > 0 {
+				rq.Ttl = durationpb.New(ttl)
+			}
+
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.UpdateLogLevel(ctx, rq)
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					addLogLevelRows(table, resp.GetLevel(), resp.GetExpireAt(), resp.GetModules())
+				})
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&module, "module", module, "Module to change the log level of. The global log level is changed if empty.")
+	cmd.Flags().DurationVar(&ttl, "ttl", ttl, "Time after which the log level is reverted. The change is permanent if zero.")
+
+	return cmd
+}
+
+// addLogLevelRows 将全局和模块级别的日志级别添加到表格中.
+func addLogLevelRows(table *uitable.Table, level string, expireAt *timestamppb.Timestamp, modules []*ucv1.ModuleLogLevel) {
+	table.AddRow("MODULE", "LEVEL", "EXPIRE AT")
+	table.AddRow("(global)", level, formatTime(expireAt))
+	for _, module := range modules {
+		table.AddRow(module.GetModule(), module.GetLevel(), formatTime(module.GetExpireAt()))
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ra1n6ow/opsx/cmd/opsxctl/app/options"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// NewOpsxCtlCommand 创建一个 *cobra.Command 对象，作为 opsxctl 的根命令.
+func NewOpsxCtlCommand() *cobra.Command {
+	// 创建默认的命令行选项
+	f := &factory{opts: options.NewClientOptions()}
+
+	cmd := &cobra.Command{
+		// 指定命令的名字，该名字会出现在帮助信息中
+		Use: "opsxctl",
+		// 命令的简短描述
+		Short: "opsxctl controls the opsx usercenter service",
+		// 命令的详细描述
+		Long: `opsxctl is the command-line client of the opsx usercenter service.
+
+It talks to usercenter over gRPC or HTTP. Run "opsxctl login" first, the token is saved
+to ~/.opsx/credentials and used by the other commands.`,
+		// 命令出错时，不打印帮助信息。设置为 true 可以确保命令出错时一眼就能看到错误信息
+		SilenceUsage: true,
+		// 在执行任意子命令之前，校验命令行选项并加载登录凭证
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return f.complete()
+		},
+		// 设置命令运行时的参数检查，不需要指定命令行参数
+		Args: cobra.NoArgs,
+	}
+
+	// 将 ClientOptions 中的选项绑定到命令标志
+	f.opts.AddFlags(cmd.PersistentFlags())
+
+	cmd.AddCommand(
+		newLoginCommand(f),
+		newLogoutCommand(f),
+		newHealthCommand(f),
+		newUserCommand(f),
+		newRoleCommand(f),
+		newLogLevelCommand(f),
+		newVersionCommand(f),
+	)
+
+	return cmd
+}
+
+// factory 保存子命令共用的命令行选项和登录凭证，并据此创建客户端和输出器.
+type factory struct {
+	opts *options.ClientOptions
+
+	// credsFile 为凭证文件的路径.
+	credsFile string
+	// creds 为登录时保存的凭证.
+	creds *Credentials
+}
+
+// complete 校验命令行选项并加载登录凭证.
+func (f *factory) complete() error {
+	if err := f.opts.Validate(); err != nil {
+		return err
+	}
+
+	credsFile, err := credentialsFile()
+	if err != nil {
+		return err
+	}
+	creds, err := loadCredentials(credsFile)
+	if err != nil {
+		return fmt.Errorf("failed to load credentials from %s: %w", credsFile, err)
+	}
+	f.credsFile, f.creds = credsFile, creds
+
+	return nil
+}
+
+// server 返回要连接的服务器地址. 优先使用 --server 指定的地址，其次使用登录时（相同协议）保存的地址，最后使用默认地址.
+func (f *factory) server() string {
+	if f.opts.Server != "" {
+		return f.opts.Server
+	}
+	if f.creds.Server != "" && f.creds.Protocol == f.opts.Protocol {
+		return f.creds.Server
+	}
+	return options.DefaultServer(f.opts.Protocol)
+}
+
+// token 返回访问服务使用的令牌. 优先使用 --token 指定的令牌，其次使用登录时保存的、尚未过期的令牌.
+func (f *factory) token() string {
+	if f.opts.Token != "" {
+		return f.opts.Token
+	}
+	if f.creds.Expired() {
+		return ""
+	}
+	return f.creds.Token
+}
+
+// client 创建访问 Usercenter 服务的客户端.
+func (f *factory) client() (ucv1.UsercenterClient, func() error, error) {
+	return newClient(f.opts, f.server(), f.token())
+}
+
+// printer 返回按照 --output 格式向 cmd 的标准输出写入结果的输出器.
+func (f *factory) printer(cmd *cobra.Command) *printer {
+	return &printer{out: cmd.OutOrStdout(), format: f.opts.Output}
+}
+
+// run 创建客户端和带有超时时间的 context，并调用 fn. 返回的错误为适合展示给用户的错误.
+func (f *factory) run(fn func(ctx context.Context, client ucv1.UsercenterClient) error) error {
+	client, closeFn, err := f.client()
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	ctx, cancel := context.WithTimeout(context.Background(), f.opts.Timeout)
+	defer cancel()
+
+	return displayError(fn(ctx, client))
+}
+
+// displayError 将服务端返回的 *errorsx.ErrorX 转换为适合展示给用户的错误.
+// 优先展示本地化后的错误信息，并列出字段校验错误和重试等待时间.
+func displayError(err error) error {
+	if err == nil {
+		return nil
+	}
+	errx := new(errorsx.ErrorX)
+	if !errors.As(err, &errx) {
+		return err
+	}
+
+	message := errx.Message
+	if errx.LocalizedMessage != nil && errx.LocalizedMessage.Message != "" {
+		message = errx.LocalizedMessage.Message
+	}
+	if errx.RetryDelay > 0 {
+		message += fmt.Sprintf(", retry after %s", errx.RetryDelay)
+	}
+
+	message = fmt.Sprintf("%s (reason: %s)", message, errx.Reason)
+	// 登录时用户名或密码错误不需要提示重新登录
+	if errx.Code == errorsx.ErrUnauthenticated.Code && errx.Reason != errno.ErrInvalidCredentials.Reason {
+		message += `, please run "opsxctl login" first`
+	}
+	for _, v := range errx.FieldViolations {
+		message += fmt.Sprintf("\n  %s: %s", v.Field, v.Description)
+	}
+	return errors.New(message)
+}
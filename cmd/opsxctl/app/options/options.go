@@ -0,0 +1,101 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+	"github.com/spf13/pflag"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// ProtocolGRPC 表示通过 gRPC 访问 Usercenter 服务.
+	ProtocolGRPC = "grpc"
+	// ProtocolHTTP 表示通过 HTTP（gRPC-Gateway 或 Gin 服务器）访问 Usercenter 服务.
+	ProtocolHTTP = "http"
+
+	// OutputTable 表示以表格格式输出.
+	OutputTable = "table"
+	// OutputJSON 表示以 JSON 格式输出.
+	OutputJSON = "json"
+	// OutputYAML 表示以 YAML 格式输出.
+	OutputYAML = "yaml"
+)
+
+var (
+	// 定义支持的协议集合.
+	availableProtocols = sets.New(ProtocolGRPC, ProtocolHTTP)
+	// 定义支持的输出格式集合.
+	availableOutputs = sets.New(OutputTable, OutputJSON, OutputYAML)
+	// defaultServers 为各协议默认连接的服务器地址，与 opsx-usercenter 的默认监听地址保持一致.
+	defaultServers = map[string]string{ProtocolGRPC: "localhost:7701", ProtocolHTTP: "localhost:7700"}
+)
+
+// ClientOptions 包含 opsxctl 的命令行选项.
+type ClientOptions struct {
+	// Server 为 Usercenter 服务的地址. 为空时使用登录时保存的地址，仍为空时使用协议对应的默认地址.
+	Server string
+	// Protocol 为访问 Usercenter 服务使用的协议：grpc 或 http.
+	Protocol string
+	// Timeout 为单次请求的超时时间.
+	Timeout time.Duration
+	// Token 为访问 Usercenter 服务使用的令牌. 为空时使用登录时保存的令牌.
+	Token string
+	// Output 为输出格式：table、json 或 yaml.
+	Output string
+	// TLSOptions 为连接 Usercenter 服务使用的 TLS 配置.
+	TLSOptions *genericoptions.TLSOptions
+}
+
+// NewClientOptions 创建带有默认值的 ClientOptions 实例.
+func NewClientOptions() *ClientOptions {
+	return &ClientOptions{
+		Protocol:   ProtocolGRPC,
+		Timeout:    10 * time.Second,
+		Output:     OutputTable,
+		TLSOptions: genericoptions.NewTLSOptions(),
+	}
+}
+
+// AddFlags 将 ClientOptions 的选项绑定到命令行标志.
+func (o *ClientOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Server, "server", "s", o.Server, fmt.Sprintf("Address of the usercenter server. Defaults to the server used at login, "+
+		"or %s for grpc and %s for http.", defaultServers[ProtocolGRPC], defaultServers[ProtocolHTTP]))
+	fs.StringVar(&o.Protocol, "protocol", o.Protocol, fmt.Sprintf("Protocol used to talk to the usercenter server, available options: %v", sets.List(availableProtocols)))
+	fs.DurationVar(&o.Timeout, "timeout", o.Timeout, "Timeout of a single request.")
+	fs.StringVar(&o.Token, "token", o.Token, "Bearer token used to authenticate. Defaults to the token saved at login.")
+	fs.StringVarP(&o.Output, "output", "o", o.Output, fmt.Sprintf("Output format, available options: %v", sets.List(availableOutputs)))
+	o.TLSOptions.AddFlags(fs)
+	// 客户端认证模式仅用于服务端
+	_ = fs.MarkHidden("tls.client-auth")
+}
+
+// Validate 校验 ClientOptions 中的选项是否合法.
+func (o *ClientOptions) Validate() error {
+	errs := []error{}
+
+	if !availableProtocols.Has(o.Protocol) {
+		errs = append(errs, fmt.Errorf("invalid protocol %q: must be one of %v", o.Protocol, sets.List(availableProtocols)))
+	}
+	if !availableOutputs.Has(o.Output) {
+		errs = append(errs, fmt.Errorf("invalid output format %q: must be one of %v", o.Output, sets.List(availableOutputs)))
+	}
+	if o.Timeout <= 0 {
+		errs = append(errs, errors.New("timeout must be greater than 0"))
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+// DefaultServer 返回 protocol 协议默认连接的服务器地址.
+func DefaultServer(protocol string) string {
+	return defaultServers[protocol]
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gosuri/uitable"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ra1n6ow/opsx/cmd/opsxctl/app/options"
+)
+
+// printer 按照指定的格式输出命令的结果.
+type printer struct {
+	out    io.Writer
+	format string
+}
+
+// Print 输出 obj. 表格格式下，使用 toTable 构建表格；JSON 和 YAML 格式下，直接序列化 obj.
+// obj 为 Protobuf 消息时，使用 protojson 序列化，字段名与 HTTP 接口返回的字段名保持一致.
+func (p *printer) Print(obj any, toTable func(table *uitable.Table)) error {
+	switch p.format {
+	case options.OutputJSON:
+		data, err := marshalJSON(obj)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(p.out, string(data))
+		return err
+	case options.OutputYAML:
+		data, err := marshalYAML(obj)
+		if err != nil {
+			return err
+		}
+		_, err = p.out.Write(data)
+		return err
+	default:
+		table := uitable.New()
+		table.MaxColWidth = 80
+		toTable(table)
+		_, err := fmt.Fprintln(p.out, table.String())
+		return err
+	}
+}
+
+// marshalJSON 将 obj 序列化为缩进格式的 JSON.
+func marshalJSON(obj any) ([]byte, error) {
+	if msg, ok := obj.(proto.Message); ok {
+		// protojson 的输出格式不稳定（会随机插入空格），重新格式化以便输出稳定的结果
+		data, err := protojson.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		var v any
+		if err := json.Unmarshal(data, &v); err != nil {
+			return nil, err
+		}
+		obj = v
+	}
+	return json.MarshalIndent(obj, "", "  ")
+}
+
+// marshalYAML 将 obj 序列化为 YAML. 先序列化为 JSON，以便与 JSON 格式的输出使用相同的字段名和字段顺序.
+func marshalYAML(obj any) ([]byte, error) {
+	data, err := marshalJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析到 yaml.Node 中以保留 JSON 的字段顺序，并清除 JSON 的流式风格，输出块风格的 YAML
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	resetStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), encoder.Close()
+}
+
+// resetStyle 递归清除 node 的风格，使用默认的块风格输出.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
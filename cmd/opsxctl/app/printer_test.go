@@ -0,0 +1,40 @@
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gosuri/uitable"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/cmd/opsxctl/app/options"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+func TestPrinter(t *testing.T) {
+	resp := &ucv1.ListUserRolesResponse{Roles: []string{"admin", "user"}}
+	toTable := func(table *uitable.Table) {
+		table.AddRow("ROLE")
+		for _, role := range resp.GetRoles() {
+			table.AddRow(role)
+		}
+	}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: options.OutputTable, want: "ROLE \nadmin\nuser \n"},
+		{format: options.OutputJSON, want: "{\n  \"roles\": [\n    \"admin\",\n    \"user\"\n  ]\n}\n"},
+		{format: options.OutputYAML, want: "roles:\n  - admin\n  - user\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, (&printer{out: &buf, format: tt.format}).Print(resp, toTable))
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// newRoleCommand 创建 role 子命令，用于管理用户的角色.
+func newRoleCommand(f *factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "role",
+		Aliases: []string{"roles"},
+		Short:   "Manage roles of users",
+		Args:    cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newRoleAssignCommand(f),
+		newRoleRevokeCommand(f),
+		newRoleListCommand(f),
+	)
+
+	return cmd
+}
+
+// newRoleAssignCommand 创建 role assign 子命令.
+func newRoleAssignCommand(f *factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "assign USER_ID ROLE",
+		Short:   "Assign a role to a user",
+		Example: "  opsxctl role assign user-000001 admin",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.AssignRole(ctx, &ucv1.AssignRoleRequest{UserID: args[0], Role: args[1]})
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					table.AddRow(fmt.Sprintf("Role %s assigned to user %s", args[1], args[0]))
+				})
+			})
+		},
+	}
+}
+
+// newRoleRevokeCommand 创建 role revoke 子命令.
+func newRoleRevokeCommand(f *factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "revoke USER_ID ROLE",
+		Short:   "Revoke a role from a user",
+		Example: "  opsxctl role revoke user-000001 admin",
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.RevokeRole(ctx, &ucv1.RevokeRoleRequest{UserID: args[0], Role: args[1]})
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					table.AddRow(fmt.Sprintf("Role %s revoked from user %s", args[1], args[0]))
+				})
+			})
+		},
+	}
+}
+
+// newRoleListCommand 创建 role list 子命令，列出用户拥有的角色.
+func newRoleListCommand(f *factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "list USER_ID",
+		Aliases: []string{"ls"},
+		Short:   "List roles of a user",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.ListUserRoles(ctx, &ucv1.ListUserRolesRequest{UserID: args[0]})
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					table.AddRow("ROLE")
+					for _, role := range resp.GetRoles() {
+						table.AddRow(role)
+					}
+				})
+			})
+		},
+	}
+}
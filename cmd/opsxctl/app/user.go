@@ -0,0 +1,216 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// newUserCommand 创建 user 子命令，用于管理用户.
+func newUserCommand(f *factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "user",
+		Aliases: []string{"users"},
+		Short:   "Manage users",
+		Args:    cobra.NoArgs,
+	}
+
+	cmd.AddCommand(
+		newUserCreateCommand(f),
+		newUserGetCommand(f),
+		newUserListCommand(f),
+		newUserUpdateCommand(f),
+		newUserDeleteCommand(f),
+	)
+
+	return cmd
+}
+
+// newUserCreateCommand 创建 user create 子命令.
+func newUserCreateCommand(f *factory) *cobra.Command {
+	rq := &ucv1.CreateUserRequest{}
+	var nickname string
+
+	cmd := &cobra.Command{
+		Use:     "create",
+		Short:   "Create a user",
+		Example: "  opsxctl user create --username alice --password <password> --email alice@example.com --phone 18888888888",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("nickname") {
+				rq.Nickname = &nickname
+			}
+
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.CreateUser(ctx, rq)
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					table.AddRow("USER ID")
+					table.AddRow(resp.GetUserID())
+				})
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&rq.Username, "username", rq.Username, "Username of the user.")
+	cmd.Flags().StringVar(&rq.Password, "password", rq.Password, "Password of the user.")
+	cmd.Flags().StringVar(&nickname, "nickname", nickname, "Nickname of the user.")
+	cmd.Flags().StringVar(&rq.Email, "email", rq.Email, "Email of the user.")
+	cmd.Flags().StringVar(&rq.Phone, "phone", rq.Phone, "Phone number of the user.")
+
+	return cmd
+}
+
+// newUserGetCommand 创建 user get 子命令.
+func newUserGetCommand(f *factory) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get USER_ID",
+		Short: "Show a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.GetUser(ctx, &ucv1.GetUserRequest{UserID: args[0]})
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					addUserRows(table, resp.GetUser())
+				})
+			})
+		},
+	}
+}
+
+// newUserListCommand 创建 user list 子命令.
+func newUserListCommand(f *factory) *cobra.Command {
+	rq := &ucv1.ListUsersRequest{Limit: 20}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List users",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.ListUsers(ctx, rq)
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					addUserRows(table, resp.GetUsers()...)
+					table.AddRow("")
+					table.AddRow(fmt.Sprintf("Total: %d", resp.GetTotalCount()))
+				})
+			})
+		},
+	}
+
+	cmd.Flags().Int64Var(&rq.Offset, "offset", rq.Offset, "Number of users to skip.")
+	cmd.Flags().Int64Var(&rq.Limit, "limit", rq.Limit, "Maximum number of users to list.")
+
+	return cmd
+}
+
+// newUserUpdateCommand 创建 user update 子命令. 只更新通过命令行标志指定的字段.
+func newUserUpdateCommand(f *factory) *cobra.Command {
+	var username, nickname, email, phone string
+
+	cmd := &cobra.Command{
+		Use:     "update USER_ID",
+		Short:   "Update a user",
+		Example: "  opsxctl user update user-000001 --nickname alice --email alice@example.com",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rq := &ucv1.UpdateUserRequest{UserID: args[0]}
+			for name, field := range map[string]struct {
+				value  *string
+				target **string
+			}{
+				"username": {&username, &rq.Username},
+				"nickname": {&nickname, &rq.Nickname},
+				"email":    {&email, &rq.Email},
+				"phone":    {&phone, &rq.Phone},
+			} {
+				if cmd.Flags().Changed(name) {
+					*field.target = field.value
+				}
+			}
+			if rq.Username == nil && rq.Nickname == nil && rq.Email == nil && rq.Phone == nil {
+				return errors.New("at least one of --username, --nickname, --email and --phone must be specified")
+			}
+
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.UpdateUser(ctx, rq)
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					table.AddRow(fmt.Sprintf("User %s updated", rq.GetUserID()))
+				})
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&username, "username", username, "New username of the user.")
+	cmd.Flags().StringVar(&nickname, "nickname", nickname, "New nickname of the user.")
+	cmd.Flags().StringVar(&email, "email", email, "New email of the user.")
+	cmd.Flags().StringVar(&phone, "phone", phone, "New phone number of the user.")
+
+	return cmd
+}
+
+// newUserDeleteCommand 创建 user delete 子命令.
+func newUserDeleteCommand(f *factory) *cobra.Command {
+	return &cobra.Command{
+		Use:     "delete USER_ID",
+		Aliases: []string{"rm"},
+		Short:   "Delete a user",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return f.run(func(ctx context.Context, client ucv1.UsercenterClient) error {
+				resp, err := client.DeleteUser(ctx, &ucv1.DeleteUserRequest{UserID: args[0]})
+				if err != nil {
+					return err
+				}
+
+				return f.printer(cmd).Print(resp, func(table *uitable.Table) {
+					table.AddRow(fmt.Sprintf("User %s deleted", args[0]))
+				})
+			})
+		},
+	}
+}
+
+// addUserRows 向表格中添加表头和用户信息.
+func addUserRows(table *uitable.Table, users ...*ucv1.User) {
+	table.AddRow("USER ID", "USERNAME", "NICKNAME", "EMAIL", "PHONE", "CREATED AT")
+	for _, user := range users {
+		table.AddRow(user.GetUserID(), user.GetUsername(), user.GetNickname(), user.GetEmail(), user.GetPhone(), formatTime(user.GetCreatedAt()))
+	}
+}
+
+// formatTime 以本地时间格式化 Protobuf 时间戳. 时间戳为空时返回空字符串.
+func formatTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Local().Format(time.DateTime)
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package app
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ra1n6ow/opsx/cmd/opsxctl/app/options"
+	"github.com/ra1n6ow/opsx/pkg/version"
+)
+
+// newVersionCommand 创建 version 子命令，输出 opsxctl 的版本信息.
+func newVersionCommand(f *factory) *cobra.Command {
+	var short bool
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the version information of opsxctl",
+		Args:  cobra.NoArgs,
+		// 输出版本信息不需要加载登录凭证
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return f.opts.Validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			info := version.Get()
+			switch {
+			case short:
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), info.String())
+				return err
+			case f.opts.Output == options.OutputTable:
+				_, err := fmt.Fprintln(cmd.OutOrStdout(), info.Text())
+				return err
+			default:
+				return f.printer(cmd).Print(info, nil)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&short, "short", short, "Print just the version number.")
+
+	return cmd
+}
@@ -0,0 +1,24 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package main
+
+import (
+	"os"
+
+	"github.com/ra1n6ow/opsx/cmd/opsxctl/app"
+)
+
+// Go 程序的默认入口函数.
+func main() {
+	// 创建 opsxctl 命令
+	command := app.NewOpsxCtlCommand()
+
+	// 执行命令并处理错误
+	if err := command.Execute(); err != nil {
+		// 返回非 0 退出码，便于脚本根据退出码判断命令是否执行成功
+		os.Exit(1)
+	}
+}
@@ -20,33 +20,35 @@ func main() {
 	errx := errorsx.New(500, "InternalError.DBConnection", "Something went wrong: %s", "DB connection failed")
 
 	// fmt.Println 会调用 errx 的 Error 方法，输出：
-	// error: code = 500 reason = InternalError.DBConnection message = Something went wrong: DB connection failed metadata = map[]
+	// Error: code = 500 reason = InternalError.DBConnection message = Something went wrong: DB connection failed metadata = map[]
 	fmt.Println(errx)
 
 	// 给错误添加元数据，增强错误的上下文信息，便于调试和追踪。
-	errx.WithMetadata(map[string]string{
+	// 注意：WithMetadata、KV 和 WithMessage 等方法不会修改 errx 本身，而是返回修改后的副本，需要将返回值赋值回 errx。
+	// 这样可以保证 errno 中的预定义错误不会被修改，多个请求之间不会互相影响。
+	errx = errx.WithMetadata(map[string]string{
 		"user_id":    "12345",   // 添加用户 ID 信息
 		"request_id": "abc-def", // 添加请求 ID 信息
 	})
 
 	// 继续向错误中添加元数据，这次使用了 KV 方法，它是一种更加简洁的方式，用 key-value 的模式逐一设置元数据。
 	// 这里添加 trace_id 信息，用于关联分布式链路信息。
-	errx.KV("trace_id", "xyz-789")
+	errx = errx.KV("trace_id", "xyz-789")
 
 	// 使用 WithMessage 方法更新错误的 Message 字段。
 	// 更新后的 Message 是：Updated message: retry failed。
 	// Note: 更新消息字段并不会影响 Code、Reason 和 Metadata，它只是说明错误的上下文发生了变化。
-	errx.WithMessage("Updated message: %s", "retry failed")
+	errx = errx.WithMessage("Updated message: %s", "retry failed")
 
-	// 再次打印 errx，此时的内容已经发生了变化：
-	// error: code = 500 reason = InternalError.DBConnection message = Updated message: retry failed metadata = map[request_id:abc-def trace_id:xyz-789 user_id:12345]
+	// 再次打印 errx，此时 errx 为修改后的副本：
+	// Error: code = 500 reason = InternalError.DBConnection message = Updated message: retry failed metadata = map[request_id:abc-def trace_id:xyz-789 user_id:12345]
 	// 元数据也会被一并输出。
 	fmt.Println(errx)
 
 	// 调用 doSomething 函数，生成一个错误，并打印它，这里返回一个更新过 Message 字段的预定义错误 errno.ErrUsernameInvalid。
 	someerr := doSomething()
 	// 打印错误。
-	// error: code = 400 reason = InvalidArgument.UsernameInvalid message = Username is too short metadata = map[]
+	// Error: code = 400 reason = InvalidArgument.UsernameInvalid message = Username is too short metadata = map[]
 	fmt.Println(someerr)
 
 	// 调用预定义错误 errno.ErrUsernameInvalid 的 Is 方法，判断 someerr 是否属于该类型错误。
@@ -61,7 +63,8 @@ func main() {
 
 // 定义一个函数 doSomething，返回一个错误
 func doSomething() error {
-	// 这里返回了一个已经定义的错误类型 errno.ErrUsernameInvalid，但动态地设置了 Message 字段为 "Username is too short"。
+	// 这里返回了一个已经定义的错误类型 errno.ErrUsernameInvalid 的副本，并动态地设置了 Message 字段为 "Username is too short"。
+	// WithMessage 不会修改预定义错误 errno.ErrUsernameInvalid 本身，其 Message 仍然为默认值。
 	// 重点是：虽然错误的 Message 不同，但错误的 Code 和 Reason 是一致的，这方便使用 Is 方法进行类型判断而不受具体内容影响。
 	return errno.ErrUsernameInvalid.WithMessage("Username is too short")
 }
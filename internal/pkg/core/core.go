@@ -0,0 +1,187 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package core
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/utilities"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/pkg/defaulter"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// ErrorResponse 定义了错误响应的结构，
+// 用于 API 请求中发生错误时返回统一的格式化错误信息.
+type ErrorResponse struct {
+	// HTTP 状态码
+	Code int `json:"code,omitempty"`
+	// 错误原因，标识错误类型
+	Reason string `json:"reason,omitempty"`
+	// 错误详情的描述信息
+	Message string `json:"message,omitempty"`
+	// 附带的元数据信息
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// 请求中字段的校验错误
+	FieldViolations []errorsx.FieldViolation `json:"fieldViolations,omitempty"`
+	// 客户端重试前需要等待的时间，格式与 google.protobuf.Duration 的 JSON 格式相同，例如 1.5s
+	RetryDelay string `json:"retryDelay,omitempty"`
+	// 根据请求的 Accept-Language 本地化后的错误信息
+	LocalizedMessage *errorsx.LocalizedMessage `json:"localizedMessage,omitempty"`
+}
+
+// NewErrorResponse 根据 errx 生成错误响应.
+func NewErrorResponse(errx *errorsx.ErrorX) ErrorResponse {
+	resp := ErrorResponse{
+		Code:             errx.Code,
+		Reason:           errx.Reason,
+		Message:          errx.Message,
+		Metadata:         errx.Metadata,
+		FieldViolations:  errx.FieldViolations,
+		LocalizedMessage: errx.LocalizedMessage,
+	}
+	if errx.RetryDelay > 0 {
+		resp.RetryDelay = strconv.FormatFloat(errx.RetryDelay.Seconds(), 'f', -1, 64) + "s"
+	}
+	return resp
+}
+
+// setRetryAfter 在需要客户端延迟重试时设置 Retry-After 响应头，单位为秒（向上取整）.
+func setRetryAfter(header http.Header, errx *errorsx.ErrorX) {
+	if errx.RetryDelay > 0 {
+		header.Set("Retry-After", strconv.FormatInt(int64(math.Ceil(errx.RetryDelay.Seconds())), 10))
+	}
+}
+
+// Handler 定义了业务处理函数的类型，与 biz 层的方法签名保持一致.
+type Handler[T any, R any] func(ctx context.Context, rq *T) (R, error)
+
+// Binder 定义了将 HTTP 请求中的数据绑定到 Protobuf 消息的函数类型.
+type Binder func(c *gin.Context, rq proto.Message) error
+
+// 与 grpc-gateway 中 runtime.JSONPb 保持一致的序列化选项，确保 Gin 模式和 gRPC-Gateway 模式返回相同格式的数据.
+var (
+	marshalOptions = protojson.MarshalOptions{
+		// 枚举类型的字段以数字格式输出
+		UseEnumNumbers: true,
+	}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+// BindJSON 使用 protojson 将请求体解析到 Protobuf 消息中.
+func BindJSON(c *gin.Context, rq proto.Message) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	return unmarshalOptions.Unmarshal(body, rq)
+}
+
+// BindQuery 使用与 grpc-gateway 相同的规则将 URL 查询参数解析到 Protobuf 消息中.
+func BindQuery(c *gin.Context, rq proto.Message) error {
+	return runtime.PopulateQueryParameters(rq, c.Request.URL.Query(), &utilities.DoubleArray{})
+}
+
+// BindURI 将路由中的路径参数（例如 /v1/users/:userID）解析到 Protobuf 消息的同名字段中.
+func BindURI(c *gin.Context, rq proto.Message) error {
+	for _, param := range c.Params {
+		if err := runtime.PopulateFieldFromPath(rq, param.Key, param.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleRequest 依次使用 binders 解析请求，根据 defaults 注解填充默认值，使用 SetValidator 设置的校验器校验请求，
+// 调用业务处理函数，并将结果写回客户端.
+// 类型参数 T 必须是 Protobuf 消息对应的结构体类型，即 *T 实现了 proto.Message 接口.
+func HandleRequest[T any, R any](c *gin.Context, handler Handler[T, R], binders ...Binder) {
+	var request T
+	rq, ok := any(&request).(proto.Message)
+	if !ok {
+		WriteResponse(c, nil, errno.ErrInternal)
+		return
+	}
+
+	for _, bind := range binders {
+		if err := bind(c, rq); err != nil {
+			WriteResponse(c, nil, errno.ErrBind)
+			return
+		}
+	}
+
+	// 与 gRPC 模式的 DefaulterInterceptor 一致，在校验之前填充默认值
+	if err := defaulter.Apply(rq); err != nil {
+		WriteResponse(c, nil, errno.ErrInternal.WithCause(err))
+		return
+	}
+
+	if err := validate(c, rq); err != nil {
+		WriteResponse(c, nil, err)
+		return
+	}
+
+	response, err := handler(c.Request.Context(), &request)
+	WriteResponse(c, response, err)
+}
+
+// WriteResponse 是通用的响应函数.
+// 它会根据是否发生错误，生成成功响应或标准化的错误响应.
+// 发生错误时，会使用 errorsx.ErrorX 中的 HTTP 状态码，并在元数据中附加请求 ID，与 gRPC 模式保持一致.
+// 错误信息会根据请求的 Accept-Language 本地化，需要客户端延迟重试时会设置 Retry-After 响应头.
+func WriteResponse(c *gin.Context, data any, err error) {
+	if err != nil {
+		// 记录错误，供访问日志等中间件使用
+		_ = c.Error(err)
+
+		// 如果发生错误，生成错误响应
+		errx := withRequestID(c.Request.Context(), errorsx.FromError(err)) // 提取错误详细信息
+		errx = errx.Localize(c.GetHeader("Accept-Language"))
+		setRetryAfter(c.Writer.Header(), errx)
+		c.JSON(errx.Code, NewErrorResponse(errx))
+		return
+	}
+
+	// 如果没有错误，返回成功响应
+	WriteResponseWithCode(c, http.StatusOK, data)
+}
+
+// WriteResponseWithCode 使用指定的 HTTP 状态码返回 data，适用于需要返回响应体的非 200 响应，例如健康检查失败.
+func WriteResponseWithCode(c *gin.Context, code int, data any) {
+	// Protobuf 消息使用 protojson 序列化，与 grpc-gateway 的输出保持一致
+	if msg, ok := data.(proto.Message); ok {
+		body, err := marshalOptions.Marshal(msg)
+		if err != nil {
+			WriteResponse(c, nil, errno.ErrInternal)
+			return
+		}
+		c.Data(code, "application/json", body)
+		return
+	}
+
+	c.JSON(code, data)
+}
+
+// withRequestID 返回在元数据中附加了请求 ID 的错误副本，与 gRPC 模式保持一致.
+func withRequestID(ctx context.Context, errx *errorsx.ErrorX) *errorsx.ErrorX {
+	if requestID := contextx.RequestID(ctx); requestID != "" {
+		return errx.WithRequestID(requestID)
+	}
+	return errx
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package core
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/known"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// GatewayErrorHandler 是 gRPC-Gateway 的错误处理函数.
+// 默认的错误处理函数会返回 google.rpc.Status 格式的错误（错误原因位于 details 中），
+// 这里将错误转换为 *errorsx.ErrorX，使用其中的 HTTP 状态码返回与 Gin 模式相同格式的 ErrorResponse，
+// 并将请求 ID 设置到响应头中. 错误详情中的字段校验错误、重试信息和本地化错误信息也会一并返回.
+func GatewayErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	errx := errorsx.FromError(err)
+
+	if requestID := gatewayRequestID(ctx, errx); requestID != "" {
+		w.Header().Set(known.XRequestID, requestID)
+	}
+
+	// gRPC 服务器已经根据 Accept-Language 本地化的错误保持不变，请求没有到达 gRPC 服务器时在这里本地化
+	errx = errx.Localize(r.Header.Get("Accept-Language"))
+	setRetryAfter(w.Header(), errx)
+
+	resp := NewErrorResponse(errx)
+	body, merr := marshaler.Marshal(resp)
+	if merr != nil {
+		errx = errno.ErrInternal
+		body, _ = marshaler.Marshal(ErrorResponse{Code: errx.Code, Reason: errx.Reason, Message: errx.Message})
+	}
+
+	// 错误响应不发送 Trailer
+	w.Header().Del("Trailer")
+	w.Header().Del("Transfer-Encoding")
+	w.Header().Set("Content-Type", marshaler.ContentType(resp))
+	w.WriteHeader(errx.Code)
+	_, _ = w.Write(body)
+}
+
+// GatewayRoutingErrorHandler 返回 gRPC-Gateway 的路由错误处理函数，路由错误最终交给 errorHandler 处理.
+// 请求的路由不存在时返回 errno.ErrPageNotFound，与 Gin 模式的 404 处理保持一致，其他路由错误使用默认的处理方式.
+func GatewayRoutingErrorHandler(errorHandler runtime.ErrorHandlerFunc) runtime.RoutingErrorHandlerFunc {
+	return func(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+		if httpStatus == http.StatusNotFound {
+			errorHandler(ctx, mux, marshaler, w, r, errno.ErrPageNotFound)
+			return
+		}
+		runtime.DefaultRoutingErrorHandler(ctx, mux, marshaler, w, r, httpStatus)
+	}
+}
+
+// GatewayHeaderMatcher 是 gRPC-Gateway 的请求头和响应头匹配函数.
+// 它在默认规则的基础上原样传递 x-request-id，使客户端传入的请求 ID 能够传递给 gRPC 服务器，
+// 并且 gRPC 服务器返回的请求 ID 以 X-Request-Id 响应头返回给客户端，与 Gin 模式保持一致.
+func GatewayHeaderMatcher(incoming bool) runtime.HeaderMatcherFunc {
+	return func(key string) (string, bool) {
+		if strings.EqualFold(key, known.XRequestID) {
+			return key, true
+		}
+		if incoming {
+			return runtime.DefaultHeaderMatcher(key)
+		}
+		return runtime.MetadataHeaderPrefix + key, true
+	}
+}
+
+// gatewayRequestID 返回请求 ID. 优先使用 gRPC 服务器返回的响应头，
+// 没有时（例如请求没有到达 gRPC 服务器）使用错误元数据中的请求 ID.
+func gatewayRequestID(ctx context.Context, errx *errorsx.ErrorX) string {
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		if requestIDs := md.HeaderMD.Get(known.XRequestID); len(requestIDs) > 0 {
+			return requestIDs[0]
+		}
+	}
+	return errx.Metadata["X-Request-ID"]
+}
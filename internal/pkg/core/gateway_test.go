@@ -0,0 +1,121 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/known"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+func TestGatewayErrorHandler(t *testing.T) {
+	mux := runtime.NewServeMux()
+	marshaler := &runtime.JSONPb{}
+
+	// 模拟 gRPC 服务器返回的错误：经过 gRPC 传输后，错误原因和元数据位于 ErrorInfo 中
+	grpcErr := errno.ErrUserNotFound.GRPCStatus().Err()
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{
+		HeaderMD: metadata.Pairs(known.XRequestID, "request-1"),
+	})
+
+	w := httptest.NewRecorder()
+	GatewayErrorHandler(ctx, mux, marshaler, w, httptest.NewRequest(http.MethodGet, "/v1/users/user-1", nil), grpcErr)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "request-1", w.Header().Get(known.XRequestID))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, ErrorResponse{
+		Code:    http.StatusNotFound,
+		Reason:  errno.ErrUserNotFound.Reason,
+		Message: errno.ErrUserNotFound.Message,
+	}, resp)
+
+	// 请求没有到达 gRPC 服务器时，从错误元数据中获取请求 ID
+	errx := *errno.ErrPermissionDenied
+	errx.Metadata = map[string]string{"X-Request-ID": "request-2"}
+	w = httptest.NewRecorder()
+	GatewayErrorHandler(context.Background(), mux, marshaler, w, httptest.NewRequest(http.MethodGet, "/", nil), &errx)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, "request-2", w.Header().Get(known.XRequestID))
+}
+
+func TestGatewayErrorHandlerDetails(t *testing.T) {
+	mux := runtime.NewServeMux()
+	marshaler := &runtime.JSONPb{}
+
+	// 字段校验错误和重试信息经过 gRPC 传输后返回给客户端
+	grpcErr := errno.ErrInvalidArgument.
+		WithFieldViolations(errorsx.FieldViolation{Field: "username", Description: "must be 3 to 20 characters"}).
+		WithRetryDelay(1500 * time.Millisecond).
+		GRPCStatus().Err()
+	r := httptest.NewRequest(http.MethodPost, "/v1/users", nil)
+	r.Header.Set("Accept-Language", "zh-CN,zh;q=0.9")
+
+	w := httptest.NewRecorder()
+	GatewayErrorHandler(context.Background(), mux, marshaler, w, r, grpcErr)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "2", w.Header().Get("Retry-After"))
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, []errorsx.FieldViolation{{Field: "username", Description: "must be 3 to 20 characters"}}, resp.FieldViolations)
+	assert.Equal(t, "1.5s", resp.RetryDelay)
+	// 请求没有在 gRPC 服务器中本地化时，根据 Accept-Language 本地化
+	require.NotNil(t, resp.LocalizedMessage)
+	assert.Equal(t, "zh-CN", resp.LocalizedMessage.Locale)
+
+	// gRPC 服务器已经本地化的错误保持不变
+	w = httptest.NewRecorder()
+	GatewayErrorHandler(context.Background(), mux, marshaler, w, r, errno.ErrUserNotFound.WithLocalizedMessage("en", "No such user.").GRPCStatus().Err())
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, &errorsx.LocalizedMessage{Locale: "en", Message: "No such user."}, resp.LocalizedMessage)
+	assert.Empty(t, w.Header().Get("Retry-After"))
+}
+
+func TestGatewayRoutingErrorHandler(t *testing.T) {
+	handler := GatewayRoutingErrorHandler(GatewayErrorHandler)
+	mux := runtime.NewServeMux()
+
+	w := httptest.NewRecorder()
+	handler(context.Background(), mux, &runtime.JSONPb{}, w, httptest.NewRequest(http.MethodGet, "/none", nil), http.StatusNotFound)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	var resp ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, errno.ErrPageNotFound.Reason, resp.Reason)
+}
+
+func TestGatewayHeaderMatcher(t *testing.T) {
+	key, ok := GatewayHeaderMatcher(true)("X-Request-Id")
+	assert.True(t, ok)
+	assert.Equal(t, "X-Request-Id", key)
+
+	_, ok = GatewayHeaderMatcher(true)("X-Custom")
+	assert.False(t, ok)
+
+	key, ok = GatewayHeaderMatcher(false)(known.XRequestID)
+	assert.True(t, ok)
+	assert.Equal(t, known.XRequestID, key)
+
+	key, ok = GatewayHeaderMatcher(false)("x-custom")
+	assert.True(t, ok)
+	assert.Equal(t, runtime.MetadataHeaderPrefix+"x-custom", key)
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package core
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// validatorKey 为 Gin 上下文中保存请求校验器的键.
+const validatorKey = "opsx.validator"
+
+// Validator 定义了请求校验器. gRPC 和 gRPC-Gateway 模式下由校验拦截器调用，
+// Gin 模式下由 HandleRequest 在解析请求之后调用，确保三种模式的校验行为一致.
+type Validator interface {
+	// Validate 校验请求 rq，校验失败时返回 *errorsx.ErrorX.
+	Validate(ctx context.Context, rq any) error
+}
+
+// SetValidator 将请求校验器保存到 Gin 上下文中，供 HandleRequest 使用.
+func SetValidator(c *gin.Context, validator Validator) {
+	c.Set(validatorKey, validator)
+}
+
+// validate 使用 Gin 上下文中的请求校验器校验 rq. 没有设置请求校验器时不做校验.
+func validate(c *gin.Context, rq any) error {
+	value, ok := c.Get(validatorKey)
+	if !ok {
+		return nil
+	}
+	return value.(Validator).Validate(c.Request.Context(), rq)
+}
@@ -3,6 +3,8 @@
 // license that can be found in the LICENSE file. The original repo for
 // this file is https://github.com/Ra1n6ow/opsx.
 
+//go:generate go run ../../../cmd/gen-errcatalog -output-dir ../../../api/errors
+
 package errno
 
 import (
@@ -36,24 +38,27 @@ var (
 	// ErrOperationFailed 表示操作失败.
 	ErrOperationFailed = errorsx.ErrOperationFailed
 
+	// ErrTooManyRequests 表示请求过于频繁，被限流.
+	ErrTooManyRequests = errorsx.Register(http.StatusTooManyRequests, "ResourceExhausted.TooManyRequests", "Too many requests. Please try again later.")
+
 	// ErrPageNotFound 表示页面未找到.
-	ErrPageNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.PageNotFound", Message: "Page not found."}
+	ErrPageNotFound = errorsx.Register(http.StatusNotFound, "NotFound.PageNotFound", "Page not found.")
 
 	// ErrSignToken 表示签发 JWT Token 时出错.
-	ErrSignToken = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.SignToken", Message: "Error occurred while signing the JSON web token."}
+	ErrSignToken = errorsx.Register(http.StatusUnauthorized, "Unauthenticated.SignToken", "Error occurred while signing the JSON web token.")
 
 	// ErrTokenInvalid 表示 JWT Token 格式无效.
-	ErrTokenInvalid = &errorsx.ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenInvalid", Message: "Token was invalid."}
+	ErrTokenInvalid = errorsx.Register(http.StatusUnauthorized, "Unauthenticated.TokenInvalid", "Token was invalid.")
 
 	// ErrDBRead 表示数据库读取失败.
-	ErrDBRead = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.DBRead", Message: "Database read failure."}
+	ErrDBRead = errorsx.Register(http.StatusInternalServerError, "InternalError.DBRead", "Database read failure.")
 
 	// ErrDBWrite 表示数据库写入失败.
-	ErrDBWrite = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.DBWrite", Message: "Database write failure."}
+	ErrDBWrite = errorsx.Register(http.StatusInternalServerError, "InternalError.DBWrite", "Database write failure.")
 
 	// ErrAddRole 表示在添加角色时发生错误.
-	ErrAddRole = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.AddRole", Message: "Error occurred while adding the role."}
+	ErrAddRole = errorsx.Register(http.StatusInternalServerError, "InternalError.AddRole", "Error occurred while adding the role.")
 
 	// ErrRemoveRole 表示在删除角色时发生错误.
-	ErrRemoveRole = &errorsx.ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.RemoveRole", Message: "Error occurred while removing the role."}
+	ErrRemoveRole = errorsx.Register(http.StatusInternalServerError, "InternalError.RemoveRole", "Error occurred while removing the role.")
 )
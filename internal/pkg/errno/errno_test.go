@@ -0,0 +1,99 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package errno_test
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// predefined 包含 errno 包中定义的所有错误.
+var predefined = []*errorsx.ErrorX{
+	errno.OK,
+	errno.ErrInternal,
+	errno.ErrNotFound,
+	errno.ErrBind,
+	errno.ErrInvalidArgument,
+	errno.ErrUnauthenticated,
+	errno.ErrPermissionDenied,
+	errno.ErrOperationFailed,
+	errno.ErrTooManyRequests,
+	errno.ErrPageNotFound,
+	errno.ErrSignToken,
+	errno.ErrTokenInvalid,
+	errno.ErrDBRead,
+	errno.ErrDBWrite,
+	errno.ErrAddRole,
+	errno.ErrRemoveRole,
+	errno.ErrRoleNotFound,
+	errno.ErrUsernameInvalid,
+	errno.ErrPasswordInvalid,
+	errno.ErrUserAlreadyExists,
+	errno.ErrUserNotFound,
+	errno.ErrInvalidCredentials,
+}
+
+// TestPredefinedImmutable 在并发场景下基于 errno 中的错误构造新错误，验证全局错误不会被修改.
+// 需要使用 go test -race 运行以检测数据竞争.
+func TestPredefinedImmutable(t *testing.T) {
+	snapshots := make([]errorsx.ErrorX, len(predefined))
+	for i, errx := range predefined {
+		snapshots[i] = *errx.Clone()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("request-%d", i)
+			for _, errx := range predefined {
+				derived := errorsx.FromError(fmt.Errorf("wrapped: %w", errx)).WithRequestID(id).KV("user_id", id).WithCause(io.EOF)
+				assert.Equal(t, id, derived.Metadata["X-Request-ID"])
+				assert.ErrorIs(t, derived, errx)
+				_ = errx.WithMessage("message %d", i).WithMetadata(map[string]string{"key": id})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, errx := range predefined {
+		assert.Equal(t, snapshots[i], *errx, "predefined error %s was modified", errx.Reason)
+	}
+}
+
+// TestLocalizedMessages 验证所有错误都有中文的本地化错误信息.
+func TestLocalizedMessages(t *testing.T) {
+	for _, errx := range predefined {
+		if errx.Reason == "" {
+			continue
+		}
+		localized := errx.Localize("zh-CN")
+		if assert.NotNil(t, localized.LocalizedMessage, "missing zh-CN message for %s", errx.Reason) {
+			assert.Equal(t, "zh-CN", localized.LocalizedMessage.Locale)
+		}
+	}
+}
+
+// TestRegistered 验证除 OK 外的所有错误都已注册.
+func TestRegistered(t *testing.T) {
+	for _, errx := range predefined {
+		if errx == errno.OK {
+			continue
+		}
+		registered, ok := errorsx.Lookup(errx.Reason)
+		if assert.True(t, ok, "%s is not registered", errx.Reason) {
+			assert.Equal(t, errx, registered)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package errno
+
+import (
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// zhCN 为错误信息的简体中文翻译，键为错误原因.
+var zhCN = map[string]string{
+	ErrInternal.Reason:           "服务器内部错误。",
+	ErrNotFound.Reason:           "资源不存在。",
+	ErrBind.Reason:               "请求体解析失败。",
+	ErrInvalidArgument.Reason:    "参数校验失败。",
+	ErrUnauthenticated.Reason:    "认证失败。",
+	ErrPermissionDenied.Reason:   "没有权限访问请求的资源。",
+	ErrOperationFailed.Reason:    "操作失败，请稍后重试。",
+	ErrTooManyRequests.Reason:    "请求过于频繁，请稍后重试。",
+	ErrPageNotFound.Reason:       "页面不存在。",
+	ErrSignToken.Reason:          "签发 JWT 令牌失败。",
+	ErrTokenInvalid.Reason:       "令牌无效。",
+	ErrDBRead.Reason:             "数据库读取失败。",
+	ErrDBWrite.Reason:            "数据库写入失败。",
+	ErrAddRole.Reason:            "添加角色失败。",
+	ErrRemoveRole.Reason:         "移除角色失败。",
+	ErrRoleNotFound.Reason:       "角色不存在。",
+	ErrUsernameInvalid.Reason:    "用户名不合法：用户名只能包含字母、数字和下划线，长度为 3 到 20 个字符。",
+	ErrPasswordInvalid.Reason:    "密码错误。",
+	ErrUserAlreadyExists.Reason:  "用户已存在。",
+	ErrUserNotFound.Reason:       "用户不存在。",
+	ErrInvalidCredentials.Reason: "用户名或密码错误。",
+}
+
+func init() {
+	// 客户端通过 Accept-Language 请求中文时，错误响应中会附带中文的本地化错误信息
+	errorsx.RegisterLocalizedMessages("zh-CN", zhCN)
+}
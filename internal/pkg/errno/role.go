@@ -0,0 +1,15 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package errno
+
+import (
+	"net/http"
+
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// ErrRoleNotFound 表示角色未定义.
+var ErrRoleNotFound = errorsx.Register(http.StatusNotFound, "NotFound.RoleNotFound", "Role not found.")
@@ -13,22 +13,26 @@ import (
 
 var (
 	// ErrUsernameInvalid 表示用户名不合法.
-	ErrUsernameInvalid = &errorsx.ErrorX{
-		Code:    http.StatusBadRequest,
-		Reason:  "InvalidArgument.UsernameInvalid",
-		Message: "Invalid username: Username must consist of letters, digits, and underscores only, and its length must be between 3 and 20 characters.",
-	}
+	ErrUsernameInvalid = errorsx.Register(
+		http.StatusBadRequest,
+		"InvalidArgument.UsernameInvalid",
+		"Invalid username: Username must consist of letters, digits, and underscores only, and its length must be between 3 and 20 characters.",
+	)
 
 	// ErrPasswordInvalid 表示密码不合法.
-	ErrPasswordInvalid = &errorsx.ErrorX{
-		Code:    http.StatusBadRequest,
-		Reason:  "InvalidArgument.PasswordInvalid",
-		Message: "Password is incorrect.",
-	}
+	ErrPasswordInvalid = errorsx.Register(
+		http.StatusBadRequest,
+		"InvalidArgument.PasswordInvalid",
+		"Password is incorrect.",
+	)
 
 	// ErrUserAlreadyExists 表示用户已存在.
-	ErrUserAlreadyExists = &errorsx.ErrorX{Code: http.StatusBadRequest, Reason: "AlreadyExist.UserAlreadyExists", Message: "User already exists."}
+	ErrUserAlreadyExists = errorsx.Register(http.StatusBadRequest, "AlreadyExist.UserAlreadyExists", "User already exists.")
 
 	// ErrUserNotFound 表示未找到指定用户.
-	ErrUserNotFound = &errorsx.ErrorX{Code: http.StatusNotFound, Reason: "NotFound.UserNotFound", Message: "User not found."}
+	ErrUserNotFound = errorsx.Register(http.StatusNotFound, "NotFound.UserNotFound", "User not found.")
+
+	// ErrInvalidCredentials 表示登录时用户名或密码错误.
+	// 用户不存在和密码错误时返回相同的错误，避免通过登录接口探测用户名是否存在.
+	ErrInvalidCredentials = errorsx.Register(http.StatusUnauthorized, "Unauthenticated.InvalidCredentials", "Invalid username or password.")
 )
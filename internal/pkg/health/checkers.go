@@ -0,0 +1,30 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// ConnChecker 返回检查 gRPC 客户端连接状态的检查器，通常用于检查网关与上游 gRPC 服务器之间的连接.
+// 连接处于空闲状态时会触发重新连接，并视为健康.
+func ConnChecker(conn *grpc.ClientConn) Checker {
+	return CheckerFunc(func(ctx context.Context) error {
+		switch state := conn.GetState(); state {
+		case connectivity.Ready:
+			return nil
+		case connectivity.Idle:
+			conn.Connect()
+			return nil
+		default:
+			return fmt.Errorf("grpc connection to %s is %s", conn.Target(), state)
+		}
+	})
+}
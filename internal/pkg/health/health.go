@@ -0,0 +1,195 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package health 提供服务的健康检查注册表.
+//
+// 各个子系统（例如数据库、Token 签发器、网关上游连接等）通过 Register 注册健康检查器，
+// Healthz 接口和 gRPC 健康检查服务（grpc.health.v1.Health）汇总所有检查器的结果作为服务的就绪状态.
+// 服务开始优雅关停时调用 Shutdown，注册表立即报告为不健康，以便负载均衡器先将流量摘除.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// ErrShuttingDown 表示服务正在关停.
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// defaultTimeout 为单个检查器的默认超时时间.
+const defaultTimeout = 3 * time.Second
+
+// Checker 定义健康检查器. 组件健康时返回 nil，否则返回不健康的原因.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc 是 Checker 的函数适配器.
+type CheckerFunc func(ctx context.Context) error
+
+// Check 实现 Checker 接口.
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// ComponentResult 表示单个组件的健康检查结果.
+type ComponentResult struct {
+	// Name 为组件名称.
+	Name string
+	// Err 为组件不健康的原因，组件健康时为 nil.
+	Err error
+}
+
+// Result 表示汇总后的健康检查结果.
+type Result struct {
+	// Err 为服务不健康的原因，服务健康时为 nil.
+	// 服务正在关停时为 ErrShuttingDown，否则为第一个不健康组件的错误.
+	Err error
+	// Components 为各组件的检查结果，顺序与注册顺序一致.
+	Components []ComponentResult
+}
+
+// Healthy 判断服务是否健康.
+func (r *Result) Healthy() bool {
+	return r.Err == nil
+}
+
+// Registry 是健康检查器的注册表.
+type Registry struct {
+	mu       sync.RWMutex
+	names    []string
+	checkers map[string]Checker
+
+	// timeout 为单个检查器的超时时间.
+	timeout time.Duration
+	// shuttingDown 表示服务是否正在关停.
+	shuttingDown atomic.Bool
+	// services 为 gRPC 健康检查服务中需要报告状态的服务名称.
+	services []string
+	// grpcsrv 用于保存 gRPC 健康检查服务的状态，以支持 Watch 和 List.
+	grpcsrv *health.Server
+}
+
+// NewRegistry 创建一个 Registry 实例. services 为 gRPC 健康检查服务中需要报告状态的服务名称，
+// 空字符串 "" 表示整个服务器的状态，总是会被报告.
+func NewRegistry(services ...string) *Registry {
+	r := &Registry{
+		checkers: make(map[string]Checker),
+		timeout:  defaultTimeout,
+		services: append([]string{""}, services...),
+		grpcsrv:  health.NewServer(),
+	}
+	r.setServingStatus(true)
+
+	return r
+}
+
+// Register 注册名为 name 的健康检查器. 重复注册同名检查器时，后注册的检查器覆盖先注册的检查器.
+func (r *Registry) Register(name string, checker Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.checkers[name]; !ok {
+		r.names = append(r.names, name)
+	}
+	r.checkers[name] = checker
+}
+
+// Check 并发执行所有检查器，并返回汇总后的结果.
+func (r *Registry) Check(ctx context.Context) *Result {
+	r.mu.RLock()
+	names := append([]string(nil), r.names...)
+	checkers := make([]Checker, len(names))
+	for i, name := range names {
+		checkers[i] = r.checkers[name]
+	}
+	r.mu.RUnlock()
+
+	components := make([]ComponentResult, len(names))
+	var wg sync.WaitGroup
+	for i := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+			components[i] = ComponentResult{Name: names[i], Err: checkers[i].Check(ctx)}
+		}()
+	}
+	wg.Wait()
+
+	result := &Result{Components: components}
+	if r.shuttingDown.Load() {
+		result.Err = ErrShuttingDown
+		return result
+	}
+	for _, component := range components {
+		if component.Err != nil {
+			result.Err = component.Err
+			break
+		}
+	}
+
+	// 同步 gRPC 健康检查服务的状态，以便通知 Watch 的客户端
+	r.setServingStatus(result.Healthy())
+
+	return result
+}
+
+// Shutdown 将服务标记为正在关停. 此后 Check 总是返回不健康，gRPC 健康检查服务报告 NOT_SERVING.
+// 应该在优雅关停服务器之前调用，以便负载均衡器先将流量摘除.
+func (r *Registry) Shutdown() {
+	r.shuttingDown.Store(true)
+	r.grpcsrv.Shutdown()
+}
+
+// HealthServer 返回基于注册表的 gRPC 健康检查服务.
+// Check 方法每次都会执行所有检查器；Watch 方法在状态发生变化（调用 Check 或 Shutdown）时通知客户端.
+func (r *Registry) HealthServer() grpc_health_v1.HealthServer {
+	return &healthServer{Server: r.grpcsrv, registry: r}
+}
+
+// setServingStatus 设置 gRPC 健康检查服务中所有服务的状态.
+func (r *Registry) setServingStatus(healthy bool) {
+	status := grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	if healthy {
+		status = grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	for _, service := range r.services {
+		r.grpcsrv.SetServingStatus(service, status)
+	}
+}
+
+// healthServer 实现了 grpc_health_v1.HealthServer 接口. Check 方法报告实时的健康状态.
+type healthServer struct {
+	*health.Server
+
+	registry *Registry
+}
+
+// Check 执行所有检查器，并返回请求的服务的健康状态.
+func (s *healthServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	result := s.registry.Check(ctx)
+
+	resp, err := s.Server.Check(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// 服务正在关停时，底层的健康检查服务不再更新状态，这里直接返回 NOT_SERVING
+	if !result.Healthy() {
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+	}
+	return resp, nil
+}
+
+// 确保 healthServer 实现了 grpc_health_v1.HealthServer 接口.
+var _ grpc_health_v1.HealthServer = (*healthServer)(nil)
@@ -0,0 +1,74 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegistryCheck(t *testing.T) {
+	errDown := errors.New("database is down")
+	var dbErr error
+
+	registry := NewRegistry("test.Service")
+	registry.timeout = 10 * time.Millisecond
+	registry.Register("db", CheckerFunc(func(ctx context.Context) error { return dbErr }))
+	registry.Register("slow", CheckerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}))
+
+	result := registry.Check(context.Background())
+	assert.True(t, result.Healthy())
+	assert.Equal(t, []ComponentResult{{Name: "db"}, {Name: "slow"}}, result.Components)
+
+	dbErr = errDown
+	result = registry.Check(context.Background())
+	assert.False(t, result.Healthy())
+	assert.ErrorIs(t, result.Err, errDown)
+	assert.Equal(t, []ComponentResult{{Name: "db", Err: errDown}, {Name: "slow"}}, result.Components)
+
+	// 检查器恢复后，服务重新变为健康
+	dbErr = nil
+	assert.True(t, registry.Check(context.Background()).Healthy())
+}
+
+func TestRegistryHealthServer(t *testing.T) {
+	var dbErr error
+	registry := NewRegistry("test.Service")
+	registry.Register("db", CheckerFunc(func(ctx context.Context) error { return dbErr }))
+	hs := registry.HealthServer()
+
+	check := func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := hs.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, check(""))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, check("test.Service"))
+
+	dbErr = errors.New("database is down")
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, check(""))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, check("test.Service"))
+
+	dbErr = nil
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, check("test.Service"))
+
+	// 未知的服务
+	_, err := hs.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: "unknown"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// 开始关停后，即使所有检查器都健康，也报告 NOT_SERVING
+	registry.Shutdown()
+	assert.ErrorIs(t, registry.Check(context.Background()).Err, ErrShuttingDown)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, check(""))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, check("test.Service"))
+}
@@ -15,3 +15,12 @@ const (
 	// XUserID 用来定义上下文的键，代表请求用户 ID. UserID 整个用户生命周期唯一.
 	XUserID = "x-user-id"
 )
+
+// 定义内置角色.
+const (
+	// RoleAdmin 代表管理员角色，可以调用所有接口.
+	RoleAdmin = "admin"
+
+	// RoleUser 代表普通用户角色. 所有已注册用户都默认拥有该角色.
+	RoleUser = "user"
+)
@@ -0,0 +1,335 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package log
+
+import (
+	"errors"
+	"maps"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// LevelStatus 为全局 Logger 当前的日志级别配置.
+type LevelStatus struct {
+	// Level 为全局日志级别.
+	Level string
+	// ExpireAt 为全局日志级别自动恢复的时间，零值表示不会自动恢复.
+	ExpireAt time.Time
+	// Modules 为模块级别的日志级别，按模块名排序.
+	Modules []ModuleLevel
+}
+
+// ModuleLevel 为一个模块的日志级别.
+type ModuleLevel struct {
+	// Module 为模块名，例如 middleware 或者 usercenter/biz.
+	Module string
+	// Level 为该模块的日志级别.
+	Level string
+	// ExpireAt 为该模块的日志级别自动恢复的时间，零值表示不会自动恢复.
+	ExpireAt time.Time
+}
+
+// SetLevel 在运行时修改全局 Logger 的日志级别，例如配置热加载时. level 非法时返回错误.
+// 如果之前通过 SetLevelWithTTL 临时修改了日志级别，会取消自动恢复.
+func SetLevel(level string) error {
+	return SetLevelWithTTL(level, 0)
+}
+
+// SetLevelWithTTL 在运行时修改全局 Logger 的日志级别，并在 ttl 后自动恢复为修改前的级别.
+// ttl 小于等于 0 时永久生效. level 非法时返回错误.
+func SetLevelWithTTL(level string, ttl time.Duration) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return std.levels.set("", level, ttl)
+}
+
+// SetModuleLevel 在运行时修改模块 module 的日志级别，并在 ttl 后自动恢复. ttl 小于等于 0 时永久生效.
+// 模块名与调用日志函数的代码所在的包路径按 / 分割的片段匹配，例如 middleware 匹配 internal/pkg/middleware/grpc 包.
+// 多个模块都匹配时，使用最长的模块名. level 为空时删除该模块的日志级别.
+func SetModuleLevel(module, level string, ttl time.Duration) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if module == "" {
+		return errEmptyModule
+	}
+	return std.levels.set(strings.Trim(module, "/"), level, ttl)
+}
+
+// Level 返回全局 Logger 当前的日志级别.
+func Level() string {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return std.levels.global.String()
+}
+
+// Levels 返回全局 Logger 当前的日志级别配置，包括模块级别的日志级别和自动恢复的时间.
+func Levels() LevelStatus {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return std.levels.status()
+}
+
+// errEmptyModule 为模块名为空时返回的错误.
+var errEmptyModule = errors.New("log: module must not be empty")
+
+// levels 保存全局日志级别和模块级别的日志级别，并负责在有效期结束后自动恢复.
+type levels struct {
+	global zap.AtomicLevel
+	// modules 为模块级别的日志级别. 修改时整体替换，记录日志时无需加锁.
+	modules atomic.Pointer[moduleLevels]
+
+	mu sync.Mutex
+	// reverts 为等待自动恢复的日志级别，键为模块名，全局日志级别的键为空字符串.
+	reverts map[string]*revert
+}
+
+// moduleLevels 为模块级别的日志级别.
+type moduleLevels struct {
+	levels map[string]zapcore.Level
+	// min 和 max 为所有模块中最低和最高的日志级别，用于快速判断是否需要获取调用方所在的包.
+	min, max zapcore.Level
+}
+
+// revert 为一次临时修改的自动恢复信息.
+type revert struct {
+	timer    *time.Timer
+	expireAt time.Time
+	// previous 为临时修改前的日志级别，为 nil 表示修改前没有设置该模块的日志级别.
+	previous *zapcore.Level
+}
+
+// newLevels 创建全局日志级别为 level 的 levels.
+func newLevels(level zapcore.Level) *levels {
+	return &levels{global: zap.NewAtomicLevelAt(level), reverts: make(map[string]*revert)}
+}
+
+// set 修改模块 module 的日志级别，module 为空时修改全局日志级别. ttl 大于 0 时，在 ttl 后自动恢复.
+func (l *levels) set(module, level string, ttl time.Duration) error {
+	var target *zapcore.Level
+	if level != "" || module == "" {
+		zapLevel, err := zapcore.ParseLevel(level)
+		if err != nil {
+			return err
+		}
+		target = &zapLevel
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// 连续多次临时修改时，恢复为第一次临时修改前的日志级别
+	previous := l.get(module)
+	if r, ok := l.reverts[module]; ok {
+		r.timer.Stop()
+		previous = r.previous
+		delete(l.reverts, module)
+	}
+
+	l.apply(module, target)
+	if ttl <= 0 {
+		return nil
+	}
+
+	r := &revert{expireAt: time.Now().Add(ttl), previous: previous}
+	r.timer = time.AfterFunc(ttl, func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		// 自动恢复前又被修改过时，不做处理
+		if l.reverts[module] != r {
+			return
+		}
+		delete(l.reverts, module)
+		l.apply(module, r.previous)
+	})
+	l.reverts[module] = r
+	return nil
+}
+
+// get 返回模块 module 的日志级别，module 为空时返回全局日志级别. 模块没有设置日志级别时返回 nil.
+func (l *levels) get(module string) *zapcore.Level {
+	if module == "" {
+		level := l.global.Level()
+		return &level
+	}
+	if mods := l.modules.Load(); mods != nil {
+		if level, ok := mods.levels[module]; ok {
+			return &level
+		}
+	}
+	return nil
+}
+
+// apply 将模块 module 的日志级别设置为 level，module 为空时设置全局日志级别. level 为 nil 时删除该模块的日志级别.
+func (l *levels) apply(module string, level *zapcore.Level) {
+	if module == "" {
+		l.global.SetLevel(*level)
+		return
+	}
+
+	modules := make(map[string]zapcore.Level)
+	if mods := l.modules.Load(); mods != nil {
+		maps.Copy(modules, mods.levels)
+	}
+	if level == nil {
+		delete(modules, module)
+	} else {
+		modules[module] = *level
+	}
+
+	if len(modules) == 0 {
+		l.modules.Store(nil)
+		return
+	}
+	values := slices.Collect(maps.Values(modules))
+	l.modules.Store(&moduleLevels{levels: modules, min: slices.Min(values), max: slices.Max(values)})
+}
+
+// status 返回当前的日志级别配置.
+func (l *levels) status() LevelStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	status := LevelStatus{Level: l.global.String()}
+	if r, ok := l.reverts[""]; ok {
+		status.ExpireAt = r.expireAt
+	}
+	if mods := l.modules.Load(); mods != nil {
+		for _, module := range slices.Sorted(maps.Keys(mods.levels)) {
+			ml := ModuleLevel{Module: module, Level: mods.levels[module].String()}
+			if r, ok := l.reverts[module]; ok {
+				ml.ExpireAt = r.expireAt
+			}
+			status.Modules = append(status.Modules, ml)
+		}
+	}
+	return status
+}
+
+// enabled 判断是否可能输出 level 级别的日志.
+func (l *levels) enabled(level zapcore.Level) bool {
+	if l.global.Enabled(level) {
+		return true
+	}
+	mods := l.modules.Load()
+	return mods != nil && level >= mods.min
+}
+
+// enabledFor 判断调用方是否可以输出 level 级别的日志. 调用方所在的包匹配模块级别的日志级别时，使用该级别.
+func (l *levels) enabledFor(level zapcore.Level) bool {
+	enabled := l.global.Enabled(level)
+	mods := l.modules.Load()
+	// 获取调用方所在的包需要遍历调用栈，开销较大. 只有模块级别的日志级别可能改变判断结果时才获取：
+	// 全局日志级别允许输出时，只有高于 level 的模块级别才可能禁止输出；反之只有不高于 level 的模块级别才可能允许输出
+	if mods == nil || (enabled && level >= mods.max) || (!enabled && level < mods.min) {
+		return enabled
+	}
+
+	pkg := callerPackage()
+	var matched string
+	for module := range mods.levels {
+		if len(module) > len(matched) && matchModule(pkg, module) {
+			matched = module
+		}
+	}
+	if matched == "" {
+		return enabled
+	}
+	return level >= mods.levels[matched]
+}
+
+// matchModule 判断包路径 pkg 是否属于模块 module，即 module 为 pkg 按 / 分割后连续的若干个片段.
+func matchModule(pkg, module string) bool {
+	return strings.Contains("/"+pkg+"/", "/"+module+"/")
+}
+
+// logPackage 为当前包的包路径.
+var logPackage = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	return packageName(runtime.FuncForPC(pc).Name())
+}()
+
+// pcPackages 缓存调用栈中 PC 对应的包路径，键为 PC，需要跳过的 PC 对应空字符串.
+// 程序中调用日志函数的位置是有限的，因此缓存的大小也是有限的.
+var pcPackages sync.Map
+
+// callerPackage 返回调用日志函数的代码所在的包路径. 跳过 zap、标准库 log 和当前包（测试文件除外）的调用栈.
+// 调用栈中的所有 PC 都需要跳过时返回空字符串.
+func callerPackage() string {
+	var pcs [32]uintptr
+	for _, pc := range pcs[:runtime.Callers(3, pcs[:])] {
+		pkg, ok := pcPackages.Load(pc)
+		if !ok {
+			pkg, _ = pcPackages.LoadOrStore(pc, resolvePackage(pc))
+		}
+		if pkg := pkg.(string); pkg != "" {
+			return pkg
+		}
+	}
+	return ""
+}
+
+// resolvePackage 返回 pc 对应的包路径，pc 需要跳过时返回空字符串. pc 处有内联的函数调用时，使用最内层不需要跳过的函数.
+func resolvePackage(pc uintptr) string {
+	frames := runtime.CallersFrames([]uintptr{pc})
+	for {
+		frame, more := frames.Next()
+		pkg := packageName(frame.Function)
+		skip := strings.HasPrefix(pkg, "go.uber.org/zap") || pkg == "log" ||
+			(pkg == logPackage && !strings.HasSuffix(frame.File, "_test.go"))
+		if !skip {
+			return pkg
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// packageName 返回函数全名 function 所在的包路径，
+// 例如 github.com/ra1n6ow/opsx/internal/pkg/log.(*zapLogger).Infow 返回 github.com/ra1n6ow/opsx/internal/pkg/log.
+func packageName(function string) string {
+	slash := strings.LastIndex(function, "/")
+	if dot := strings.Index(function[slash+1:], "."); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
+
+// levelCore 根据全局日志级别和模块级别的日志级别过滤日志.
+type levelCore struct {
+	zapcore.Core
+	levels *levels
+}
+
+// Enabled 实现 zapcore.LevelEnabler 接口.
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.levels.enabled(level)
+}
+
+// With 实现 zapcore.Core 接口.
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+// Check 实现 zapcore.Core 接口.
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.enabledFor(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
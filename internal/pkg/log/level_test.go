@@ -0,0 +1,139 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package log
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// initTestLogger 将全局 Logger 替换为输出到临时文件的 Logger，测试结束后恢复. 返回日志文件路径.
+func initTestLogger(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "level.log")
+	old := std
+	t.Cleanup(func() { std = old })
+	Init(&Options{Level: "info", Format: "json", OutputPaths: []string{path}})
+	return path
+}
+
+// TestSetLevelWithTTL 测试临时修改的日志级别在有效期结束后自动恢复
+func TestSetLevelWithTTL(t *testing.T) {
+	initTestLogger(t)
+
+	require.NoError(t, SetLevelWithTTL("debug", time.Hour))
+	// 连续多次临时修改时，恢复为第一次临时修改前的日志级别
+	require.NoError(t, SetLevelWithTTL("warn", 50*time.Millisecond))
+	assert.Equal(t, "warn", Level())
+	assert.False(t, Levels().ExpireAt.IsZero())
+	assert.Eventually(t, func() bool { return Level() == "info" }, time.Second, 10*time.Millisecond)
+	assert.True(t, Levels().ExpireAt.IsZero())
+
+	// SetLevel 取消自动恢复
+	require.NoError(t, SetLevelWithTTL("debug", 50*time.Millisecond))
+	require.NoError(t, SetLevel("error"))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "error", Level())
+
+	assert.Error(t, SetLevelWithTTL("verbose", time.Second))
+	assert.Equal(t, "error", Level())
+}
+
+// TestSetModuleLevel 测试模块级别的日志级别
+func TestSetModuleLevel(t *testing.T) {
+	path := initTestLogger(t)
+
+	// 当前测试代码位于 internal/pkg/log 包中
+	require.NoError(t, SetModuleLevel("middleware", "debug", 0))
+	Debugw("not matched")
+	require.NoError(t, SetModuleLevel("pkg/log", "debug", 50*time.Millisecond))
+	Debugw("matched")
+	W(t.Context()).Debugw("matched with context")
+
+	// 模块级别的日志级别可以高于全局日志级别，最长的模块名优先
+	require.NoError(t, SetModuleLevel("internal/pkg/log", "error", 0))
+	Warnw("filtered by module level")
+
+	status := Levels()
+	assert.Equal(t, "info", status.Level)
+	require.Len(t, status.Modules, 3)
+	assert.Equal(t, "internal/pkg/log", status.Modules[0].Module)
+	assert.Equal(t, "middleware", status.Modules[1].Module)
+	assert.Equal(t, "pkg/log", status.Modules[2].Module)
+	assert.False(t, status.Modules[2].ExpireAt.IsZero())
+
+	// 删除模块级别的日志级别，以及自动恢复
+	require.NoError(t, SetModuleLevel("internal/pkg/log", "", 0))
+	assert.Eventually(t, func() bool { return len(Levels().Modules) == 1 }, time.Second, 10*time.Millisecond)
+	Debugw("reverted")
+	Sync()
+
+	var messages []any
+	for _, line := range readJSONLines(t, path) {
+		messages = append(messages, line["message"])
+	}
+	assert.Equal(t, []any{"matched", "matched with context"}, messages)
+
+	assert.Error(t, SetModuleLevel("", "debug", 0))
+	assert.Error(t, SetModuleLevel("middleware", "verbose", 0))
+}
+
+// TestEnabledFor 测试模块级别的日志级别高于和低于全局日志级别时的判断结果
+func TestEnabledFor(t *testing.T) {
+	l := newLevels(zapcore.InfoLevel)
+
+	// 不匹配当前包的模块级别不影响判断结果
+	require.NoError(t, l.set("middleware", "error", 0))
+	assert.True(t, l.enabledFor(zapcore.InfoLevel))
+	assert.False(t, l.enabledFor(zapcore.DebugLevel))
+
+	require.NoError(t, l.set("pkg/log", "debug", 0))
+	assert.True(t, l.enabledFor(zapcore.DebugLevel))
+
+	require.NoError(t, l.set("internal/pkg/log", "error", 0))
+	assert.False(t, l.enabledFor(zapcore.WarnLevel))
+	assert.True(t, l.enabledFor(zapcore.ErrorLevel))
+
+	// 再次判断时使用缓存的包路径，结果不变
+	assert.False(t, l.enabledFor(zapcore.WarnLevel))
+}
+
+// BenchmarkEnabledFor 测试设置了模块级别的日志级别时，判断是否输出日志的开销
+func BenchmarkEnabledFor(b *testing.B) {
+	l := newLevels(zapcore.InfoLevel)
+	require.NoError(b, l.set("middleware", "debug", 0))
+
+	// 全局日志级别允许输出，无需获取调用方所在的包
+	b.Run("global", func(b *testing.B) {
+		for b.Loop() {
+			l.enabledFor(zapcore.InfoLevel)
+		}
+	})
+	// 需要获取调用方所在的包
+	b.Run("module", func(b *testing.B) {
+		for b.Loop() {
+			l.enabledFor(zapcore.DebugLevel)
+		}
+	})
+}
+
+// TestMatchModule 测试模块名与包路径的匹配
+func TestMatchModule(t *testing.T) {
+	pkg := "github.com/ra1n6ow/opsx/internal/pkg/middleware/grpc"
+
+	assert.True(t, matchModule(pkg, "middleware"))
+	assert.True(t, matchModule(pkg, "middleware/grpc"))
+	assert.True(t, matchModule(pkg, "internal/pkg"))
+	assert.False(t, matchModule(pkg, "middle"))
+	assert.False(t, matchModule(pkg, "grpc/middleware"))
+	assert.Equal(t, "github.com/ra1n6ow/opsx/internal/pkg/log", logPackage)
+}
@@ -7,14 +7,19 @@ package log
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
 	"sync"
-	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
 	"github.com/ra1n6ow/opsx/internal/pkg/known"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
 )
 
 // 该接口包含了项目中支持的日志记录方法，提供对不同日志级别的支持。
@@ -44,6 +49,11 @@ type Logger interface {
 // zapLogger 是 Logger 接口的具体实现. 它底层封装了 zap.Logger.
 type zapLogger struct {
 	z *zap.Logger
+	// levels 为可以在运行时修改的日志级别.
+	levels *levels
+	// pkg 在 z 的基础上多跳过 1 层调用栈，供包级别的日志函数（例如 log.Infow）使用，
+	// 使日志中的 caller 指向调用包级别函数的位置.
+	pkg *zapLogger
 }
 
 // 确保 *zapLogger 实现了 Logger 接口. 以下变量赋值，可以使错误在编译期被发现.
@@ -80,55 +90,36 @@ func New(opts *Options) *zapLogger {
 		zapLevel = zapcore.InfoLevel
 	}
 
-	// 创建 encoder 配置，用于控制日志的输出格式
-	encoderConfig := zap.NewProductionEncoderConfig()
-	// 自定义 MessageKey 为 message，message 语义更明确
-	encoderConfig.MessageKey = "message"
-	// 自定义 TimeKey 为 timestamp，timestamp 语义更明确
-	encoderConfig.TimeKey = "timestamp"
-	// 指定时间序列化函数，将时间序列化为 `2006-01-02 15:04:05.000` 格式，更易读
-	encoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-		enc.AppendString(t.Format("2006-01-02 15:04:05.000"))
-	}
-	// 指定 time.Duration 序列化函数，将 time.Duration 序列化为经过的毫秒数的浮点数
-	// 毫秒数比默认的秒数更精确
-	encoderConfig.EncodeDuration = func(d time.Duration, enc zapcore.PrimitiveArrayEncoder) {
-		enc.AppendFloat64(float64(d) / float64(time.Millisecond))
-	}
+	// 使用 levels，以便在运行时修改全局和模块级别的日志级别
+	levels := newLevels(zapLevel)
 
-	// when output to local path, with color is forbidden
-	if opts.Format == "console" && opts.EnableColor {
-		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+	core, err := newCore(opts)
+	if err != nil {
+		panic(err)
 	}
+	core = &levelCore{Core: core, levels: levels}
 
-	// 创建构建 zap.Logger 需要的配置
-	cfg := &zap.Config{
-		// 是否在日志中显示调用日志所在的文件和行号，例如：`"caller":"opsx/opsx.go:75"`
-		DisableCaller: opts.DisableCaller,
-		// 是否禁止在 panic 及以上级别打印堆栈信息
-		DisableStacktrace: opts.DisableStacktrace,
-		// 指定日志级别
-		Level: zap.NewAtomicLevelAt(zapLevel),
-		// 指定日志显示格式，可选值：console, json
-		Encoding:      opts.Format,
-		EncoderConfig: encoderConfig,
-		// 指定日志输出位置
-		OutputPaths: opts.OutputPaths,
-		// 设置 zap 内部错误输出位置
-		ErrorOutputPaths: []string{"stderr"},
+	// 设置 zap 内部错误输出位置
+	zapOpts := []zap.Option{zap.ErrorOutput(zapcore.Lock(os.Stderr))}
+	// 是否在日志中显示调用日志所在的文件和行号，例如：`"caller":"opsx/opsx.go:75"`
+	if !opts.DisableCaller {
+		zapOpts = append(zapOpts, zap.AddCaller())
 	}
-
-	// 使用 cfg 创建 *zap.Logger 对象
-	// 由于 log 包对 zap 包进行了封装，因此在调用栈中需要跳过的调用深度应增加 2，即使用 zap.AddCallerSkip(2)。
-	z, err := cfg.Build(zap.AddStacktrace(zapcore.PanicLevel), zap.AddCallerSkip(2))
-	if err != nil {
-		panic(err)
+	// 是否在 panic 及以上级别打印堆栈信息
+	if !opts.DisableStacktrace {
+		zapOpts = append(zapOpts, zap.AddStacktrace(zapcore.PanicLevel))
 	}
+	z := zap.New(core, zapOpts...)
 
 	// 将标准库的 log 输出重定向到 zap.Logger
 	zap.RedirectStdLog(z)
 
-	return &zapLogger{z: z}
+	// 由于 zapLogger 的方法对 zap.Logger 进行了封装，因此在调用栈中需要跳过的调用深度应增加 1，即使用 zap.AddCallerSkip(1)。
+	// 包级别的日志函数（例如 log.Infow）又多了一层封装，需要再多跳过 1 层。
+	// 注意：不能对包级别函数和 W 返回的 Logger 使用相同的调用深度，否则其中一种会记录错误的 caller.
+	l := &zapLogger{z: z.WithOptions(zap.AddCallerSkip(1)), levels: levels}
+	l.pkg = &zapLogger{z: z.WithOptions(zap.AddCallerSkip(2)), levels: levels}
+	return l
 }
 
 // Sync 调用底层 zap.Logger 的 Sync 方法，将缓存中的日志刷新到磁盘文件中. 主程序需要在退出前调用 Sync.
@@ -142,56 +133,56 @@ func (l *zapLogger) Sync() {
 
 // Debugw 输出 debug 级别的日志.
 func Debugw(msg string, kvs ...any) {
-	std.Debugw(msg, kvs...)
+	std.pkg.Debugw(msg, kvs...)
 }
 
 func (l *zapLogger) Debugw(msg string, kvs ...any) {
-	l.z.Sugar().Debugw(msg, kvs...)
+	l.z.Sugar().Debugw(msg, errorFields(kvs)...)
 }
 
 // Infow 输出 info 级别的日志.
 func Infow(msg string, kvs ...any) {
-	std.Infow(msg, kvs...)
+	std.pkg.Infow(msg, kvs...)
 }
 
 func (l *zapLogger) Infow(msg string, kvs ...any) {
-	l.z.Sugar().Infow(msg, kvs...)
+	l.z.Sugar().Infow(msg, errorFields(kvs)...)
 }
 
 // Warnw 输出 warning 级别的日志.
 func Warnw(msg string, kvs ...any) {
-	std.Warnw(msg, kvs...)
+	std.pkg.Warnw(msg, kvs...)
 }
 
 func (l *zapLogger) Warnw(msg string, kvs ...any) {
-	l.z.Sugar().Warnw(msg, kvs...)
+	l.z.Sugar().Warnw(msg, errorFields(kvs)...)
 }
 
 // Errorw 输出 error 级别的日志.
 func Errorw(msg string, kvs ...any) {
-	std.Errorw(msg, kvs...)
+	std.pkg.Errorw(msg, kvs...)
 }
 
 func (l *zapLogger) Errorw(msg string, kvs ...any) {
-	l.z.Sugar().Errorw(msg, kvs...)
+	l.z.Sugar().Errorw(msg, errorFields(kvs)...)
 }
 
 // Panicw 输出 panic 级别的日志.
 func Panicw(msg string, kvs ...any) {
-	std.Panicw(msg, kvs...)
+	std.pkg.Panicw(msg, kvs...)
 }
 
 func (l *zapLogger) Panicw(msg string, kvs ...any) {
-	l.z.Sugar().Panicw(msg, kvs...)
+	l.z.Sugar().Panicw(msg, errorFields(kvs)...)
 }
 
 // Fatalw 输出 fatal 级别的日志.
 func Fatalw(msg string, kvs ...any) {
-	std.Fatalw(msg, kvs...)
+	std.pkg.Fatalw(msg, kvs...)
 }
 
 func (l *zapLogger) Fatalw(msg string, kvs ...any) {
-	l.z.Sugar().Fatalw(msg, kvs...)
+	l.z.Sugar().Fatalw(msg, errorFields(kvs)...)
 }
 
 // W 解析传入的 context，尝试提取关注的键值，并添加到 zap.Logger 结构化日志中.
@@ -216,9 +207,66 @@ func (l *zapLogger) W(ctx context.Context) Logger {
 		}
 	}
 
+	// 提取链路追踪的 trace_id 和 span_id，便于关联日志和链路
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		lc.z = lc.z.With(zap.String("trace_id", sc.TraceID().String()), zap.String("span_id", sc.SpanID().String()))
+	}
+
 	return lc
 }
 
+// errorFields 在 kvs 中追加 *errorsx.ErrorX 类型错误的内部原因和调用栈.
+// 例如，对于 "err", errno.ErrDBRead.WithCause(cause)，会追加 "err_cause", cause.Error().
+// 内部原因不会返回给客户端，只能通过日志排查.
+func errorFields(kvs []any) []any {
+	var extra []any
+	for i := 0; i < len(kvs)-1; i++ {
+		// 与 zap.SugaredLogger 保持一致，zap.Field 类型的参数单独占一个位置
+		if _, ok := kvs[i].(zap.Field); ok {
+			continue
+		}
+
+		key, value := kvs[i], kvs[i+1]
+		i++
+
+		err, ok := value.(error)
+		if !ok {
+			continue
+		}
+		errx := new(errorsx.ErrorX)
+		if !errors.As(err, &errx) {
+			continue
+		}
+
+		if cause := errorCause(errx); cause != "" {
+			extra = append(extra, fmt.Sprintf("%v_cause", key), cause)
+		}
+		if stack := errx.StackTrace(); stack != "" {
+			extra = append(extra, fmt.Sprintf("%v_stack", key), stack)
+		}
+	}
+	if len(extra) == 0 {
+		return kvs
+	}
+	// 不修改调用方传入的 kvs
+	return append(kvs[:len(kvs):len(kvs)], extra...)
+}
+
+// errorCause 返回 errx 的内部原因. 内部原因也是 *errorsx.ErrorX 时，继续展开其内部原因，以 ": " 连接.
+func errorCause(errx *errorsx.ErrorX) string {
+	var causes []string
+	for cause := errx.Unwrap(); cause != nil; {
+		next, ok := cause.(*errorsx.ErrorX)
+		if !ok {
+			causes = append(causes, cause.Error())
+			break
+		}
+		causes = append(causes, next.Message)
+		cause = next.Unwrap()
+	}
+	return strings.Join(causes, ": ")
+}
+
 // clone 深度拷贝 zapLogger.
 func (l *zapLogger) clone() *zapLogger {
 	newLogger := *l
@@ -6,9 +6,20 @@
 package log
 
 import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
 )
 
 // MockLogger 用于测试的自定义 Logger
@@ -59,3 +70,85 @@ func TestSync(t *testing.T) {
 		Sync() // 确保 Sync 不会引发 panic
 	}, "Sync should not panic")
 }
+
+// TestSetLevel 测试在运行时修改日志级别
+func TestSetLevel(t *testing.T) {
+	defer func() { _ = SetLevel("debug") }()
+
+	assert.NoError(t, SetLevel("warn"))
+	assert.Equal(t, "warn", Level())
+	assert.False(t, std.z.Core().Enabled(zapcore.InfoLevel))
+	assert.True(t, std.z.Core().Enabled(zapcore.WarnLevel))
+
+	// W 返回的 Logger 同样使用新的日志级别
+	assert.NoError(t, SetLevel("info"))
+	assert.True(t, W(context.Background()).(*zapLogger).z.Core().Enabled(zapcore.InfoLevel))
+
+	// 非法的日志级别不会修改当前级别
+	assert.Error(t, SetLevel("verbose"))
+	assert.Equal(t, "info", Level())
+}
+
+// TestErrorFields 测试记录日志时追加错误的内部原因
+func TestErrorFields(t *testing.T) {
+	base := errorsx.New(500, "InternalError.DBRead", "Database read failure.")
+	cause := errors.New("connection refused")
+
+	// 不是 *errorsx.ErrorX 或者没有内部原因时，不追加字段
+	kvs := []any{"err", cause, "key", "value"}
+	assert.Equal(t, kvs, errorFields(kvs))
+	assert.Equal(t, []any{"err", base}, errorFields([]any{"err", base}))
+
+	kvs = []any{"err", base.WithCause(cause), "userID", "user-1"}
+	fields := errorFields(kvs)
+	assert.Len(t, kvs, 4) // 不修改调用方传入的 kvs
+	assert.Equal(t, append(kvs, "err_cause", "connection refused"), fields)
+
+	// 展开嵌套的 *errorsx.ErrorX，同时支持被 fmt.Errorf 包装的错误
+	nested := errorsx.ErrInternal.WithCause(base.WithCause(cause))
+	fields = errorFields([]any{"err", fmt.Errorf("list users: %w", nested)})
+	assert.Equal(t, "Database read failure.: connection refused", fields[3])
+
+	// 记录了调用栈时追加调用栈
+	fields = errorFields([]any{"err", base.WithStack()})
+	assert.Equal(t, "err_stack", fields[2])
+	assert.Contains(t, fields[3], "log.TestErrorFields")
+}
+
+// TestCaller 测试包级别的日志函数和 W 返回的 Logger 都记录调用日志的位置
+func TestCaller(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "caller.log")
+
+	old := std
+	defer func() { std = old }()
+	Init(&Options{Level: "info", Format: "json", OutputPaths: []string{path}})
+
+	Infow("package level")
+	W(context.Background()).Infow("with context")
+	Sync()
+
+	lines := readJSONLines(t, path)
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.Contains(t, line["caller"], "log/log_test.go:", "message %q", line["message"])
+	}
+}
+
+// readJSONLines 读取 JSON 格式的日志文件，返回每一行日志.
+func readJSONLines(t *testing.T, path string) []map[string]any {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := make(map[string]any)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		lines = append(lines, line)
+	}
+	require.NoError(t, scanner.Err())
+	return lines
+}
@@ -6,6 +6,8 @@
 package log
 
 import (
+	"time"
+
 	"go.uber.org/zap/zapcore"
 )
 
@@ -31,6 +33,57 @@ type Options struct {
 	// OutputPaths 指定日志的输出位置.
 	// 默认值为标准输出（stdout），也可以指定文件路径或其他输出目标.
 	OutputPaths []string
+	// Rotation 指定输出到文件时的日志轮转配置.
+	Rotation RotationOptions
+	// Sampling 指定日志采样配置，用于限制高频日志的输出量.
+	Sampling SamplingOptions
+	// Sinks 指定多个日志输出，每个输出可以使用独立的日志级别和格式.
+	// 设置了 Sinks 时，Format、EnableColor 和 OutputPaths 不再生效.
+	Sinks []SinkOptions
+}
+
+// RotationOptions 定义了日志文件的轮转配置. 只对文件类型的输出位置生效，标准输出和标准错误输出不会被轮转.
+type RotationOptions struct {
+	// MaxSize 指定单个日志文件的最大大小，单位为 MB，超过后会轮转日志文件.
+	// 小于等于 0 时不轮转日志文件.
+	MaxSize int `json:"max-size" mapstructure:"max-size"`
+	// MaxAge 指定轮转后的日志文件的最大保留天数. 为 0 时不按时间删除.
+	MaxAge int `json:"max-age" mapstructure:"max-age"`
+	// MaxBackups 指定轮转后的日志文件的最大保留个数. 为 0 时不按个数删除.
+	MaxBackups int `json:"max-backups" mapstructure:"max-backups"`
+	// LocalTime 指定轮转后的日志文件名中的时间是否使用本地时间，默认使用 UTC 时间.
+	LocalTime bool `json:"local-time" mapstructure:"local-time"`
+	// Compress 指定是否使用 gzip 压缩轮转后的日志文件.
+	Compress bool `json:"compress" mapstructure:"compress"`
+}
+
+// SamplingOptions 定义了日志采样配置.
+// 在每个 Tick 周期内，相同级别和内容的日志，只输出前 Initial 条，之后每 Thereafter 条输出一条.
+type SamplingOptions struct {
+	// Initial 指定每个周期内相同日志最先输出的条数. 小于等于 0 时不采样.
+	Initial int `json:"initial" mapstructure:"initial"`
+	// Thereafter 指定超过 Initial 条后，每隔多少条输出一条. 为 0 时丢弃超过 Initial 条的日志.
+	Thereafter int `json:"thereafter" mapstructure:"thereafter"`
+	// Tick 指定采样周期，默认值为 1s.
+	Tick time.Duration `json:"tick" mapstructure:"tick"`
+}
+
+// SinkOptions 定义了一个日志输出的配置.
+// 例如，可以将 error 及以上级别的日志输出到标准错误输出，将其他日志输出到文件.
+type SinkOptions struct {
+	// Level 指定该输出的最低日志级别，为空时不限制.
+	// 全局日志级别（Options.Level）仍然生效，只有同时满足全局日志级别和该级别的日志才会输出.
+	Level string `json:"level" mapstructure:"level"`
+	// MaxLevel 指定该输出的最高日志级别，为空时不限制.
+	MaxLevel string `json:"max-level" mapstructure:"max-level"`
+	// Format 指定该输出的日志格式，可选值包括：console 和 json. 为空时使用 Options.Format.
+	Format string `json:"format" mapstructure:"format"`
+	// EnableColor 指定该输出是否输出带颜色的日志，只对 console 格式生效.
+	EnableColor bool `json:"enable-color" mapstructure:"enable-color"`
+	// OutputPaths 指定该输出的输出位置.
+	OutputPaths []string `json:"output-paths" mapstructure:"output-paths"`
+	// Rotation 指定该输出的日志轮转配置，为 nil 时使用 Options.Rotation.
+	Rotation *RotationOptions `json:"rotation" mapstructure:"rotation"`
 }
 
 // NewOptions 创建并返回一个带有默认值的 Options 对象.
@@ -49,5 +102,12 @@ func NewOptions() *Options {
 		EnableColor: false,
 		// 默认日志输出位置为标准输出
 		OutputPaths: []string{"stdout"},
+		// 默认单个日志文件最大 100MB，保留最近 30 天、最多 10 个压缩后的日志文件
+		Rotation: RotationOptions{
+			MaxSize:    100,
+			MaxAge:     30,
+			MaxBackups: 10,
+			Compress:   true,
+		},
 	}
 }
@@ -0,0 +1,167 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package log
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// newCore 根据 opts 创建 zapcore.Core. 每个日志输出对应一个 zapcore.Core.
+// 返回的 zapcore.Core 只根据日志输出的 Level 和 MaxLevel 过滤日志，全局日志级别由调用方处理.
+func newCore(opts *Options) (zapcore.Core, error) {
+	sinks := opts.Sinks
+	if len(sinks) == 0 {
+		// 没有设置 Sinks 时，使用 Format、EnableColor 和 OutputPaths 作为唯一的日志输出
+		sinks = []SinkOptions{{Format: opts.Format, EnableColor: opts.EnableColor, OutputPaths: opts.OutputPaths}}
+	}
+
+	o := &outputs{opened: make(map[string]zapcore.WriteSyncer)}
+	cores := make([]zapcore.Core, 0, len(sinks))
+	for i, sink := range sinks {
+		core, err := o.newSinkCore(opts, &sink)
+		if err != nil {
+			return nil, fmt.Errorf("log: sink %d: %w", i, err)
+		}
+		cores = append(cores, core)
+	}
+	core := zapcore.NewTee(cores...)
+
+	if sampling := opts.Sampling; sampling.Initial > 0 {
+		tick := sampling.Tick
+		if tick <= 0 {
+			tick = time.Second
+		}
+		core = zapcore.NewSamplerWithOptions(core, tick, sampling.Initial, sampling.Thereafter)
+	}
+	return core, nil
+}
+
+// outputs 用于打开日志输出位置. 多个日志输出使用同一个输出位置时，只打开一次，避免多个 lumberjack.Logger 同时轮转同一个文件.
+type outputs struct {
+	opened map[string]zapcore.WriteSyncer
+}
+
+// newSinkCore 创建日志输出 sink 对应的 zapcore.Core.
+func (o *outputs) newSinkCore(opts *Options, sink *SinkOptions) (zapcore.Core, error) {
+	format := sink.Format
+	if format == "" {
+		format = opts.Format
+	}
+	encoder, err := newEncoder(format, sink.EnableColor)
+	if err != nil {
+		return nil, err
+	}
+
+	minLevel, err := parseLevel(sink.Level, zapcore.DebugLevel)
+	if err != nil {
+		return nil, err
+	}
+	maxLevel, err := parseLevel(sink.MaxLevel, zapcore.FatalLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	rotation := opts.Rotation
+	if sink.Rotation != nil {
+		rotation = *sink.Rotation
+	}
+	ws, err := o.open(sink.OutputPaths, rotation)
+	if err != nil {
+		return nil, err
+	}
+
+	enabler := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+		return l >= minLevel && l <= maxLevel
+	})
+	return zapcore.NewCore(encoder, ws, enabler), nil
+}
+
+// open 打开 paths 中的所有输出位置，并合并为一个 zapcore.WriteSyncer.
+func (o *outputs) open(paths []string, rotation RotationOptions) (zapcore.WriteSyncer, error) {
+	syncers := make([]zapcore.WriteSyncer, 0, len(paths))
+	for _, path := range paths {
+		if ws, ok := o.opened[path]; ok {
+			syncers = append(syncers, ws)
+			continue
+		}
+
+		var ws zapcore.WriteSyncer
+		if rotation.MaxSize > 0 && isFilePath(path) {
+			ws = zapcore.AddSync(&lumberjack.Logger{
+				Filename:   path,
+				MaxSize:    rotation.MaxSize,
+				MaxAge:     rotation.MaxAge,
+				MaxBackups: rotation.MaxBackups,
+				LocalTime:  rotation.LocalTime,
+				Compress:   rotation.Compress,
+			})
+		} else {
+			// 标准输出、标准错误输出和其他 zap 支持的输出位置，直接使用 zap.Open 打开
+			var err error
+			if ws, _, err = zap.Open(path); err != nil {
+				return nil, err
+			}
+		}
+		o.opened[path] = ws
+		syncers = append(syncers, ws)
+	}
+	return zapcore.NewMultiWriteSyncer(syncers...), nil
+}
+
+// isFilePath 判断输出位置 path 是否为本地文件路径.
+func isFilePath(path string) bool {
+	return path != "stdout" && path != "stderr" && !strings.Contains(path, "://")
+}
+
+// newEncoder 创建 format 格式的日志编码器.
+func newEncoder(format string, enableColor bool) (zapcore.Encoder, error) {
+	// 创建 encoder 配置，用于控制日志的输出格式
+	encoderConfig := zap.NewProductionEncoderConfig()
+	// 自定义 MessageKey 为 message，message 语义更明确
+	encoderConfig.MessageKey = "message"
+	// 自定义 TimeKey 为 timestamp，timestamp 语义更明确
+	encoderConfig.TimeKey = "timestamp"
+	// 指定时间序列化函数，将时间序列化为 `2006-01-02 15:04:05.000` 格式，更易读
+	encoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(t.Format("2006-01-02 15:04:05.000"))
+	}
+	// 指定 time.Duration 序列化函数，将 time.Duration 序列化为经过的毫秒数的浮点数
+	// 毫秒数比默认的秒数更精确
+	encoderConfig.EncodeDuration = func(d time.Duration, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendFloat64(float64(d) / float64(time.Millisecond))
+	}
+
+	switch format {
+	case "console":
+		// 输出到文件时不应开启颜色
+		if enableColor {
+			encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		}
+		return zapcore.NewConsoleEncoder(encoderConfig), nil
+	case "json":
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+}
+
+// parseLevel 将字符串形式的日志级别转换为 zapcore.Level. level 为空时返回 def.
+func parseLevel(level string, def zapcore.Level) (zapcore.Level, error) {
+	if level == "" {
+		return def, nil
+	}
+	var zapLevel zapcore.Level
+	if err := zapLevel.UnmarshalText([]byte(level)); err != nil {
+		return def, err
+	}
+	return zapLevel, nil
+}
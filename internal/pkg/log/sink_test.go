@@ -0,0 +1,107 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package log
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+// TestSinks 测试按日志级别将日志输出到不同的位置
+func TestSinks(t *testing.T) {
+	dir := t.TempDir()
+	errorPath, otherPath := filepath.Join(dir, "error.log"), filepath.Join(dir, "other.log")
+
+	logger := New(&Options{
+		Level:  "debug",
+		Format: "json",
+		Sinks: []SinkOptions{
+			{Level: "error", OutputPaths: []string{errorPath}},
+			{MaxLevel: "warn", Format: "console", OutputPaths: []string{otherPath}},
+		},
+	})
+	logger.Debugw("debug message")
+	logger.Infow("info message")
+	logger.Errorw("error message")
+	logger.Sync()
+
+	lines := readJSONLines(t, errorPath)
+	require.Len(t, lines, 1)
+	assert.Equal(t, "error message", lines[0]["message"])
+
+	data, err := os.ReadFile(otherPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "debug message")
+	assert.Contains(t, string(data), "info message")
+	assert.NotContains(t, string(data), "error message")
+
+	// 全局日志级别同样作用于所有日志输出
+	logger.levels.global.SetLevel(zapcore.WarnLevel)
+	logger.Infow("filtered message")
+	logger.Sync()
+	data, err = os.ReadFile(otherPath)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "filtered message")
+}
+
+// TestSinksInvalid 测试非法的日志输出配置
+func TestSinksInvalid(t *testing.T) {
+	assert.Panics(t, func() {
+		New(&Options{Sinks: []SinkOptions{{Format: "xml", OutputPaths: []string{"stdout"}}}})
+	})
+	assert.Panics(t, func() {
+		New(&Options{Format: "json", Sinks: []SinkOptions{{Level: "verbose", OutputPaths: []string{"stdout"}}}})
+	})
+}
+
+// TestRotation 测试日志文件超过最大大小后轮转
+func TestRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "opsx.log")
+
+	logger := New(&Options{
+		Level:       "info",
+		Format:      "json",
+		OutputPaths: []string{path},
+		Rotation:    RotationOptions{MaxSize: 1},
+	})
+	// 写入超过 1MB 的日志
+	message := strings.Repeat("x", 1024)
+	for range 1100 {
+		logger.Infow(message)
+	}
+	logger.Sync()
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2, "the log file should be rotated once")
+}
+
+// TestSampling 测试相同的日志超过 Initial 条后，每 Thereafter 条输出一条
+func TestSampling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sampling.log")
+
+	logger := New(&Options{
+		Level:       "info",
+		Format:      "json",
+		OutputPaths: []string{path},
+		Sampling:    SamplingOptions{Initial: 2, Thereafter: 5},
+	})
+	for range 10 {
+		logger.Infow("repeated message")
+	}
+	logger.Infow("another message")
+	logger.Sync()
+
+	// 第 1、2、7 条 repeated message 和 another message
+	assert.Len(t, readJSONLines(t, path), 4)
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+)
+
+// GatewayMiddleware 返回一个 gRPC-Gateway 中间件，用于记录网关处理的 HTTP 请求指标.
+// 需要同时使用 GatewayErrorHandler 包装网关的错误处理函数，才能在指标中记录错误原因.
+func (m *Metrics) GatewayMiddleware() runtime.Middleware {
+	return func(next runtime.HandlerFunc) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			// 使用匹配到的路由模板作为标签，避免标签基数过大
+			route := r.URL.Path
+			if pattern, ok := runtime.HTTPPattern(r.Context()); ok {
+				route = pattern.String()
+			}
+
+			done := m.HTTPStarted(r.Method, route)
+			rw := &responseWriter{ResponseWriter: w, code: http.StatusOK}
+			defer func() { done(rw.code, rw.reason) }()
+
+			next(rw, r, pathParams)
+		}
+	}
+}
+
+// GatewayErrorHandler 包装 gRPC-Gateway 的错误处理函数，将错误原因记录到 GatewayMiddleware 的指标中.
+func GatewayErrorHandler(next runtime.ErrorHandlerFunc) runtime.ErrorHandlerFunc {
+	return func(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+		if rw, ok := w.(*responseWriter); ok {
+			rw.reason = Reason(err)
+		}
+		next(ctx, mux, marshaler, w, r, err)
+	}
+}
+
+// responseWriter 包装 http.ResponseWriter，用于记录响应的状态码和错误原因.
+type responseWriter struct {
+	http.ResponseWriter
+
+	code   int
+	reason string
+}
+
+// WriteHeader 记录响应状态码.
+func (w *responseWriter) WriteHeader(code int) {
+	w.code = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Flush 实现 http.Flusher 接口，网关的流式响应依赖该接口.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap 返回原始的 http.ResponseWriter，供 http.ResponseController 使用.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package metrics 实现了 gRPC、gRPC-Gateway 和 Gin 服务器共用的 Prometheus 指标.
+//
+// 所有请求指标都带有 method 和 reason 标签，其中 reason 为 errorsx.Reason，请求成功时为空字符串.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc/status"
+
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// namespace 为所有指标名称的前缀.
+const namespace = "opsx"
+
+// Metrics 保存服务器的全部 Prometheus 指标.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	grpcHandled  *prometheus.CounterVec
+	grpcHandling *prometheus.HistogramVec
+	grpcInFlight *prometheus.GaugeVec
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	httpInFlight *prometheus.GaugeVec
+}
+
+// New 创建一个 Metrics 实例. 每个实例使用独立的 Registry，并注册 Go 运行时和进程指标.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		grpcHandled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_server",
+			Name:      "handled_total",
+			Help:      "Total number of RPCs completed on the server.",
+		}, []string{"method", "code", "reason"}),
+		grpcHandling: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_server",
+			Name:      "handling_seconds",
+			Help:      "Histogram of response latency of RPCs handled by the server.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "reason"}),
+		grpcInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "grpc_server",
+			Name:      "in_flight_requests",
+			Help:      "Number of RPCs currently being handled by the server.",
+		}, []string{"method"}),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http_server",
+			Name:      "requests_total",
+			Help:      "Total number of HTTP requests completed on the server.",
+		}, []string{"method", "route", "status", "reason"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http_server",
+			Name:      "request_duration_seconds",
+			Help:      "Histogram of response latency of HTTP requests handled by the server.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "reason"}),
+		httpInFlight: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http_server",
+			Name:      "in_flight_requests",
+			Help:      "Number of HTTP requests currently being handled by the server.",
+		}, []string{"method", "route"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.grpcHandled, m.grpcHandling, m.grpcInFlight,
+		m.httpRequests, m.httpDuration, m.httpInFlight,
+	)
+
+	return m
+}
+
+// Registry 返回注册了全部指标的 Registry.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler 返回暴露指标的 HTTP 处理器.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// GRPCStarted 记录一个开始处理的 RPC，返回的函数用于在 RPC 处理完成时记录结果.
+func (m *Metrics) GRPCStarted(method string) func(err error) {
+	start := time.Now()
+	m.grpcInFlight.WithLabelValues(method).Inc()
+
+	return func(err error) {
+		m.grpcInFlight.WithLabelValues(method).Dec()
+
+		reason := Reason(err)
+		m.grpcHandled.WithLabelValues(method, status.Code(err).String(), reason).Inc()
+		m.grpcHandling.WithLabelValues(method, reason).Observe(time.Since(start).Seconds())
+	}
+}
+
+// HTTPStarted 记录一个开始处理的 HTTP 请求，返回的函数用于在请求处理完成时记录结果.
+// route 为请求匹配的路由模板，例如 /v1/users/{userID}.
+func (m *Metrics) HTTPStarted(method, route string) func(code int, reason string) {
+	start := time.Now()
+	m.httpInFlight.WithLabelValues(method, route).Inc()
+
+	return func(code int, reason string) {
+		m.httpInFlight.WithLabelValues(method, route).Dec()
+		m.httpRequests.WithLabelValues(method, route, strconv.Itoa(code), reason).Inc()
+		m.httpDuration.WithLabelValues(method, route, reason).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Reason 返回 err 对应的 errorsx.Reason，err 为 nil 时返回空字符串.
+func Reason(err error) string {
+	if err == nil {
+		return ""
+	}
+	return errorsx.Reason(err)
+}
@@ -0,0 +1,77 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/metrics"
+)
+
+func TestGRPCMetrics(t *testing.T) {
+	m := metrics.New()
+
+	done := m.GRPCStarted("/v1.Usercenter/GetUser")
+	// 请求处理中
+	assert.Contains(t, scrape(t, m), `opsx_grpc_server_in_flight_requests{method="/v1.Usercenter/GetUser"} 1`)
+	done(nil)
+
+	m.GRPCStarted("/v1.Usercenter/GetUser")(errno.ErrUserNotFound)
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `opsx_grpc_server_in_flight_requests{method="/v1.Usercenter/GetUser"} 0`)
+	assert.Contains(t, body, `opsx_grpc_server_handled_total{code="OK",method="/v1.Usercenter/GetUser",reason=""} 1`)
+	assert.Contains(t, body, `opsx_grpc_server_handled_total{code="NotFound",method="/v1.Usercenter/GetUser",reason="NotFound.UserNotFound"} 1`)
+	assert.Contains(t, body, `opsx_grpc_server_handling_seconds_count{method="/v1.Usercenter/GetUser",reason="NotFound.UserNotFound"} 1`)
+	// Go 运行时指标
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestGatewayMetrics(t *testing.T) {
+	m := metrics.New()
+
+	mux := runtime.NewServeMux(
+		runtime.WithMiddlewares(m.GatewayMiddleware()),
+		runtime.WithErrorHandler(metrics.GatewayErrorHandler(runtime.DefaultHTTPErrorHandler)),
+	)
+	require.NoError(t, mux.HandlePath(http.MethodGet, "/v1/users/{userID}", func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		if params["userID"] == "missing" {
+			_, outbound := runtime.MarshalerForRequest(mux, r)
+			runtime.HTTPError(r.Context(), mux, outbound, w, r, errno.ErrUserNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, path := range []string{"/v1/users/user-1", "/v1/users/missing"} {
+		mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrape(t, m)
+	assert.Contains(t, body, `opsx_http_server_requests_total{method="GET",reason="",route="/v1/users/{userID=*}",status="200"} 1`)
+	assert.Contains(t, body, `opsx_http_server_requests_total{method="GET",reason="NotFound.UserNotFound",route="/v1/users/{userID=*}",status="404"} 1`)
+	assert.Contains(t, body, `opsx_http_server_in_flight_requests{method="GET",route="/v1/users/{userID=*}"} 0`)
+}
+
+// scrape 返回 Metrics 暴露的全部指标文本.
+func scrape(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil).WithContext(context.Background()))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// 校验指标文本格式合法
+	_, err := testutil.GatherAndCount(m.Registry())
+	require.NoError(t, err)
+
+	return strings.TrimSpace(w.Body.String())
+}
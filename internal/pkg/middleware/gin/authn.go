@@ -0,0 +1,35 @@
+package gin
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/pkg/token"
+)
+
+// AuthnMiddleware 是一个认证中间件，用于从请求中解析 JWT Token 并校验其有效性.
+// 校验通过后，会将用户 ID 保存到请求的上下文中，供后续处理逻辑使用.
+func AuthnMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, err := token.ParseRequest(c)
+		if err != nil {
+			if errors.Is(err, token.ErrMissingHeader) {
+				core.WriteResponse(c, nil, errno.ErrUnauthenticated)
+			} else {
+				core.WriteResponse(c, nil, errno.ErrTokenInvalid)
+			}
+			c.Abort()
+			return
+		}
+
+		// 将用户 ID 保存到请求上下文中
+		c.Request = c.Request.WithContext(contextx.WithUserID(c.Request.Context(), userID))
+
+		// 继续处理请求
+		c.Next()
+	}
+}
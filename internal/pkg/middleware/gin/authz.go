@@ -0,0 +1,55 @@
+package gin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+)
+
+// Authorizer 用于定义授权接口的实现.
+type Authorizer interface {
+	// Authorize 判断 subject（用户 ID）是否有权限访问 object.
+	Authorize(ctx context.Context, subject, object string) (bool, error)
+}
+
+// ObjectFunc 用于将 HTTP 请求转换为授权资源（例如对应的 gRPC 方法全名）.
+// 返回空字符串表示无法识别该请求，此时拒绝访问.
+type ObjectFunc func(c *gin.Context) string
+
+// AuthzMiddleware 是一个授权中间件，需要放在 AuthnMiddleware 之后使用.
+// 它使用请求上下文中的用户 ID 和 objectFunc 返回的资源进行鉴权，
+// 从而使 Gin 模式与 gRPC 模式共享同一套授权策略.
+func AuthzMiddleware(authorizer Authorizer, objectFunc ObjectFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		object := objectFunc(c)
+		if object == "" {
+			log.W(ctx).Warnw("No authorization object found for request", "method", c.Request.Method, "path", c.FullPath())
+			core.WriteResponse(c, nil, errno.ErrPermissionDenied)
+			c.Abort()
+			return
+		}
+
+		allowed, err := authorizer.Authorize(ctx, contextx.UserID(ctx), object)
+		if err != nil {
+			log.W(ctx).Errorw("Failed to authorize request", "err", err, "object", object)
+			core.WriteResponse(c, nil, err)
+			c.Abort()
+			return
+		}
+		if !allowed {
+			log.W(ctx).Warnw("Permission denied", "object", object)
+			core.WriteResponse(c, nil, errno.ErrPermissionDenied)
+			c.Abort()
+			return
+		}
+
+		// 继续处理请求
+		c.Next()
+	}
+}
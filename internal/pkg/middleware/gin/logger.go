@@ -0,0 +1,44 @@
+package gin
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// AccessLogMiddleware 是一个 Gin 中间件，用于记录每个请求的访问日志.
+// 日志中包含请求方法、路径、状态码、耗时等信息；请求失败时还会记录错误原因.
+func AccessLogMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+
+		// 继续处理请求
+		c.Next()
+
+		kvs := []any{
+			"method", c.Request.Method,
+			"path", path,
+			"route", c.FullPath(),
+			"status", c.Writer.Status(),
+			"latency", time.Since(start).String(),
+			"client-ip", c.ClientIP(),
+		}
+
+		// 处理请求的过程中通过 c.Error 记录的错误
+		if err := c.Errors.Last(); err != nil {
+			kvs = append(kvs, "reason", errorsx.Reason(err.Err), "err", err.Err)
+		}
+
+		// 使用处理请求后的上下文，以便日志中包含认证后的用户 ID
+		logger := log.W(c.Request.Context())
+		if c.Writer.Status() >= 500 {
+			logger.Errorw("HTTP request completed", kvs...)
+			return
+		}
+		logger.Infow("HTTP request completed", kvs...)
+	}
+}
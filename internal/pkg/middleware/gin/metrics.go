@@ -0,0 +1,30 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/metrics"
+)
+
+// MetricsMiddleware 是一个 Gin 中间件，用于记录 HTTP 请求数、耗时和正在处理的请求数.
+// 错误原因来自处理请求过程中通过 c.Error 记录的错误.
+func MetricsMiddleware(m *metrics.Metrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			// 未匹配到路由的请求统一记录，避免标签基数过大
+			route = "unmatched"
+		}
+
+		done := m.HTTPStarted(c.Request.Method, route)
+
+		// 继续处理请求
+		c.Next()
+
+		var reason string
+		if err := c.Errors.Last(); err != nil {
+			reason = metrics.Reason(err.Err)
+		}
+		done(c.Writer.Status(), reason)
+	}
+}
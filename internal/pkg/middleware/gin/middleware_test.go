@@ -0,0 +1,176 @@
+package gin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/known"
+	"github.com/ra1n6ow/opsx/internal/pkg/metrics"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+	"github.com/ra1n6ow/opsx/pkg/token"
+	"github.com/ra1n6ow/opsx/pkg/validation"
+)
+
+// newTestEngine 创建一个注册了通用中间件的 Gin 引擎.
+func newTestEngine(middlewares ...gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(RequestIDMiddleware(), AccessLogMiddleware(), RecoveryMiddleware())
+	engine.Use(middlewares...)
+	return engine
+}
+
+// decodeError 解析错误响应.
+func decodeError(t *testing.T, w *httptest.ResponseRecorder) core.ErrorResponse {
+	t.Helper()
+
+	var resp core.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	engine := newTestEngine()
+	engine.GET("/ping", func(c *gin.Context) {
+		c.String(http.StatusOK, contextx.RequestID(c.Request.Context()))
+	})
+	engine.GET("/error", func(c *gin.Context) {
+		core.WriteResponse(c, nil, errno.ErrUserNotFound)
+	})
+
+	// 使用客户端传入的请求 ID
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	req.Header.Set(known.XRequestID, "rid-123")
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, "rid-123", w.Body.String())
+	assert.Equal(t, "rid-123", w.Header().Get(known.XRequestID))
+
+	// 没有请求 ID 时生成一个新的请求 ID
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/ping", nil))
+	assert.NotEmpty(t, w.Body.String())
+	assert.Equal(t, w.Body.String(), w.Header().Get(known.XRequestID))
+
+	// 错误响应中附加请求 ID，且不修改全局错误
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/error", nil)
+	req.Header.Set(known.XRequestID, "rid-456")
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	resp := decodeError(t, w)
+	assert.Equal(t, errno.ErrUserNotFound.Reason, resp.Reason)
+	assert.Equal(t, "rid-456", resp.Metadata["X-Request-ID"])
+	assert.Empty(t, errno.ErrUserNotFound.Metadata)
+}
+
+func TestRecoveryMiddleware(t *testing.T) {
+	engine := newTestEngine()
+	engine.GET("/panic", func(c *gin.Context) {
+		panic("something went wrong")
+	})
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/panic", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, errno.ErrInternal.Reason, decodeError(t, w).Reason)
+}
+
+func TestAuthnMiddleware(t *testing.T) {
+	token.Init("test-secret-key", time.Hour)
+	validToken, _, err := token.Sign("user-abc")
+	require.NoError(t, err)
+
+	engine := newTestEngine(AuthnMiddleware())
+	engine.GET("/me", func(c *gin.Context) {
+		c.String(http.StatusOK, contextx.UserID(c.Request.Context()))
+	})
+
+	tests := []struct {
+		name       string
+		header     string
+		wantStatus int
+		wantReason string
+		wantBody   string
+	}{
+		{name: "missing token", wantStatus: http.StatusUnauthorized, wantReason: errno.ErrUnauthenticated.Reason},
+		{name: "invalid token", header: "Bearer invalid", wantStatus: http.StatusUnauthorized, wantReason: errno.ErrTokenInvalid.Reason},
+		{name: "valid token", header: "Bearer " + validToken, wantStatus: http.StatusOK, wantBody: "user-abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantReason != "" {
+				assert.Equal(t, tt.wantReason, decodeError(t, w).Reason)
+				return
+			}
+			assert.Equal(t, tt.wantBody, w.Body.String())
+		})
+	}
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	m := metrics.New()
+
+	engine := newTestEngine()
+	engine.Use(MetricsMiddleware(m))
+	engine.GET("/v1/users/:userID", func(c *gin.Context) {
+		core.WriteResponse(c, nil, errno.ErrUserNotFound)
+	})
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/users/user-1", nil))
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(), `opsx_http_server_requests_total{method="GET",reason="NotFound.UserNotFound",route="/v1/users/:userID",status="404"} 1`)
+}
+
+func TestValidatorMiddleware(t *testing.T) {
+	validator := validation.New(nil)
+	validation.Register(validator, func(ctx context.Context, rq *ucv1.GetUserRequest) error {
+		var violations validation.Violations
+		if !strings.HasPrefix(rq.GetUserID(), "user-") {
+			violations.Add("userID", "must start with user-")
+		}
+		return violations.Err()
+	})
+
+	engine := newTestEngine(ValidatorMiddleware(validator))
+	engine.GET("/v1/users/:userID", func(c *gin.Context) {
+		core.HandleRequest(c, func(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+			return &ucv1.GetUserResponse{User: &ucv1.User{UserID: rq.GetUserID()}}, nil
+		}, core.BindURI)
+	})
+
+	// 解析请求之后，调用业务处理函数之前校验请求
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/users/alice", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	resp := decodeError(t, w)
+	assert.Equal(t, errno.ErrInvalidArgument.Reason, resp.Reason)
+	assert.Equal(t, []errorsx.FieldViolation{{Field: "userID", Description: "must start with user-"}}, resp.FieldViolations)
+
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/users/user-1", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,37 @@
+package gin
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+)
+
+// Limiter 用于判断请求是否允许通过.
+type Limiter interface {
+	// Allow 判断当前请求是否允许通过.
+	Allow() bool
+	// RetryAfter 返回被限流的请求建议的重试等待时间.
+	RetryAfter() time.Duration
+}
+
+// RateLimitMiddleware 是一个限流中间件，超过限制的请求返回 errno.ErrTooManyRequests，并通过 Retry-After 响应头告知客户端重试等待时间.
+// skipPaths 中的路由不受限流，例如健康检查和指标接口.
+func RateLimitMiddleware(limiter Limiter, skipPaths ...string) gin.HandlerFunc {
+	skip := sets.New(skipPaths...)
+	return func(c *gin.Context) {
+		if !skip.Has(c.FullPath()) && !limiter.Allow() {
+			log.W(c.Request.Context()).Warnw("Request is rate limited", "method", c.Request.Method, "path", c.Request.URL.Path)
+			core.WriteResponse(c, nil, errno.ErrTooManyRequests.WithRetryDelay(limiter.RetryAfter()))
+			c.Abort()
+			return
+		}
+
+		// 继续处理请求
+		c.Next()
+	}
+}
@@ -0,0 +1,30 @@
+package gin
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+)
+
+// RecoveryMiddleware 是一个 Gin 中间件，用于捕获请求处理过程中发生的 panic.
+// 捕获后会记录错误日志和调用栈，并返回 errno.ErrInternal，避免服务进程崩溃.
+func RecoveryMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if r := recover(); r != nil {
+				log.W(c.Request.Context()).Errorw("Panic recovered", "panic", r, "stack", string(debug.Stack()))
+
+				core.WriteResponse(c, nil, errno.ErrInternal.WithCause(fmt.Errorf("panic: %v", r)))
+				c.Abort()
+			}
+		}()
+
+		// 继续处理请求
+		c.Next()
+	}
+}
@@ -0,0 +1,33 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/known"
+)
+
+// RequestIDMiddleware 是一个 Gin 中间件，用于设置请求 ID.
+// 与 gRPC 的 RequestIDInterceptor 行为一致：优先使用客户端传入的 x-request-id，
+// 没有时生成一个新的 UUID，并将请求 ID 保存到请求上下文和响应头中.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 从请求头中获取请求 ID
+		requestID := c.Request.Header.Get(known.XRequestID)
+
+		// 如果没有请求 ID，则生成一个新的 UUID
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		// 将请求 ID 添加到请求上下文中
+		c.Request = c.Request.WithContext(contextx.WithRequestID(c.Request.Context(), requestID))
+
+		// 将请求 ID 设置到响应头中
+		c.Writer.Header().Set(known.XRequestID, requestID)
+
+		// 继续处理请求
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/tracing"
+)
+
+// TracingMiddleware 是一个 Gin 中间件，用于从 HTTP 请求头中提取链路上下文并创建服务端 Span.
+// 错误原因来自处理请求过程中通过 c.Error 记录的错误.
+func TracingMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx := tracing.Propagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := tracing.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(semconv.HTTPRequestMethodKey.String(c.Request.Method), semconv.HTTPRoute(route)),
+		)
+		c.Request = c.Request.WithContext(ctx)
+
+		// 继续处理请求
+		c.Next()
+
+		span.SetAttributes(semconv.HTTPResponseStatusCode(c.Writer.Status()))
+		var err error
+		if last := c.Errors.Last(); last != nil {
+			err = last.Err
+		}
+		tracing.End(span, err)
+	}
+}
@@ -0,0 +1,19 @@
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+)
+
+// ValidatorMiddleware 是一个 Gin 中间件，用于设置请求校验器.
+// Gin 中间件无法获取解析后的请求，实际的校验由 core.HandleRequest 在解析请求之后执行，
+// 与 gRPC 的 ValidatorInterceptor 行为一致.
+func ValidatorMiddleware(validator core.Validator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		core.SetValidator(c, validator)
+
+		// 继续处理请求
+		c.Next()
+	}
+}
@@ -0,0 +1,70 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/pkg/token"
+)
+
+// AuthnInterceptor 是一个 gRPC 拦截器，用于对请求进行认证.
+// 它从 metadata 中解析 `authorization: Bearer <token>`，校验通过后将 token 中的用户 ID 保存到上下文中.
+// skipMethods 为无需认证的 gRPC 方法全名列表，例如：/v1.Usercenter/Login.
+func AuthnInterceptor(skipMethods ...string) grpc.UnaryServerInterceptor {
+	skip := sets.New(skipMethods...)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if skip.Has(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, err := authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// 继续处理请求
+		return handler(ctx, req)
+	}
+}
+
+// AuthnStreamInterceptor 是 AuthnInterceptor 的流式版本.
+func AuthnStreamInterceptor(skipMethods ...string) grpc.StreamServerInterceptor {
+	skip := sets.New(skipMethods...)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if skip.Has(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		ctx, err := authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		// 继续处理请求
+		return handler(srv, wrapServerStream(ss, ctx))
+	}
+}
+
+// authenticate 校验请求中的 token，并返回携带用户 ID 的上下文.
+func authenticate(ctx context.Context) (context.Context, error) {
+	userID, err := token.ParseRequest(ctx)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to parse token", "err", err)
+		if errors.Is(err, token.ErrMissingHeader) {
+			return ctx, errno.ErrUnauthenticated
+		}
+		return ctx, errno.ErrTokenInvalid
+	}
+
+	// 将用户 ID 保存到上下文中，供后续的处理逻辑和日志使用
+	//nolint: staticcheck
+	return contextx.WithUserID(ctx, userID), nil
+}
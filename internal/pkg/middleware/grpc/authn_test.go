@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/pkg/token"
+)
+
+func TestAuthnInterceptor(t *testing.T) {
+	token.Init("test-secret-key", time.Hour)
+	validToken, _, err := token.Sign("user-abc")
+	require.NoError(t, err)
+
+	interceptor := AuthnInterceptor("/v1.Usercenter/Login")
+	handler := func(ctx context.Context, req any) (any, error) {
+		return contextx.UserID(ctx), nil
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		header  string
+		want    string
+		wantErr error
+	}{
+		{name: "skip method", method: "/v1.Usercenter/Login", want: ""},
+		{name: "missing token", method: "/v1.Usercenter/GetUser", wantErr: errno.ErrUnauthenticated},
+		{name: "invalid token", method: "/v1.Usercenter/GetUser", header: "Bearer invalid", wantErr: errno.ErrTokenInvalid},
+		{name: "valid token", method: "/v1.Usercenter/GetUser", header: "Bearer " + validToken, want: "user-abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.header != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.header))
+			}
+
+			resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp)
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+)
+
+// Authorizer 用于定义授权接口的实现.
+type Authorizer interface {
+	// Authorize 判断 subject（用户 ID）是否有权限访问 object（gRPC 方法全名）.
+	Authorize(ctx context.Context, subject, object string) (bool, error)
+}
+
+// AuthzInterceptor 是一个 gRPC 拦截器，用于对请求进行授权.
+// 它需要放在 AuthnInterceptor 之后，使用上下文中的用户 ID 和 gRPC 方法全名进行鉴权.
+// skipMethods 为无需授权的 gRPC 方法全名列表，通常与认证白名单保持一致.
+func AuthzInterceptor(authorizer Authorizer, skipMethods ...string) grpc.UnaryServerInterceptor {
+	skip := sets.New(skipMethods...)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if skip.Has(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		if err := authorize(ctx, authorizer, info.FullMethod); err != nil {
+			return nil, err
+		}
+
+		// 继续处理请求
+		return handler(ctx, req)
+	}
+}
+
+// AuthzStreamInterceptor 是 AuthzInterceptor 的流式版本.
+func AuthzStreamInterceptor(authorizer Authorizer, skipMethods ...string) grpc.StreamServerInterceptor {
+	skip := sets.New(skipMethods...)
+
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if skip.Has(info.FullMethod) {
+			return handler(srv, ss)
+		}
+
+		if err := authorize(ss.Context(), authorizer, info.FullMethod); err != nil {
+			return err
+		}
+
+		// 继续处理请求
+		return handler(srv, ss)
+	}
+}
+
+// authorize 使用 authorizer 判断当前用户是否有权限调用 fullMethod.
+func authorize(ctx context.Context, authorizer Authorizer, fullMethod string) error {
+	userID := contextx.UserID(ctx)
+
+	allowed, err := authorizer.Authorize(ctx, userID, fullMethod)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to authorize request", "err", err, "method", fullMethod)
+		return err
+	}
+	if !allowed {
+		log.W(ctx).Warnw("Permission denied", "method", fullMethod)
+		return errno.ErrPermissionDenied
+	}
+
+	return nil
+}
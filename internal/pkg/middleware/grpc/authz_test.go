@@ -0,0 +1,58 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/pkg/authz"
+)
+
+func TestAuthzInterceptor(t *testing.T) {
+	resolver := authz.RoleResolverFunc(func(_ context.Context, subject string) ([]string, error) {
+		if subject == "user-admin" {
+			return []string{"admin"}, nil
+		}
+		return []string{"user"}, nil
+	})
+	authorizer := authz.NewAuthorizer(resolver,
+		authz.Policy{Role: "admin", Object: "*"},
+		authz.Policy{Role: "user", Object: "/v1.Usercenter/GetUser"},
+	)
+
+	interceptor := AuthzInterceptor(authorizer, "/v1.Usercenter/Login")
+	handler := func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		userID  string
+		wantErr error
+	}{
+		{name: "skip method", method: "/v1.Usercenter/Login"},
+		{name: "allowed by user role", method: "/v1.Usercenter/GetUser", userID: "user-abc"},
+		{name: "denied", method: "/v1.Usercenter/AssignRole", userID: "user-abc", wantErr: errno.ErrPermissionDenied},
+		{name: "allowed by admin role", method: "/v1.Usercenter/AssignRole", userID: "user-admin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := contextx.WithUserID(context.Background(), tt.userID)
+
+			resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "ok", resp)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/pkg/defaulter"
+)
+
+// DefaulterInterceptor 是一个 gRPC 拦截器，根据请求消息中的 defaults 注解为未设置的字段填充默认值.
+// 需要位于 ValidatorInterceptor 之前，以便校验填充默认值之后的请求.
+func DefaulterInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := applyDefaults(ctx, req); err != nil {
+			return nil, err
+		}
+
+		// 继续处理请求
+		return handler(ctx, req)
+	}
+}
+
+// DefaulterStreamInterceptor 是 DefaulterInterceptor 的流式版本，为客户端发送的每一条消息填充默认值.
+func DefaulterStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &recvHookServerStream{ServerStream: ss, hook: applyDefaults})
+	}
+}
+
+// applyDefaults 为 Protobuf 请求消息填充默认值. defaults 注解不合法属于服务端错误，返回 errno.ErrInternal.
+func applyDefaults(ctx context.Context, req any) error {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if err := defaulter.Apply(msg); err != nil {
+		log.W(ctx).Errorw("Failed to apply defaults to request", "err", err)
+		return errno.ErrInternal.WithCause(err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+func TestDefaulterInterceptor(t *testing.T) {
+	var got *ucv1.ListUsersRequest
+	interceptor := DefaulterInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) {
+		got = req.(*ucv1.ListUsersRequest)
+		return "ok", nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.Usercenter/ListUsers"}
+
+	// 未设置 limit 时使用 user.proto 中声明的默认值
+	_, err := interceptor(context.Background(), &ucv1.ListUsersRequest{Offset: 5}, info, handler)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, got.GetOffset())
+	assert.EqualValues(t, 20, got.GetLimit())
+
+	// 已设置的字段保持不变
+	_, err = interceptor(context.Background(), &ucv1.ListUsersRequest{Limit: 3}, info, handler)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, got.GetLimit())
+}
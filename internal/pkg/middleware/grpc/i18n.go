@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// acceptLanguageKeys 为请求元数据中保存 Accept-Language 的键.
+// gRPC 客户端直接设置 accept-language，gRPC-Gateway 会将 HTTP 请求头 Accept-Language 转换为 grpcgateway-accept-language.
+var acceptLanguageKeys = []string{"accept-language", "grpcgateway-accept-language"}
+
+// LocalizeInterceptor 是一个 gRPC 拦截器，根据请求的 Accept-Language 为返回的错误附加本地化错误信息.
+func LocalizeInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		res, err := handler(ctx, req)
+		if err != nil {
+			return res, localizeError(ctx, err)
+		}
+
+		return res, nil
+	}
+}
+
+// LocalizeStreamInterceptor 是 LocalizeInterceptor 的流式版本.
+func LocalizeStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return localizeError(ss.Context(), err)
+		}
+
+		return nil
+	}
+}
+
+// localizeError 根据请求元数据中的 Accept-Language 返回本地化后的错误.
+// 请求没有携带 Accept-Language 时，err 保持不变.
+func localizeError(ctx context.Context, err error) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range acceptLanguageKeys {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			return errorsx.FromError(err).Localize(values[0])
+		}
+	}
+	return err
+}
@@ -0,0 +1,59 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// LoggerInterceptor 是一个 gRPC 拦截器，用于记录每个请求的访问日志.
+// 日志中包含 gRPC 方法、耗时等信息；请求失败时还会记录错误原因.
+func LoggerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		// 继续处理请求
+		res, err := handler(ctx, req)
+		logRequest(ctx, info.FullMethod, start, err)
+
+		return res, err
+	}
+}
+
+// LoggerStreamInterceptor 是 LoggerInterceptor 的流式版本.
+func LoggerStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+
+		// 继续处理请求
+		err := handler(srv, ss)
+		logRequest(ss.Context(), info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// logRequest 记录一条 gRPC 请求的访问日志.
+func logRequest(ctx context.Context, method string, start time.Time, err error) {
+	kvs := []any{
+		"method", method,
+		"latency", time.Since(start).String(),
+	}
+
+	if err == nil {
+		log.W(ctx).Infow("gRPC request completed", kvs...)
+		return
+	}
+
+	errx := errorsx.FromError(err)
+	kvs = append(kvs, "code", errx.Code, "reason", errx.Reason, "err", err)
+	if errx.Code >= 500 {
+		log.W(ctx).Errorw("gRPC request completed", kvs...)
+		return
+	}
+	log.W(ctx).Infow("gRPC request completed", kvs...)
+}
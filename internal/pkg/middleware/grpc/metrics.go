@@ -0,0 +1,35 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/metrics"
+)
+
+// MetricsInterceptor 是一个 gRPC 拦截器，用于记录 RPC 的请求数、耗时和正在处理的请求数.
+func MetricsInterceptor(m *metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		done := m.GRPCStarted(info.FullMethod)
+
+		// 继续处理请求
+		res, err := handler(ctx, req)
+		done(err)
+
+		return res, err
+	}
+}
+
+// MetricsStreamInterceptor 是 MetricsInterceptor 的流式版本.
+func MetricsStreamInterceptor(m *metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		done := m.GRPCStarted(info.FullMethod)
+
+		// 继续处理请求
+		err := handler(srv, ss)
+		done(err)
+
+		return err
+	}
+}
@@ -0,0 +1,49 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+)
+
+// Limiter 用于判断请求是否允许通过.
+type Limiter interface {
+	// Allow 判断当前请求是否允许通过.
+	Allow() bool
+	// RetryAfter 返回被限流的请求建议的重试等待时间.
+	RetryAfter() time.Duration
+}
+
+// RateLimitInterceptor 是一个 gRPC 拦截器，用于对请求进行限流. 超过限制的请求返回 errno.ErrTooManyRequests，并通过 RetryInfo 告知客户端重试等待时间.
+// skipMethods 中的方法不受限流，例如健康检查接口.
+func RateLimitInterceptor(limiter Limiter, skipMethods ...string) grpc.UnaryServerInterceptor {
+	skip := sets.New(skipMethods...)
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !skip.Has(info.FullMethod) && !limiter.Allow() {
+			log.W(ctx).Warnw("Request is rate limited", "method", info.FullMethod)
+			return nil, errno.ErrTooManyRequests.WithRetryDelay(limiter.RetryAfter())
+		}
+
+		// 继续处理请求
+		return handler(ctx, req)
+	}
+}
+
+// RateLimitStreamInterceptor 是 RateLimitInterceptor 的流式版本.
+func RateLimitStreamInterceptor(limiter Limiter, skipMethods ...string) grpc.StreamServerInterceptor {
+	skip := sets.New(skipMethods...)
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !skip.Has(info.FullMethod) && !limiter.Allow() {
+			log.W(ss.Context()).Warnw("Request is rate limited", "method", info.FullMethod)
+			return errno.ErrTooManyRequests.WithRetryDelay(limiter.RetryAfter())
+		}
+
+		// 继续处理请求
+		return handler(srv, ss)
+	}
+}
@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"google.golang.org/grpc"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+)
+
+// RecoveryInterceptor 是一个 gRPC 拦截器，用于捕获请求处理过程中发生的 panic.
+// 捕获后会记录错误日志和调用栈，并返回 errno.ErrInternal，避免服务进程崩溃.
+func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (res any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverFrom(ctx, info.FullMethod, r)
+			}
+		}()
+
+		// 继续处理请求
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor 是 RecoveryInterceptor 的流式版本.
+func RecoveryStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recoverFrom(ss.Context(), info.FullMethod, r)
+			}
+		}()
+
+		// 继续处理请求
+		return handler(srv, ss)
+	}
+}
+
+// recoverFrom 记录 panic 信息，并返回需要返回给客户端的错误.
+func recoverFrom(ctx context.Context, method string, r any) error {
+	log.W(ctx).Errorw("Panic recovered", "method", method, "panic", r, "stack", string(debug.Stack()))
+	return errno.ErrInternal.WithCause(fmt.Errorf("panic: %v", r))
+}
@@ -15,41 +15,69 @@ import (
 // RequestIDInterceptor 是一个 gRPC 拦截器，用于设置请求 ID.
 func RequestIDInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		var requestID string
-		md, _ := metadata.FromIncomingContext(ctx)
-
-		// 从请求中获取请求 ID
-		if requestIDs := md[known.XRequestID]; len(requestIDs) > 0 {
-			requestID = requestIDs[0]
-		}
-
-		// 如果没有请求 ID，则生成一个新的 UUID
-		if requestID == "" {
-			requestID = uuid.New().String()
-			md.Append(known.XRequestID, requestID)
-		}
-
-		// 将元数据设置为新的 incoming context
-		ctx = metadata.NewIncomingContext(ctx, md)
+		ctx, requestID := withRequestID(ctx)
 
 		// 将请求 ID 设置到响应的 Header Metadata 中
 		// grpc.SetHeader 会在 gRPC 方法响应中添加元数据（Metadata），
 		// 此处将包含请求 ID 的 Metadata 设置到 Header 中。
 		// 注意：grpc.SetHeader 仅设置数据，它不会立即发送给客户端。
 		// Header Metadata 会在 RPC 响应返回时一并发送。
-		_ = grpc.SetHeader(ctx, md)
-
-		// 将请求 ID 添加到 ctx 中
-		//nolint: staticcheck
-		ctx = contextx.WithRequestID(ctx, requestID)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(known.XRequestID, requestID))
 
 		// 继续处理请求
 		res, err := handler(ctx, req)
 		// 错误处理，附加请求 ID
 		if err != nil {
-			return res, errorsx.FromError(err).WithRequestID(requestID)
+			return res, errorWithRequestID(err, requestID)
 		}
 
 		return res, nil
 	}
 }
+
+// RequestIDStreamInterceptor 是 RequestIDInterceptor 的流式版本.
+func RequestIDStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, requestID := withRequestID(ss.Context())
+
+		// 流式 RPC 的 Header 在第一次发送消息时发送给客户端
+		_ = ss.SetHeader(metadata.Pairs(known.XRequestID, requestID))
+
+		// 继续处理请求
+		if err := handler(srv, wrapServerStream(ss, ctx)); err != nil {
+			return errorWithRequestID(err, requestID)
+		}
+
+		return nil
+	}
+}
+
+// withRequestID 从 incoming metadata 中获取请求 ID，没有时生成一个新的 UUID.
+// 返回的上下文中，incoming metadata 和 contextx 均包含该请求 ID.
+func withRequestID(ctx context.Context) (context.Context, string) {
+	var requestID string
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	// 从请求中获取请求 ID
+	if requestIDs := md[known.XRequestID]; len(requestIDs) > 0 {
+		requestID = requestIDs[0]
+	}
+
+	// 如果没有请求 ID，则生成一个新的 UUID
+	if requestID == "" {
+		requestID = uuid.New().String()
+		md = md.Copy()
+		md.Set(known.XRequestID, requestID)
+		// 将元数据设置为新的 incoming context
+		ctx = metadata.NewIncomingContext(ctx, md)
+	}
+
+	// 将请求 ID 添加到 ctx 中
+	//nolint: staticcheck
+	return contextx.WithRequestID(ctx, requestID), requestID
+}
+
+// errorWithRequestID 将 err 转换为 *errorsx.ErrorX，并在元数据中附加请求 ID.
+func errorWithRequestID(err error, requestID string) error {
+	return errorsx.FromError(err).WithRequestID(requestID)
+}
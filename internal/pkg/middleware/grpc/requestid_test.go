@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/known"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+func TestRequestIDInterceptorKeepsErrnoPristine(t *testing.T) {
+	interceptor := RequestIDInterceptor()
+	handler := func(ctx context.Context, req any) (any, error) {
+		return nil, errno.ErrUserNotFound
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.Usercenter/GetUser"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			requestID := fmt.Sprintf("request-%d", i)
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(known.XRequestID, requestID))
+
+			_, err := interceptor(ctx, nil, info, handler)
+			assert.ErrorIs(t, err, errno.ErrUserNotFound)
+			// 每个请求的错误中只包含自己的请求 ID
+			assert.Equal(t, map[string]string{"X-Request-ID": requestID}, errorsx.FromError(err).Metadata)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Nil(t, errno.ErrUserNotFound.Metadata)
+}
@@ -0,0 +1,40 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// wrappedServerStream 包装了 grpc.ServerStream，用于在流式拦截器中替换请求的上下文.
+type wrappedServerStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+}
+
+// Context 返回替换后的上下文.
+func (w *wrappedServerStream) Context() context.Context {
+	return w.ctx
+}
+
+// wrapServerStream 返回一个使用 ctx 作为上下文的 grpc.ServerStream.
+// 即使 ss 已经是 *wrappedServerStream 也总是创建新的包装，不修改外层拦截器持有的 ss 的上下文.
+func wrapServerStream(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &wrappedServerStream{ServerStream: ss, ctx: ctx}
+}
+
+// recvHookServerStream 包装了 grpc.ServerStream，在接收到客户端消息后调用 hook，例如填充默认值和校验请求.
+type recvHookServerStream struct {
+	grpc.ServerStream
+
+	hook func(ctx context.Context, m any) error
+}
+
+// RecvMsg 接收客户端发送的消息，并调用 hook 处理消息.
+func (s *recvHookServerStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return s.hook(s.Context(), m)
+}
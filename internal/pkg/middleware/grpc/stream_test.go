@@ -0,0 +1,210 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/known"
+	"github.com/ra1n6ow/opsx/pkg/authz"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+	"github.com/ra1n6ow/opsx/pkg/token"
+)
+
+const (
+	echoMethod  = "/test.Echo/Echo"
+	panicMethod = "/test.Echo/Panic"
+	adminMethod = "/test.Echo/Admin"
+)
+
+// echoServiceDesc 定义一个用于测试的流式服务. Echo 和 Admin 方法将上下文中的请求 ID 和用户 ID
+// 依次发送给客户端，Panic 方法直接 panic.
+var echoServiceDesc = grpc.ServiceDesc{
+	ServiceName: "test.Echo",
+	HandlerType: (*any)(nil),
+	Streams: []grpc.StreamDesc{
+		{StreamName: "Echo", Handler: echoHandler, ServerStreams: true},
+		{StreamName: "Panic", Handler: func(any, grpc.ServerStream) error { panic("boom") }, ServerStreams: true},
+		{StreamName: "Admin", Handler: echoHandler, ServerStreams: true},
+	},
+}
+
+func echoHandler(_ any, ss grpc.ServerStream) error {
+	ctx := ss.Context()
+	for _, value := range []string{contextx.RequestID(ctx), contextx.UserID(ctx)} {
+		if err := ss.SendMsg(wrapperspb.String(value)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newBufconnClient 启动一个配置了全部流式拦截器的 bufconn gRPC 服务器，并返回连接该服务器的客户端.
+func newBufconnClient(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+
+	resolver := authz.RoleResolverFunc(func(_ context.Context, subject string) ([]string, error) {
+		if subject == "user-admin" {
+			return []string{"admin"}, nil
+		}
+		return []string{"user"}, nil
+	})
+	authorizer := authz.NewAuthorizer(resolver,
+		authz.Policy{Role: "admin", Object: "*"},
+		authz.Policy{Role: "user", Object: echoMethod},
+		authz.Policy{Role: "user", Object: panicMethod},
+	)
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(grpc.ChainStreamInterceptor(
+		RequestIDStreamInterceptor(),
+		LoggerStreamInterceptor(),
+		RecoveryStreamInterceptor(),
+		AuthnStreamInterceptor(),
+		AuthzStreamInterceptor(authorizer),
+	))
+	srv.RegisterService(&echoServiceDesc, struct{}{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+// callStream 调用流式方法，返回接收到的全部消息、响应 Header 和错误.
+func callStream(ctx context.Context, conn *grpc.ClientConn, method string) ([]string, metadata.MD, error) {
+	stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, method)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := stream.SendMsg(&wrapperspb.StringValue{}); err != nil {
+		return nil, nil, err
+	}
+	if err := stream.CloseSend(); err != nil {
+		return nil, nil, err
+	}
+
+	var values []string
+	for {
+		msg := &wrapperspb.StringValue{}
+		if err := stream.RecvMsg(msg); err != nil {
+			header, _ := stream.Header()
+			if errors.Is(err, io.EOF) {
+				return values, header, nil
+			}
+			return values, header, err
+		}
+		values = append(values, msg.GetValue())
+	}
+}
+
+func TestStreamInterceptors(t *testing.T) {
+	token.Init("test-secret-key", time.Hour)
+	userToken, _, err := token.Sign("user-abc")
+	require.NoError(t, err)
+	adminToken, _, err := token.Sign("user-admin")
+	require.NoError(t, err)
+
+	conn := newBufconnClient(t)
+
+	tests := []struct {
+		name       string
+		method     string
+		md         metadata.MD
+		want       []string
+		wantErr    error
+		wantHeader string
+	}{
+		{
+			name:    "unauthenticated",
+			method:  echoMethod,
+			md:      metadata.Pairs(known.XRequestID, "rid-1"),
+			wantErr: errno.ErrUnauthenticated,
+		},
+		{
+			name:       "authenticated",
+			method:     echoMethod,
+			md:         metadata.Pairs(known.XRequestID, "rid-2", "authorization", "Bearer "+userToken),
+			want:       []string{"rid-2", "user-abc"},
+			wantHeader: "rid-2",
+		},
+		{
+			name:    "permission denied",
+			method:  adminMethod,
+			md:      metadata.Pairs(known.XRequestID, "rid-3", "authorization", "Bearer "+userToken),
+			wantErr: errno.ErrPermissionDenied,
+		},
+		{
+			name:       "admin",
+			method:     adminMethod,
+			md:         metadata.Pairs(known.XRequestID, "rid-4", "authorization", "Bearer "+adminToken),
+			want:       []string{"rid-4", "user-admin"},
+			wantHeader: "rid-4",
+		},
+		{
+			name:    "panic recovered",
+			method:  panicMethod,
+			md:      metadata.Pairs(known.XRequestID, "rid-5", "authorization", "Bearer "+userToken),
+			wantErr: errno.ErrInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			values, header, err := callStream(metadata.NewOutgoingContext(ctx, tt.md), conn, tt.method)
+			if tt.wantErr != nil {
+				errx := errorsx.FromError(err)
+				assert.True(t, errx.Is(tt.wantErr), "unexpected error: %v", err)
+				// 错误中附加了请求 ID，且全局错误未被修改
+				assert.Equal(t, tt.md.Get(known.XRequestID)[0], errx.Metadata["X-Request-ID"])
+				assert.Empty(t, errorsx.FromError(tt.wantErr).Metadata)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, values)
+			assert.Equal(t, []string{tt.wantHeader}, header.Get(known.XRequestID))
+		})
+	}
+
+	// 没有携带请求 ID 时，服务端生成请求 ID 并通过 Header 返回
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+userToken)
+	values, header, err := callStream(ctx, conn, echoMethod)
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	assert.NotEmpty(t, values[0])
+	assert.Equal(t, []string{values[0]}, header.Get(known.XRequestID))
+}
+
+func TestWrapServerStream(t *testing.T) {
+	type key struct{}
+	outerCtx := context.WithValue(context.Background(), key{}, "outer")
+	outer := wrapServerStream(nil, outerCtx)
+
+	// 再次包装时不修改外层拦截器持有的流的上下文
+	inner := wrapServerStream(outer, context.WithValue(outerCtx, key{}, "inner"))
+	assert.NotSame(t, outer, inner)
+	assert.Equal(t, "outer", outer.Context().Value(key{}))
+	assert.Equal(t, "inner", inner.Context().Value(key{}))
+}
@@ -0,0 +1,138 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/tracing"
+)
+
+// TracingInterceptor 是一个 gRPC 拦截器，用于从 incoming metadata 中提取链路上下文并创建服务端 Span.
+func TracingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+
+		// 继续处理请求
+		res, err := handler(ctx, req)
+		endSpan(span, err)
+
+		return res, err
+	}
+}
+
+// TracingStreamInterceptor 是 TracingInterceptor 的流式版本.
+func TracingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+
+		// 继续处理请求
+		err := handler(srv, wrapServerStream(ss, ctx))
+		endSpan(span, err)
+
+		return err
+	}
+}
+
+// TracingClientInterceptor 是一个 gRPC 客户端拦截器，用于创建客户端 Span，
+// 并将链路上下文注入到 outgoing metadata 中传递给服务端.
+func TracingClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, span := startClientSpan(ctx, method)
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		endSpan(span, err)
+
+		return err
+	}
+}
+
+// TracingStreamClientInterceptor 是 TracingClientInterceptor 的流式版本.
+// 为简单起见，客户端 Span 在流创建完成时结束.
+func TracingStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, span := startClientSpan(ctx, method)
+
+		cs, err := streamer(ctx, desc, cc, method, opts...)
+		endSpan(span, err)
+
+		return cs, err
+	}
+}
+
+// startServerSpan 从 incoming metadata 中提取链路上下文，并创建服务端 Span.
+func startServerSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = tracing.Propagator().Extract(ctx, metadataCarrier(md))
+
+	return tracing.Start(ctx, spanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(rpcAttributes(fullMethod)...),
+	)
+}
+
+// startClientSpan 创建客户端 Span，并将链路上下文注入到 outgoing metadata 中.
+func startClientSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	ctx, span := tracing.Start(ctx, spanName(fullMethod),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(rpcAttributes(fullMethod)...),
+	)
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	tracing.Propagator().Inject(ctx, metadataCarrier(md))
+
+	return metadata.NewOutgoingContext(ctx, md), span
+}
+
+// endSpan 记录 gRPC 状态码，并结束 Span.
+func endSpan(span trace.Span, err error) {
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(status.Code(err))))
+	tracing.End(span, err)
+}
+
+// spanName 返回 gRPC 方法对应的 Span 名称，例如 v1.Usercenter/GetUser.
+func spanName(fullMethod string) string {
+	return strings.TrimPrefix(fullMethod, "/")
+}
+
+// rpcAttributes 返回 gRPC 方法对应的 Span 属性.
+func rpcAttributes(fullMethod string) []attribute.KeyValue {
+	service, method, _ := strings.Cut(spanName(fullMethod), "/")
+	return []attribute.KeyValue{
+		semconv.RPCSystemGRPC,
+		semconv.RPCService(service),
+		semconv.RPCMethod(method),
+	}
+}
+
+// metadataCarrier 将 metadata.MD 适配为 propagation.TextMapCarrier.
+type metadataCarrier metadata.MD
+
+// Get 返回 key 对应的第一个值.
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Set 设置 key 对应的值.
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys 返回所有的 key.
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
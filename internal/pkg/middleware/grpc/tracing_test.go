@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/tracing"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+func TestTracingInterceptors(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracing.Init(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(grpc.ChainStreamInterceptor(
+		TracingStreamInterceptor(),
+		AuthnStreamInterceptor(),
+	))
+	srv.RegisterService(&echoServiceDesc, struct{}{})
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainStreamInterceptor(TracingStreamClientInterceptor()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx, parent := tracing.Start(context.Background(), "parent")
+	_, _, err = callStream(ctx, conn, echoMethod)
+	parent.End()
+	assert.Equal(t, errno.ErrUnauthenticated.Reason, errorsx.FromError(err).Reason)
+	srv.Stop()
+
+	spans := map[trace.SpanKind]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.SpanKind()] = span
+	}
+	client, server := spans[trace.SpanKindClient], spans[trace.SpanKindServer]
+	require.NotNil(t, client)
+	require.NotNil(t, server)
+
+	// 客户端和服务端的 Span 属于同一条链路，且服务端 Span 的父 Span 为客户端 Span
+	assert.Equal(t, "test.Echo/Echo", server.Name())
+	assert.Equal(t, parent.SpanContext().TraceID(), client.SpanContext().TraceID())
+	assert.Equal(t, client.SpanContext().TraceID(), server.SpanContext().TraceID())
+	assert.Equal(t, client.SpanContext().SpanID(), server.Parent().SpanID())
+	assert.True(t, server.Parent().IsRemote())
+
+	// 服务端 Span 记录了错误原因
+	assert.Equal(t, codes.Error, server.Status().Code)
+	assert.Contains(t, server.Attributes(), tracing.ReasonKey.String(errorsx.Reason(errno.ErrUnauthenticated)))
+}
@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+)
+
+// ValidatorInterceptor 是一个 gRPC 拦截器，在调用处理函数之前使用 validator 校验请求参数.
+func ValidatorInterceptor(validator core.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := validator.Validate(ctx, req); err != nil {
+			return nil, err
+		}
+
+		// 继续处理请求
+		return handler(ctx, req)
+	}
+}
+
+// ValidatorStreamInterceptor 是 ValidatorInterceptor 的流式版本，校验客户端发送的每一条消息.
+func ValidatorStreamInterceptor(validator core.Validator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &recvHookServerStream{ServerStream: ss, hook: validator.Validate})
+	}
+}
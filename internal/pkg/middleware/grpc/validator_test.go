@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/validation"
+)
+
+func TestValidatorInterceptor(t *testing.T) {
+	validator := validation.New(nil)
+	validation.Register(validator, func(ctx context.Context, rq *ucv1.GetUserRequest) error {
+		var violations validation.Violations
+		if rq.GetUserID() == "" {
+			violations.Add("userID", "must not be empty")
+		}
+		return violations.Err()
+	})
+
+	var called bool
+	interceptor := ValidatorInterceptor(validator)
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return "ok", nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/v1.Usercenter/GetUser"}
+
+	// 校验失败时不调用处理函数
+	_, err := interceptor(context.Background(), &ucv1.GetUserRequest{}, info, handler)
+	assert.ErrorIs(t, err, errno.ErrInvalidArgument)
+	assert.False(t, called)
+
+	resp, err := interceptor(context.Background(), &ucv1.GetUserRequest{UserID: "user-1"}, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+	assert.True(t, called)
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package ratelimit 提供可在运行时更新配置的服务端限流器.
+package ratelimit
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+// Limiter 是一个基于令牌桶算法的全局限流器，gRPC、gRPC-Gateway 和 Gin 服务器共用同一个限流器.
+// 可以通过 Update 在运行时修改限流配置，例如配置热加载时.
+type Limiter struct {
+	enabled atomic.Bool
+	limiter *rate.Limiter
+}
+
+// New 根据 opts 创建一个 Limiter 实例.
+func New(opts *genericoptions.RateLimitOptions) *Limiter {
+	l := &Limiter{limiter: rate.NewLimiter(rate.Limit(opts.QPS), opts.Burst)}
+	l.enabled.Store(opts.Enabled)
+	return l
+}
+
+// Update 更新限流配置. 已经积累的令牌会被保留，但不超过新的 Burst.
+func (l *Limiter) Update(opts *genericoptions.RateLimitOptions) {
+	if !opts.Enabled {
+		l.enabled.Store(false)
+		return
+	}
+
+	l.limiter.SetLimit(rate.Limit(opts.QPS))
+	l.limiter.SetBurst(opts.Burst)
+	l.enabled.Store(true)
+}
+
+// Allow 判断当前请求是否允许通过. 没有启用限流时总是返回 true.
+func (l *Limiter) Allow() bool {
+	if !l.enabled.Load() {
+		return true
+	}
+	return l.limiter.Allow()
+}
+
+// RetryAfter 返回被限流的请求建议的重试等待时间，即生成一个令牌所需的时间（至少 1 秒）.
+func (l *Limiter) RetryAfter() time.Duration {
+	limit := float64(l.limiter.Limit())
+	if limit <= 0 || math.IsInf(limit, 1) {
+		return time.Second
+	}
+	return max(time.Second, time.Duration(float64(time.Second)/limit))
+}
@@ -0,0 +1,39 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+func TestLimiter(t *testing.T) {
+	opts := &genericoptions.RateLimitOptions{Enabled: false, QPS: 1, Burst: 2}
+	l := New(opts)
+
+	// 没有启用限流时总是允许通过
+	for range 10 {
+		assert.True(t, l.Allow())
+	}
+
+	// 启用限流后，最多允许 Burst 个请求突发通过
+	l.Update(&genericoptions.RateLimitOptions{Enabled: true, QPS: 0.001, Burst: 2})
+	assert.True(t, l.Allow())
+	assert.True(t, l.Allow())
+	assert.False(t, l.Allow())
+
+	// 关闭限流后恢复
+	l.Update(&genericoptions.RateLimitOptions{Enabled: false, QPS: 0.001, Burst: 2})
+	assert.True(t, l.Allow())
+}
+
+func TestLimiterRetryAfter(t *testing.T) {
+	l := New(&genericoptions.RateLimitOptions{Enabled: true, QPS: 0.5, Burst: 1})
+	assert.Equal(t, 2*time.Second, l.RetryAfter())
+
+	// 重试等待时间至少 1 秒
+	l.Update(&genericoptions.RateLimitOptions{Enabled: true, QPS: 100, Burst: 1})
+	assert.Equal(t, time.Second, l.RetryAfter())
+}
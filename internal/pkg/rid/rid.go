@@ -0,0 +1,44 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package rid
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// ResourceID 定义资源标识符类型.
+type ResourceID string
+
+const (
+	// UserID 定义用户资源标识符.
+	UserID ResourceID = "user"
+)
+
+// 资源 ID 后缀使用的字符集和长度.
+const (
+	alphabet     = "abcdefghijklmnopqrstuvwxyz0123456789"
+	suffixLength = 8
+)
+
+// String 将资源标识符转换为字符串.
+func (rid ResourceID) String() string {
+	return string(rid)
+}
+
+// New 创建带前缀的唯一标识符，例如：user-x8k2m0qa.
+func (rid ResourceID) New() string {
+	suffix := make([]byte, suffixLength)
+	for i := range suffix {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+		if err != nil {
+			// crypto/rand 不可用时无法生成安全的随机数，直接 panic
+			panic(err)
+		}
+		suffix[i] = alphabet[n.Int64()]
+	}
+	return rid.String() + "-" + string(suffix)
+}
@@ -2,12 +2,15 @@ package server
 
 import (
 	"context"
+	"errors"
 	"net"
 
 	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/ra1n6ow/opsx/internal/pkg/log"
@@ -15,47 +18,119 @@ import (
 
 // GRPCServer 代表一个 GRPC 服务器.
 type GRPCServer struct {
-	srv *grpc.Server
-	lis net.Listener
+	srv     *grpc.Server
+	network string
+	addr    string
+	lis     net.Listener
+	// tls 表示是否开启了 TLS.
+	tls bool
 }
 
-// NewGRPCServer 创建一个新的 GRPC 服务器实例.
+// NewGRPCServer 创建一个新的 GRPC 服务器实例. grpcOptions 中的连接超时、消息大小、并发流数量和 keepalive 配置
+// 放在 serverOptions 之前，调用方传入的 serverOptions 可以覆盖这些配置.
 func NewGRPCServer(
 	grpcOptions *genericoptions.GRPCOptions,
 	serverOptions []grpc.ServerOption,
 	registerServer func(grpc.ServiceRegistrar),
 ) (*GRPCServer, error) {
-	lis, err := net.Listen("tcp", grpcOptions.Addr)
+	// 开启 TLS 时，使用 TLS 证书创建 gRPC 服务器凭证
+	tlsConfig, err := grpcOptions.TLSOptions.ServerTLSConfig()
 	if err != nil {
-		log.Errorw("Failed to listen", "err", err)
+		log.Errorw("Failed to load TLS config", "err", err)
 		return nil, err
 	}
+	serverOptions = append(grpcServerOptions(grpcOptions), serverOptions...)
+	if tlsConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
 
 	grpcsrv := grpc.NewServer(serverOptions...)
 
 	registerServer(grpcsrv)
-	registerHealthServer(grpcsrv)
+	// 调用方没有注册健康检查服务时，注册默认的健康检查服务
+	if _, ok := grpcsrv.GetServiceInfo()[grpc_health_v1.Health_ServiceDesc.ServiceName]; !ok {
+		registerHealthServer(grpcsrv)
+	}
 	// 注册反射服务, 用于在 gRPC 客户端中使用反射服务，获取服务端信息
 	reflection.Register(grpcsrv)
 
 	return &GRPCServer{
-		srv: grpcsrv,
-		lis: lis,
+		srv:     grpcsrv,
+		network: grpcOptions.Network,
+		addr:    grpcOptions.Addr,
+		tls:     tlsConfig != nil,
 	}, nil
 }
 
-// RunOrDie 启动 GRPC 服务器并在出错时记录致命错误.
-func (s *GRPCServer) RunOrDie() {
-	log.Infow("Start to listening the incoming requests", "protocol", "grpc", "addr", s.lis.Addr().String())
-	if err := s.srv.Serve(s.lis); err != nil {
-		log.Fatalw("Failed to serve grpc server", "err", err)
+// Listen 监听 GRPC 服务器的地址.
+func (s *GRPCServer) Listen() error {
+	lis, err := netListen(s.network, s.addr)
+	if err != nil {
+		log.Errorw("Failed to listen", "err", err)
+		return err
+	}
+	s.lis = lis
+	return nil
+}
+
+// Serve 启动 GRPC 服务器，直到服务器关停.
+func (s *GRPCServer) Serve() error {
+	log.Infow("Start to listening the incoming requests", "protocol", "grpc", "addr", s.lis.Addr().String(), "tls", s.tls)
+	if err := s.srv.Serve(s.lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
 	}
+	return nil
 }
 
-// GracefulStop 优雅地关闭 GRPC 服务器.
+// GracefulStop 优雅地关闭 GRPC 服务器. ctx 超时后强制关闭所有连接.
 func (s *GRPCServer) GracefulStop(ctx context.Context) {
 	log.Infow("Gracefully stop grpc server")
-	s.srv.GracefulStop()
+
+	done := make(chan struct{})
+	go func() {
+		s.srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		log.Errorw("gRPC server forced to shutdown", "err", ctx.Err())
+		s.srv.Stop()
+	}
+}
+
+// grpcServerOptions 根据 grpcOptions 返回 gRPC 服务器的选项. 值为 0 的配置项使用 gRPC 的默认值.
+func grpcServerOptions(grpcOptions *genericoptions.GRPCOptions) []grpc.ServerOption {
+	opts := []grpc.ServerOption{
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     grpcOptions.Keepalive.MaxConnectionIdle,
+			MaxConnectionAge:      grpcOptions.Keepalive.MaxConnectionAge,
+			MaxConnectionAgeGrace: grpcOptions.Keepalive.MaxConnectionAgeGrace,
+			Time:                  grpcOptions.Keepalive.Time,
+			Timeout:               grpcOptions.Keepalive.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             grpcOptions.Keepalive.MinTime,
+			PermitWithoutStream: grpcOptions.Keepalive.PermitWithoutStream,
+		}),
+	}
+	if grpcOptions.ConnectionTimeout > 0 {
+		opts = append(opts, grpc.ConnectionTimeout(grpcOptions.ConnectionTimeout))
+	}
+	if grpcOptions.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(grpcOptions.MaxRecvMsgSize))
+	}
+	if grpcOptions.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(grpcOptions.MaxSendMsgSize))
+	}
+	if grpcOptions.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(grpcOptions.MaxConcurrentStreams))
+	}
+	if grpcOptions.MaxHeaderListSize > 0 {
+		opts = append(opts, grpc.MaxHeaderListSize(grpcOptions.MaxHeaderListSize))
+	}
+	return opts
 }
 
 // registerHealthServer 注册健康检查服务.
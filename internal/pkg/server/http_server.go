@@ -2,7 +2,7 @@ package server
 
 import (
 	"context"
-	"errors"
+	"net"
 	"net/http"
 
 	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
@@ -12,25 +12,37 @@ import (
 
 // HTTPServer 代表一个 HTTP 服务器.
 type HTTPServer struct {
-	srv *http.Server
+	srv     *http.Server
+	network string
+	lis     net.Listener
 }
 
-// NewHTTPServer 创建一个新的 HTTP 服务器实例.
-func NewHTTPServer(httpOptions *genericoptions.HTTPOptions, handler http.Handler) *HTTPServer {
-	return &HTTPServer{
-		srv: &http.Server{
-			Addr:    httpOptions.Addr,
-			Handler: handler,
-		},
+// NewHTTPServer 创建一个新的 HTTP 服务器实例. 开启 TLS 时创建 HTTPS 服务器.
+func NewHTTPServer(httpOptions *genericoptions.HTTPOptions, handler http.Handler) (*HTTPServer, error) {
+	tlsConfig, err := httpOptions.TLSOptions.ServerTLSConfig()
+	if err != nil {
+		log.Errorw("Failed to load TLS config", "err", err)
+		return nil, err
 	}
+
+	return &HTTPServer{srv: newHTTPServer(httpOptions, handler, tlsConfig), network: httpOptions.Network}, nil
 }
 
-// RunOrDie 启动 HTTP 服务器并在出错时记录致命错误.
-func (s *HTTPServer) RunOrDie() {
-	log.Infow("Start to listening the incoming requests", "protocol", protocolName(s.srv), "addr", s.srv.Addr)
-	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		log.Fatalw("Failed to server HTTP(s) server", "err", err)
+// Listen 监听 HTTP 服务器的地址.
+func (s *HTTPServer) Listen() error {
+	lis, err := listen(s.network, s.srv)
+	if err != nil {
+		log.Errorw("Failed to listen", "err", err)
+		return err
 	}
+	s.lis = lis
+	return nil
+}
+
+// Serve 启动 HTTP 服务器，直到服务器关停.
+func (s *HTTPServer) Serve() error {
+	log.Infow("Start to listening the incoming requests", "protocol", protocolName(s.srv), "addr", s.lis.Addr().String())
+	return serve(s.srv, s.lis)
 }
 
 // GracefulStop 优雅地关闭 HTTP 服务器.
@@ -0,0 +1,129 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+)
+
+// startFailureStopTimeout 为启动失败时关停已经启动的服务器的超时时间.
+const startFailureStopTimeout = 10 * time.Second
+
+// Manager 管理一组服务器的生命周期.
+//
+// 服务器按照传入的顺序（即依赖顺序）依次启动：前一个服务器监听成功后，才会启动后一个服务器.
+// 任一服务器启动或运行失败时，Manager 返回错误而不是退出进程，调用方可以照常执行优雅关停.
+// 关停时按照相反的顺序依次关停服务器，先关停依赖其他服务器的服务器.
+type Manager struct {
+	servers []Server
+
+	// started 为已经启动的服务器.
+	started []Server
+	// group 用于运行服务器的 Serve 方法，任一服务器出错时取消 ctx.
+	group *errgroup.Group
+	ctx   context.Context
+	// ready 在 Start 返回时关闭，无论启动是否成功.
+	ready chan struct{}
+	// startErr 为 Start 返回的错误，在 ready 关闭之前设置.
+	startErr error
+	// stopOnce 确保服务器只被关停一次.
+	stopOnce sync.Once
+	// stopping 表示 Stop 已经被调用，此后服务器的 Serve 方法正常返回不视为错误.
+	stopping atomic.Bool
+}
+
+// NewManager 创建一个 Manager 实例. servers 需要按照依赖顺序传入，被依赖的服务器在前.
+func NewManager(servers ...Server) *Manager {
+	return &Manager{servers: servers, ready: make(chan struct{})}
+}
+
+// Start 按顺序启动所有服务器，并在所有服务器都监听成功后返回. 启动失败时，
+// 在 startFailureStopTimeout 内关停已经启动的服务器并返回错误. ctx 被取消时，Wait 返回.
+func (m *Manager) Start(ctx context.Context) (err error) {
+	// 无论启动是否成功都关闭 ready，避免等待 Ready 的调用方永久阻塞
+	defer func() {
+		m.startErr = err
+		close(m.ready)
+	}()
+
+	m.group, m.ctx = errgroup.WithContext(ctx)
+
+	for _, srv := range m.servers {
+		if err := srv.Listen(); err != nil {
+			stopCtx, cancel := context.WithTimeout(context.Background(), startFailureStopTimeout)
+			defer cancel()
+
+			m.Stop(stopCtx)
+			return err
+		}
+
+		m.started = append(m.started, srv)
+		m.group.Go(func() error { return m.serve(srv) })
+	}
+
+	return nil
+}
+
+// serve 运行 srv 的 Serve 方法. Stop 被调用之前 Serve 返回 nil（例如服务器被外部关闭）时，
+// 服务器已经不再提供服务，返回错误以便 Wait 返回.
+func (m *Manager) serve(srv Server) error {
+	if err := srv.Serve(); err != nil {
+		return err
+	}
+	if !m.stopping.Load() {
+		return fmt.Errorf("server %T exited unexpectedly", srv)
+	}
+	return nil
+}
+
+// Ready 返回一个 channel，在 Start 返回时关闭. 启动失败时同样会关闭，此时 Err 返回启动失败的原因.
+func (m *Manager) Ready() <-chan struct{} {
+	return m.ready
+}
+
+// Err 返回 Start 返回的错误. 只有在 Ready 返回的 channel 关闭之后调用才有意义.
+func (m *Manager) Err() error {
+	select {
+	case <-m.ready:
+		return m.startErr
+	default:
+		return nil
+	}
+}
+
+// Wait 阻塞直到 Start 传入的 ctx 被取消或任一服务器运行失败（包括在 Stop 之前退出）. 服务器运行失败时返回对应的错误.
+// Wait 不会关停服务器，调用方需要随后调用 Stop.
+func (m *Manager) Wait() error {
+	<-m.ctx.Done()
+
+	// 区分 ctx 被取消和服务器运行失败：服务器运行失败时，errgroup 记录了第一个错误
+	if err := context.Cause(m.ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// Stop 按启动的相反顺序优雅关停所有已经启动的服务器，并等待所有服务器的 Serve 方法返回.
+// ctx 到期后，尚未关停的服务器会被强制关停. 返回服务器运行过程中的第一个错误.
+func (m *Manager) Stop(ctx context.Context) error {
+	var err error
+	m.stopOnce.Do(func() {
+		m.stopping.Store(true)
+		for i := len(m.started) - 1; i >= 0; i-- {
+			m.started[i].GracefulStop(ctx)
+		}
+
+		if m.group != nil {
+			err = m.group.Wait()
+		}
+		log.Infow("All servers stopped")
+	})
+	return err
+}
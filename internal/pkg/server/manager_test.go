@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+// fakeServer 是用于测试的 Server 实现，记录生命周期事件.
+type fakeServer struct {
+	name      string
+	events    *events
+	listenErr error
+	// serveErr 用于让 Serve 返回错误.
+	serveErr chan error
+	stopped  chan struct{}
+	once     sync.Once
+	// stopDeadline 表示 GracefulStop 传入的 ctx 是否设置了截止时间.
+	stopDeadline bool
+}
+
+// events 记录所有服务器的生命周期事件.
+type events struct {
+	mu  sync.Mutex
+	all []string
+}
+
+func (e *events) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.all = append(e.all, event)
+}
+
+func (e *events) list() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.all...)
+}
+
+func newFakeServer(name string, events *events) *fakeServer {
+	return &fakeServer{name: name, events: events, serveErr: make(chan error, 1), stopped: make(chan struct{})}
+}
+
+func (s *fakeServer) Listen() error {
+	s.events.add("listen " + s.name)
+	return s.listenErr
+}
+
+func (s *fakeServer) Serve() error {
+	select {
+	case err := <-s.serveErr:
+		return err
+	case <-s.stopped:
+		return nil
+	}
+}
+
+func (s *fakeServer) GracefulStop(ctx context.Context) {
+	s.events.add("stop " + s.name)
+	_, s.stopDeadline = ctx.Deadline()
+	s.once.Do(func() { close(s.stopped) })
+}
+
+func TestManagerLifecycle(t *testing.T) {
+	events := &events{}
+	grpcsrv, gwsrv := newFakeServer("grpc", events), newFakeServer("gateway", events)
+	manager := NewManager(grpcsrv, gwsrv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	require.NoError(t, manager.Start(ctx))
+	select {
+	case <-manager.Ready():
+		assert.NoError(t, manager.Err())
+	default:
+		t.Fatal("manager should be ready after Start returns")
+	}
+
+	// ctx 被取消后，Wait 返回 nil
+	cancel()
+	require.NoError(t, manager.Wait())
+	require.NoError(t, manager.Stop(context.Background()))
+
+	// 按依赖顺序启动，按相反顺序关停
+	assert.Equal(t, []string{"listen grpc", "listen gateway", "stop gateway", "stop grpc"}, events.list())
+}
+
+func TestManagerListenError(t *testing.T) {
+	errListen := errors.New("address already in use")
+
+	events := &events{}
+	grpcsrv, gwsrv, metricssrv := newFakeServer("grpc", events), newFakeServer("gateway", events), newFakeServer("metrics", events)
+	gwsrv.listenErr = errListen
+	manager := NewManager(grpcsrv, gwsrv, metricssrv)
+
+	// 启动失败时返回错误，并关停已经启动的服务器
+	assert.ErrorIs(t, manager.Start(context.Background()), errListen)
+	assert.Equal(t, []string{"listen grpc", "listen gateway", "stop grpc"}, events.list())
+	assert.True(t, grpcsrv.stopDeadline, "servers should be stopped with a deadline")
+
+	// 启动失败时 Ready 同样会关闭，Err 返回启动失败的原因
+	select {
+	case <-manager.Ready():
+		assert.ErrorIs(t, manager.Err(), errListen)
+	default:
+		t.Fatal("Ready should be closed after Start fails")
+	}
+	assert.NoError(t, manager.Stop(context.Background()))
+}
+
+func TestManagerServeError(t *testing.T) {
+	errServe := errors.New("accept failed")
+
+	events := &events{}
+	grpcsrv, gwsrv := newFakeServer("grpc", events), newFakeServer("gateway", events)
+	manager := NewManager(grpcsrv, gwsrv)
+	require.NoError(t, manager.Start(context.Background()))
+
+	// 任一服务器运行失败时，Wait 返回对应的错误，其他服务器仍然可以被优雅关停
+	grpcsrv.serveErr <- errServe
+	done := make(chan error, 1)
+	go func() { done <- manager.Wait() }()
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, errServe)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait should return when a server fails")
+	}
+
+	assert.ErrorIs(t, manager.Stop(context.Background()), errServe)
+	assert.Equal(t, []string{"listen grpc", "listen gateway", "stop gateway", "stop grpc"}, events.list())
+}
+
+func TestManagerServeExitedUnexpectedly(t *testing.T) {
+	events := &events{}
+	grpcsrv, gwsrv := newFakeServer("grpc", events), newFakeServer("gateway", events)
+	manager := NewManager(grpcsrv, gwsrv)
+	require.NoError(t, manager.Start(context.Background()))
+
+	// Stop 之前 Serve 返回 nil（例如服务器被外部关闭）时，Wait 同样返回错误
+	gwsrv.serveErr <- nil
+	done := make(chan error, 1)
+	go func() { done <- manager.Wait() }()
+	select {
+	case err := <-done:
+		assert.ErrorContains(t, err, "exited unexpectedly")
+	case <-time.After(5 * time.Second):
+		t.Fatal("Wait should return when a server exits before Stop")
+	}
+
+	assert.ErrorContains(t, manager.Stop(context.Background()), "exited unexpectedly")
+}
+
+func TestManagerStopDeadline(t *testing.T) {
+	grpcOptions := genericoptions.NewGRPCOptions()
+	grpcOptions.Addr = freeAddr(t)
+
+	// 注册一个一直阻塞到连接关闭的流式方法，使 gRPC 服务器无法在截止时间内优雅关停
+	started := make(chan struct{})
+	grpcsrv, err := NewGRPCServer(grpcOptions, nil, func(s grpc.ServiceRegistrar) {
+		s.RegisterService(&grpc.ServiceDesc{
+			ServiceName: "test.Block",
+			HandlerType: (*any)(nil),
+			Streams: []grpc.StreamDesc{{StreamName: "Block", ServerStreams: true, Handler: func(_ any, ss grpc.ServerStream) error {
+				close(started)
+				<-ss.Context().Done()
+				return nil
+			}}},
+		}, struct{}{})
+	})
+	require.NoError(t, err)
+
+	manager := NewManager(grpcsrv)
+	require.NoError(t, manager.Start(context.Background()))
+
+	conn, err := grpc.NewClient(grpcOptions.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	stream, err := conn.NewStream(context.Background(), &grpc.StreamDesc{ServerStreams: true}, "/test.Block/Block")
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMsg(&emptypb.Empty{}))
+	<-started
+
+	// 截止时间到期后强制关停服务器
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	assert.NoError(t, manager.Stop(ctx))
+	assert.Less(t, time.Since(begin), 5*time.Second)
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+	"google.golang.org/grpc"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+)
+
+// MuxServer 代表一个在同一端口上同时提供 gRPC 服务和 gRPC-Gateway HTTP 服务的服务器.
+//
+// Content-Type 为 application/grpc 的 HTTP/2 请求交给 gRPC 服务器处理，其他请求交给 gRPC-Gateway 处理.
+// 开启 TLS 时通过 ALPN 协商 HTTP/2；没有开启 TLS 时支持 h2c（基于先验知识的明文 HTTP/2），gRPC 客户端即使用这种方式.
+// gRPC-Gateway 通过同一端口连接 gRPC 服务器.
+type MuxServer struct {
+	srv     *http.Server
+	network string
+	lis     net.Listener
+	grpcsrv *grpc.Server
+}
+
+// NewMuxServer 创建一个新的单端口服务器实例. grpcServer 为通过 NewGRPCServer 创建的 gRPC 服务器，
+// 它使用 httpOptions 中的地址和 TLS 配置对外提供服务，自身的监听地址和 TLS 配置不会被使用.
+// 注意：httpOptions 中的读写超时时间同样作用于 gRPC 请求，使用长时间运行的流式 RPC 时需要相应调大.
+func NewMuxServer(
+	httpOptions *genericoptions.HTTPOptions,
+	grpcServer *GRPCServer,
+	registerHandler func(mux *runtime.ServeMux, conn *grpc.ClientConn) error,
+	muxOptions ...runtime.ServeMuxOption,
+) (*MuxServer, error) {
+	// gRPC-Gateway 通过同一端口连接 gRPC 服务器
+	gwmux, err := newGatewayMux(&genericoptions.GRPCOptions{
+		Network:    httpOptions.Network,
+		Addr:       httpOptions.Addr,
+		TLSOptions: httpOptions.TLSOptions,
+	}, registerHandler, muxOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := httpOptions.TLSOptions.ServerTLSConfig()
+	if err != nil {
+		log.Errorw("Failed to load TLS config", "err", err)
+		return nil, err
+	}
+
+	// 开启 TLS 时通过 ALPN 协商 HTTP/2，否则允许明文 HTTP/2（h2c）
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(tlsConfig == nil)
+
+	srv := newHTTPServer(httpOptions, grpcHandlerFunc(grpcServer.srv, gwmux), tlsConfig)
+	srv.Protocols = protocols
+
+	return &MuxServer{srv: srv, network: httpOptions.Network, grpcsrv: grpcServer.srv}, nil
+}
+
+// Listen 监听单端口服务器的地址.
+func (s *MuxServer) Listen() error {
+	lis, err := listen(s.network, s.srv)
+	if err != nil {
+		log.Errorw("Failed to listen", "err", err)
+		return err
+	}
+	s.lis = lis
+	return nil
+}
+
+// Serve 启动单端口服务器，直到服务器关停.
+func (s *MuxServer) Serve() error {
+	log.Infow("Start to listening the incoming requests", "protocol", protocolName(s.srv)+"+grpc", "addr", s.lis.Addr().String())
+	return serve(s.srv, s.lis)
+}
+
+// GracefulStop 优雅地关闭单端口服务器.
+func (s *MuxServer) GracefulStop(ctx context.Context) {
+	log.Infow("Gracefully stop HTTP(s) and grpc server")
+	if err := s.srv.Shutdown(ctx); err != nil {
+		log.Errorw("HTTP(s) server forced to shutdown", "err", err)
+	}
+	// 通过 ServeHTTP 处理的 gRPC 请求已经随 HTTP 服务器一起关停，这里释放 gRPC 服务器的资源.
+	// 注意：不能调用 GracefulStop，grpc.Server.ServeHTTP 不支持优雅关停.
+	s.grpcsrv.Stop()
+}
+
+// grpcHandlerFunc 返回一个 http.Handler，将 gRPC 请求交给 grpcServer 处理，其他请求交给 otherHandler 处理.
+func grpcHandlerFunc(grpcServer *grpc.Server, otherHandler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+			grpcServer.ServeHTTP(w, r)
+			return
+		}
+		otherHandler.ServeHTTP(w, r)
+	})
+}
@@ -2,7 +2,7 @@ package server
 
 import (
 	"context"
-	"errors"
+	"net"
 	"net/http"
 	"time"
 
@@ -10,15 +10,20 @@ import (
 	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	mw "github.com/ra1n6ow/opsx/internal/pkg/middleware/grpc"
+	"github.com/ra1n6ow/opsx/internal/pkg/tracing"
 )
 
 // GRPCGatewayServer 代表一个 GRPC 网关服务器.
 type GRPCGatewayServer struct {
-	srv *http.Server
+	srv     *http.Server
+	network string
+	lis     net.Listener
 }
 
 // NewGRPCGatewayServer 创建一个新的 GRPC 网关服务器实例.
@@ -26,47 +31,88 @@ func NewGRPCGatewayServer(
 	httpOptions *genericoptions.HTTPOptions,
 	grpcOptions *genericoptions.GRPCOptions,
 	registerHandler func(mux *runtime.ServeMux, conn *grpc.ClientConn) error,
+	muxOptions ...runtime.ServeMuxOption,
 ) (*GRPCGatewayServer, error) {
+	gwmux, err := newGatewayMux(grpcOptions, registerHandler, muxOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := httpOptions.TLSOptions.ServerTLSConfig()
+	if err != nil {
+		log.Errorw("Failed to load TLS config", "err", err)
+		return nil, err
+	}
+
+	return &GRPCGatewayServer{srv: newHTTPServer(httpOptions, gwmux, tlsConfig), network: httpOptions.Network}, nil
+}
+
+// newGatewayMux 创建连接 grpcOptions 指定的 gRPC 服务器的 gRPC-Gateway 路由.
+func newGatewayMux(
+	grpcOptions *genericoptions.GRPCOptions,
+	registerHandler func(mux *runtime.ServeMux, conn *grpc.ClientConn) error,
+	muxOptions ...runtime.ServeMuxOption,
+) (*runtime.ServeMux, error) {
 	dialOptions := []grpc.DialOption{
 		grpc.WithConnectParams(grpc.ConnectParams{
 			Backoff:           backoff.DefaultConfig,
 			MinConnectTimeout: 10 * time.Second, // 最小连接超时时间
 		}),
+		// 将网关的链路上下文传递给 gRPC 服务器
+		grpc.WithChainUnaryInterceptor(mw.TracingClientInterceptor()),
+		grpc.WithChainStreamInterceptor(mw.TracingStreamClientInterceptor()),
+	}
+	// gRPC 服务器开启 TLS 时，使用 TLS（或 mTLS）连接 gRPC 服务器
+	creds, err := dialCredentials(grpcOptions)
+	if err != nil {
+		log.Errorw("Failed to load TLS config", "err", err)
+		return nil, err
 	}
-	dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	dialOptions = append(dialOptions, grpc.WithTransportCredentials(creds))
 
-	conn, err := grpc.NewClient(grpcOptions.Addr, dialOptions...)
+	conn, err := grpc.NewClient(dialTarget(grpcOptions), dialOptions...)
 	if err != nil {
 		log.Errorw("Failed to dial context", "err", err)
 		return nil, err
 	}
 
-	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-		MarshalOptions: protojson.MarshalOptions{
-			// 设置序列化 protobuf 数据时，枚举类型的字段以数字格式输出.
-			// 否则，默认会以字符串格式输出，跟枚举类型定义不一致，带来理解成本.
-			UseEnumNumbers: true,
-		},
-	}))
+	// 默认选项放在最前面，调用方传入的 muxOptions 可以覆盖默认选项
+	muxOptions = append([]runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				// 设置序列化 protobuf 数据时，枚举类型的字段以数字格式输出.
+				// 否则，默认会以字符串格式输出，跟枚举类型定义不一致，带来理解成本.
+				UseEnumNumbers: true,
+			},
+		}),
+		// 从 HTTP 请求头中提取链路上下文，并创建网关的服务端 Span
+		runtime.WithMiddlewares(tracing.GatewayMiddleware()),
+	}, muxOptions...)
+	gwmux := runtime.NewServeMux(muxOptions...)
+
 	if err := registerHandler(gwmux, conn); err != nil {
 		log.Errorw("Failed to register handler", "err", err)
 		return nil, err
 	}
 
-	return &GRPCGatewayServer{
-		srv: &http.Server{
-			Addr:    httpOptions.Addr,
-			Handler: gwmux,
-		},
-	}, nil
+	return gwmux, nil
 }
 
-// RunOrDie 启动 GRPC 网关服务器并在出错时记录致命错误.
-func (s *GRPCGatewayServer) RunOrDie() {
-	log.Infow("Start to listening the incoming requests", "protocol", protocolName(s.srv), "addr", s.srv.Addr)
-	if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		log.Fatalw("Failed to server HTTP(s) server", "err", err)
+// Listen 监听 GRPC 网关服务器的地址.
+func (s *GRPCGatewayServer) Listen() error {
+	lis, err := listen(s.network, s.srv)
+	if err != nil {
+		log.Errorw("Failed to listen", "err", err)
+		return err
 	}
+	s.lis = lis
+	return nil
+}
+
+// Serve 启动 GRPC 网关服务器，直到服务器关停.
+func (s *GRPCGatewayServer) Serve() error {
+	log.Infow("Start to listening the incoming requests", "protocol", protocolName(s.srv), "addr", s.lis.Addr().String())
+	return serve(s.srv, s.lis)
 }
 
 // GracefulStop 优雅地关闭 GRPC 网关服务器.
@@ -76,3 +122,28 @@ func (s *GRPCGatewayServer) GracefulStop(ctx context.Context) {
 		log.Errorw("HTTP(s) server forced to shutdown", "err", err)
 	}
 }
+
+// dialCredentials 根据 gRPC 服务器的 TLS 配置，返回网关连接 gRPC 服务器时使用的传输凭证.
+func dialCredentials(grpcOptions *genericoptions.GRPCOptions) (credentials.TransportCredentials, error) {
+	addr := grpcOptions.Addr
+	// 通过 unix socket 连接时，地址中没有主机名，使用 localhost 校验服务端证书（除非配置了 ServerName）
+	if grpcOptions.Network == "unix" {
+		addr = "localhost"
+	}
+	tlsConfig, err := grpcOptions.TLSOptions.ClientTLSConfig(addr)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return insecure.NewCredentials(), nil
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// dialTarget 返回网关连接 gRPC 服务器时使用的目标地址. gRPC 服务器监听 unix socket 时，使用 unix:// 格式的地址.
+func dialTarget(grpcOptions *genericoptions.GRPCOptions) string {
+	if grpcOptions.Network == "unix" {
+		return "unix://" + grpcOptions.Addr
+	}
+	return grpcOptions.Addr
+}
@@ -2,13 +2,23 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"os"
+
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
 )
 
 // Server 定义所有服务器类型的接口.
+// 服务器的生命周期通常由 Manager 管理：先调用 Listen 监听端口，再调用 Serve 处理请求，最后调用 GracefulStop 关停.
 type Server interface {
-	// RunOrDie 运行服务器，如果运行失败会退出程序（OrDie的含义所在）.
-	RunOrDie()
+	// Listen 监听服务器端口. Listen 成功返回后，服务器即已就绪，可以接受连接.
+	Listen() error
+	// Serve 阻塞处理请求，直到服务器关停. 服务器被 GracefulStop 正常关停时返回 nil.
+	Serve() error
 	// GracefulStop 方法用来优雅关停服务器。关停服务器时需要处理 context 的超时时间.
 	GracefulStop(ctx context.Context)
 }
@@ -20,3 +30,71 @@ func protocolName(server *http.Server) string {
 	}
 	return "http"
 }
+
+// netListen 监听 network 网络上的 addr 地址. network 为空时使用 tcp.
+// network 为 unix 时，addr 为 socket 文件路径，上次运行遗留的 socket 文件会被删除.
+func netListen(network, addr string) (net.Listener, error) {
+	if network == "" {
+		network = "tcp"
+	}
+	if network == "unix" {
+		if err := removeStaleSocket(addr); err != nil {
+			return nil, err
+		}
+	}
+	return net.Listen(network, addr)
+}
+
+// removeStaleSocket 删除 path 处遗留的 socket 文件. path 存在但不是 socket 文件时返回错误，避免误删其他文件.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s already exists and is not a socket file", path)
+	}
+	return os.Remove(path)
+}
+
+// newHTTPServer 根据 httpOptions 创建 http.Server，设置服务器的地址、超时时间和请求头大小限制.
+func newHTTPServer(httpOptions *genericoptions.HTTPOptions, handler http.Handler, tlsConfig *tls.Config) *http.Server {
+	return &http.Server{
+		Addr:              httpOptions.Addr,
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadTimeout:       httpOptions.EffectiveReadTimeout(),
+		ReadHeaderTimeout: httpOptions.ReadHeaderTimeout,
+		WriteTimeout:      httpOptions.EffectiveWriteTimeout(),
+		IdleTimeout:       httpOptions.IdleTimeout,
+		MaxHeaderBytes:    httpOptions.MaxHeaderBytes,
+	}
+}
+
+// listen 监听 HTTP 服务器的地址. 如果配置了 TLS，则返回 TLS 监听器，证书已经包含在 TLSConfig 中.
+func listen(network string, server *http.Server) (net.Listener, error) {
+	lis, err := netListen(network, server.Addr)
+	if err != nil {
+		return nil, err
+	}
+	if server.TLSConfig != nil {
+		// 与 http.Server.ServeTLS 一致，默认同时支持 HTTP/2 和 HTTP/1.1.
+		// http.Server 根据 TLSConfig.NextProtos 判断是否启用 HTTP/2，因此直接修改 TLSConfig
+		if len(server.TLSConfig.NextProtos) == 0 {
+			server.TLSConfig.NextProtos = []string{"h2", "http/1.1"}
+		}
+		return tls.NewListener(lis, server.TLSConfig), nil
+	}
+	return lis, nil
+}
+
+// serve 使用 lis 启动 HTTP 服务器. 服务器正常关停时返回 nil.
+func serve(server *http.Server, lis net.Listener) error {
+	if err := server.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
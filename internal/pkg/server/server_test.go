@@ -0,0 +1,281 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/testutil"
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+// freeAddr 返回一个当前可用的本地监听地址.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	return lis.Addr().String()
+}
+
+func TestGRPCGatewayServerWithMutualTLS(t *testing.T) {
+	certs := testutil.GenerateCerts(t)
+
+	grpcOptions := genericoptions.NewGRPCOptions()
+	grpcOptions.Addr = freeAddr(t)
+	grpcOptions.TLSOptions = &genericoptions.TLSOptions{
+		UseTLS:     true,
+		Cert:       certs.ServerCert,
+		Key:        certs.ServerKey,
+		CACert:     certs.CACert,
+		ClientAuth: genericoptions.ClientAuthRequireAndVerify,
+	}
+	httpOptions := genericoptions.NewHTTPOptions()
+	httpOptions.Addr = freeAddr(t)
+	httpOptions.TLSOptions = &genericoptions.TLSOptions{
+		UseTLS:     true,
+		Cert:       certs.ServerCert,
+		Key:        certs.ServerKey,
+		ClientAuth: genericoptions.ClientAuthNone,
+	}
+
+	// 启动开启了 mTLS 的 gRPC 服务器
+	grpcsrv, err := NewGRPCServer(grpcOptions, nil, func(grpc.ServiceRegistrar) {})
+	require.NoError(t, err)
+
+	// 启动 HTTPS 网关服务器，网关通过 mTLS 调用 gRPC 服务器的健康检查接口
+	gwsrv, err := NewGRPCGatewayServer(httpOptions, grpcOptions, func(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+		return mux.HandlePath(http.MethodGet, "/healthz", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+			resp, err := grpc_health_v1.NewHealthClient(conn).Check(r.Context(), &grpc_health_v1.HealthCheckRequest{})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			_, _ = io.WriteString(w, resp.GetStatus().String())
+		})
+	})
+	require.NoError(t, err)
+
+	// Start 返回时，两个服务器都已经开始监听
+	manager := NewManager(grpcsrv, gwsrv)
+	require.NoError(t, manager.Start(context.Background()))
+	defer manager.Stop(context.Background())
+
+	// 使用信任 CA 的 HTTPS 客户端访问网关
+	clientTLSConfig, err := (&genericoptions.TLSOptions{UseTLS: true, CACert: certs.CACert}).ClientTLSConfig(httpOptions.Addr)
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLSConfig}, Timeout: 5 * time.Second}
+
+	resp, err := client.Get("https://" + httpOptions.Addr + "/healthz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING.String(), string(body))
+
+	// 使用明文 HTTP 访问 HTTPS 网关失败
+	plainResp, err := (&http.Client{Timeout: 5 * time.Second}).Get("http://" + httpOptions.Addr + "/healthz")
+	if err == nil {
+		defer plainResp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, plainResp.StatusCode)
+	}
+
+	// 不携带客户端证书的 gRPC 客户端无法通过 mTLS 校验
+	noCertTLSConfig, err := (&genericoptions.TLSOptions{UseTLS: true, CACert: certs.CACert}).ClientTLSConfig(grpcOptions.Addr)
+	require.NoError(t, err)
+	conn, err := grpc.NewClient(grpcOptions.Addr, grpc.WithTransportCredentials(credentials.NewTLS(noCertTLSConfig)))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	assert.Error(t, err)
+}
+
+func TestHTTPServerWithTLS(t *testing.T) {
+	certs := testutil.GenerateCerts(t)
+
+	httpOptions := genericoptions.NewHTTPOptions()
+	httpOptions.Addr = freeAddr(t)
+	httpOptions.TLSOptions = &genericoptions.TLSOptions{
+		UseTLS:     true,
+		Cert:       certs.ServerCert,
+		Key:        certs.ServerKey,
+		ClientAuth: genericoptions.ClientAuthNone,
+	}
+
+	srv, err := NewHTTPServer(httpOptions, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "https", protocolName(srv.srv))
+	manager := NewManager(srv)
+	require.NoError(t, manager.Start(context.Background()))
+	defer manager.Stop(context.Background())
+
+	clientTLSConfig, err := (&genericoptions.TLSOptions{UseTLS: true, CACert: certs.CACert}).ClientTLSConfig(httpOptions.Addr)
+	require.NoError(t, err)
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLSConfig}, Timeout: 5 * time.Second}
+
+	resp, err := client.Get("https://" + httpOptions.Addr)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(body))
+
+	// 不信任服务端证书的客户端无法建立连接
+	_, err = (&http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12}}}).Get("https://" + httpOptions.Addr)
+	assert.Error(t, err)
+}
+
+func TestMuxServer(t *testing.T) {
+	certs := testutil.GenerateCerts(t)
+
+	tests := []struct {
+		name       string
+		tlsOptions *genericoptions.TLSOptions
+	}{
+		{name: "h2c"},
+		{name: "tls", tlsOptions: &genericoptions.TLSOptions{UseTLS: true, Cert: certs.ServerCert, Key: certs.ServerKey, CACert: certs.CACert}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpOptions := genericoptions.NewHTTPOptions()
+			httpOptions.Addr = freeAddr(t)
+			httpOptions.TLSOptions = tt.tlsOptions
+
+			grpcsrv, err := NewGRPCServer(genericoptions.NewGRPCOptions(), nil, func(grpc.ServiceRegistrar) {})
+			require.NoError(t, err)
+
+			// 网关通过同一端口调用 gRPC 服务器的健康检查接口
+			muxsrv, err := NewMuxServer(httpOptions, grpcsrv, func(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+				return mux.HandlePath(http.MethodGet, "/healthz", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+					resp, err := grpc_health_v1.NewHealthClient(conn).Check(r.Context(), &grpc_health_v1.HealthCheckRequest{})
+					if err != nil {
+						http.Error(w, err.Error(), http.StatusBadGateway)
+						return
+					}
+					_, _ = io.WriteString(w, resp.GetStatus().String())
+				})
+			})
+			require.NoError(t, err)
+
+			manager := NewManager(muxsrv)
+			require.NoError(t, manager.Start(context.Background()))
+			defer manager.Stop(context.Background())
+
+			clientTLSConfig, err := tt.tlsOptions.ClientTLSConfig(httpOptions.Addr)
+			require.NoError(t, err)
+
+			// gRPC 客户端直接访问
+			creds := insecure.NewCredentials()
+			scheme := "http"
+			if clientTLSConfig != nil {
+				creds = credentials.NewTLS(clientTLSConfig)
+				scheme = "https"
+			}
+			conn, err := grpc.NewClient(httpOptions.Addr, grpc.WithTransportCredentials(creds))
+			require.NoError(t, err)
+			defer conn.Close()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+			// HTTP/1.1 客户端通过网关访问
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLSConfig}, Timeout: 5 * time.Second}
+			httpResp, err := client.Get(scheme + "://" + httpOptions.Addr + "/healthz")
+			require.NoError(t, err)
+			defer httpResp.Body.Close()
+			body, err := io.ReadAll(httpResp.Body)
+			require.NoError(t, err)
+			assert.Equal(t, 1, httpResp.ProtoMajor)
+			assert.Equal(t, "SERVING", string(body))
+		})
+	}
+}
+
+func TestGRPCGatewayServerWithUnixSocket(t *testing.T) {
+	grpcOptions := genericoptions.NewGRPCOptions()
+	grpcOptions.Network = "unix"
+	grpcOptions.Addr = filepath.Join(t.TempDir(), "grpc.sock")
+	httpOptions := genericoptions.NewHTTPOptions()
+	httpOptions.Addr = freeAddr(t)
+
+	// 模拟上次运行遗留的 socket 文件
+	stale, err := net.Listen("unix", grpcOptions.Addr)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	grpcsrv, err := NewGRPCServer(grpcOptions, nil, func(grpc.ServiceRegistrar) {})
+	require.NoError(t, err)
+
+	// 网关通过 unix socket 调用 gRPC 服务器的健康检查接口
+	gwsrv, err := NewGRPCGatewayServer(httpOptions, grpcOptions, func(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+		return mux.HandlePath(http.MethodGet, "/healthz", func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+			resp, err := grpc_health_v1.NewHealthClient(conn).Check(r.Context(), &grpc_health_v1.HealthCheckRequest{})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			_, _ = io.WriteString(w, resp.GetStatus().String())
+		})
+	})
+	require.NoError(t, err)
+
+	manager := NewManager(grpcsrv, gwsrv)
+	require.NoError(t, manager.Start(context.Background()))
+	defer manager.Stop(context.Background())
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get("http://" + httpOptions.Addr + "/healthz")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "SERVING", string(body))
+}
+
+func TestNetListenRefusesNonSocketFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "grpc.sock")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	_, err := netListen("unix", path)
+	assert.Error(t, err)
+	assert.FileExists(t, path)
+}
+
+func TestNewHTTPServerTimeouts(t *testing.T) {
+	httpOptions := genericoptions.NewHTTPOptions()
+	httpOptions.WriteTimeout = time.Minute
+
+	srv, err := NewHTTPServer(httpOptions, http.NotFoundHandler())
+	require.NoError(t, err)
+	assert.Equal(t, httpOptions.Timeout, srv.srv.ReadTimeout)
+	assert.Equal(t, time.Minute, srv.srv.WriteTimeout)
+	assert.Equal(t, httpOptions.ReadHeaderTimeout, srv.srv.ReadHeaderTimeout)
+	assert.Equal(t, httpOptions.IdleTimeout, srv.srv.IdleTimeout)
+	assert.Equal(t, httpOptions.MaxHeaderBytes, srv.srv.MaxHeaderBytes)
+}
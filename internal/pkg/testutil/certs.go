@@ -0,0 +1,149 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package testutil 提供单元测试中使用的辅助函数.
+package testutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Certs 保存测试证书文件的路径.
+type Certs struct {
+	// CACert 为 CA 证书.
+	CACert string
+	// ServerCert 和 ServerKey 为服务端证书和私钥，证书对 localhost 和 127.0.0.1 有效.
+	ServerCert string
+	ServerKey  string
+	// ClientCert 和 ClientKey 为由同一个 CA 签发的客户端证书和私钥.
+	ClientCert string
+	ClientKey  string
+	// UntrustedCert 和 UntrustedKey 为由另一个 CA 签发的客户端证书和私钥.
+	UntrustedCert string
+	UntrustedKey  string
+}
+
+// GenerateCerts 在临时目录中生成一套用于测试 TLS 和 mTLS 的证书.
+func GenerateCerts(t testing.TB) *Certs {
+	t.Helper()
+
+	dir := t.TempDir()
+	ca, caKey := newCA(t, "opsx-test-ca")
+	otherCA, otherCAKey := newCA(t, "opsx-untrusted-ca")
+
+	certs := &Certs{CACert: filepath.Join(dir, "ca.crt")}
+	writePEM(t, certs.CACert, "CERTIFICATE", ca.Raw)
+
+	certs.ServerCert, certs.ServerKey = newLeaf(t, dir, "server", ca, caKey, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "localhost"},
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.ParseIP("127.0.0.1"), net.IPv6loopback},
+		// 网关连接 gRPC 服务器时也会使用服务端证书作为客户端证书
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	})
+	certs.ClientCert, certs.ClientKey = newLeaf(t, dir, "client", ca, caKey, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "opsx-client"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	certs.UntrustedCert, certs.UntrustedKey = newLeaf(t, dir, "untrusted", otherCA, otherCAKey, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "opsx-untrusted"},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	return certs
+}
+
+// newCA 创建一个自签名的 CA 证书.
+func newCA(t testing.TB, name string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          newSerial(t),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+
+	return cert, key
+}
+
+// newLeaf 使用 CA 签发证书，并将证书和私钥写入 dir 目录，返回证书和私钥的路径.
+func newLeaf(t testing.TB, dir, name string, ca *x509.Certificate, caKey *ecdsa.PrivateKey, template *x509.Certificate) (string, string) {
+	t.Helper()
+
+	key := newKey(t)
+	template.SerialNumber = newSerial(t)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(24 * time.Hour)
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("failed to create %s certificate: %v", name, err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to marshal %s key: %v", name, err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+
+	return certFile, keyFile
+}
+
+func newKey(t testing.TB) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return key
+}
+
+func newSerial(t testing.TB) *big.Int {
+	t.Helper()
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 62))
+	if err != nil {
+		t.Fatalf("failed to generate serial number: %v", err)
+	}
+	return serial
+}
+
+func writePEM(t testing.TB, file, blockType string, der []byte) {
+	t.Helper()
+
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", file, err)
+	}
+}
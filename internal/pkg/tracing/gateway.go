@@ -0,0 +1,37 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// GatewayMiddleware 返回一个 gRPC-Gateway 中间件，用于从 HTTP 请求头中提取链路上下文并创建服务端 Span.
+// 网关随后通过 ClientConn 调用 gRPC 服务时，客户端拦截器会将该 Span 的上下文传递给 gRPC 服务器.
+func GatewayMiddleware() runtime.Middleware {
+	return func(next runtime.HandlerFunc) runtime.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+			route := r.URL.Path
+			if pattern, ok := runtime.HTTPPattern(r.Context()); ok {
+				route = pattern.String()
+			}
+
+			ctx := Propagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx, span := Start(ctx, r.Method+" "+route,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(semconv.HTTPRequestMethodKey.String(r.Method), semconv.HTTPRoute(route)),
+			)
+			defer span.End()
+
+			next(w, r.WithContext(ctx), pathParams)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package tracing 提供 gRPC、gRPC-Gateway 和 Gin 服务器共用的 OpenTelemetry 链路追踪辅助函数.
+//
+// 中间件使用全局的 TracerProvider 和 TextMapPropagator，没有调用 Init 时均为空实现，不会产生任何开销.
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// instrumentationName 为创建 Tracer 时使用的名称.
+const instrumentationName = "github.com/ra1n6ow/opsx"
+
+// ReasonKey 为记录 errorsx.Reason 的 Span 属性.
+const ReasonKey = attribute.Key("opsx.error.reason")
+
+// Init 设置全局的 TracerProvider 和 TextMapPropagator.
+// 传播格式为 W3C Trace Context 和 Baggage.
+func Init(tp trace.TracerProvider) {
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Tracer 返回全局 TracerProvider 创建的 Tracer.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Propagator 返回全局的 TextMapPropagator.
+func Propagator() propagation.TextMapPropagator {
+	return otel.GetTextMapPropagator()
+}
+
+// Start 创建一个 Span. 它是 Tracer().Start 的简写.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// End 根据 err 设置 Span 的状态并结束 Span. 发生错误时，记录错误及其 errorsx.Reason.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, errorsx.FromError(err).Message)
+		span.SetAttributes(ReasonKey.String(errorsx.Reason(err)))
+	}
+	span.End()
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package usercenter
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/known"
+	"github.com/ra1n6ow/opsx/internal/usercenter/store"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/authz"
+)
+
+// roleResolver 根据存储中的角色分配关系和启动配置解析用户拥有的角色.
+type roleResolver struct {
+	store store.IStore
+	// adminUserIDs 为通过配置指定的管理员用户 ID. 用户 ID 由服务端生成，用户无法自行指定，
+	// 而用户名可以在注册或者修改用户信息时任意设置，因此不能根据用户名授予管理员角色.
+	adminUserIDs sets.Set[string]
+}
+
+// 确保 *roleResolver 实现了 authz.RoleResolver 接口.
+var _ authz.RoleResolver = (*roleResolver)(nil)
+
+// Roles 返回用户拥有的全部角色. 所有存在的用户默认拥有 known.RoleUser 角色，
+// 用户 ID 在 adminUserIDs 中的用户额外拥有 known.RoleAdmin 角色.
+func (r *roleResolver) Roles(ctx context.Context, userID string) ([]string, error) {
+	if userID == "" {
+		return nil, nil
+	}
+
+	if _, err := r.store.User().Get(ctx, userID); err != nil {
+		// 用户不存在（例如已被删除）时，不拥有任何角色
+		if errors.Is(err, errno.ErrUserNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	stored, err := r.store.Role().List(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := sets.New(stored...).Insert(known.RoleUser)
+	if r.adminUserIDs.Has(userID) {
+		roles.Insert(known.RoleAdmin)
+	}
+
+	return sets.List(roles), nil
+}
+
+// defaultPolicies 返回 usercenter 内置的授权策略. 策略中的资源为 gRPC 方法全名.
+// 普通用户能否操作某个具体的用户资源，由 biz 层进一步校验.
+func defaultPolicies() []authz.Policy {
+	policies := []authz.Policy{
+		// 管理员可以调用所有接口
+		{Role: known.RoleAdmin, Object: "*"},
+	}
+
+	userMethods := []string{
+		ucv1.Usercenter_RefreshToken_FullMethodName,
+		ucv1.Usercenter_GetUser_FullMethodName,
+		ucv1.Usercenter_UpdateUser_FullMethodName,
+		ucv1.Usercenter_DeleteUser_FullMethodName,
+		ucv1.Usercenter_ListUserRoles_FullMethodName,
+	}
+	for _, method := range userMethods {
+		policies = append(policies, authz.Policy{Role: known.RoleUser, Object: method})
+	}
+
+	return policies
+}
+
+// NewAuthorizer 创建 usercenter 使用的授权器.
+func (c *Config) NewAuthorizer(store store.IStore) *authz.Authorizer {
+	resolver := &roleResolver{store: store, adminUserIDs: sets.New(c.AdminUserIDs...)}
+	return authz.NewAuthorizer(resolver, defaultPolicies()...)
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package usercenter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/known"
+	"github.com/ra1n6ow/opsx/internal/usercenter/biz/v1/user"
+	"github.com/ra1n6ow/opsx/internal/usercenter/model"
+	"github.com/ra1n6ow/opsx/internal/usercenter/store"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+func TestAdminRoleCannotBeClaimedByUsername(t *testing.T) {
+	ctx := context.Background()
+	st := store.NewMemoryStore()
+	cfg := &Config{AdminUserIDs: []string{"user-root"}}
+	authorizer := cfg.NewAuthorizer(st)
+	users := user.New(st, authorizer)
+
+	// 注册一个与管理员用户 ID 同名的用户，不会获得管理员角色
+	created, err := users.Create(ctx, &ucv1.CreateUserRequest{Username: "user-root", Password: "opsx(#)666"})
+	require.NoError(t, err)
+	mallory := created.GetUserID()
+	require.NotEqual(t, "user-root", mallory)
+
+	isAdmin, err := authorizer.HasSubjectRole(ctx, mallory, known.RoleAdmin)
+	require.NoError(t, err)
+	assert.False(t, isAdmin)
+
+	// 修改自己的用户名，同样不会获得管理员角色
+	_, err = users.Update(contextx.WithUserID(ctx, mallory), &ucv1.UpdateUserRequest{UserID: mallory, Username: proto.String("root")})
+	require.NoError(t, err)
+
+	allowed, err := authorizer.Authorize(ctx, mallory, ucv1.Usercenter_AssignRole_FullMethodName)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	// 只有用户 ID 在配置中的用户拥有管理员角色
+	require.NoError(t, st.User().Create(ctx, &model.UserM{UserID: "user-root", Username: "admin"}))
+	allowed, err = authorizer.Authorize(ctx, "user-root", ucv1.Usercenter_AssignRole_FullMethodName)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+}
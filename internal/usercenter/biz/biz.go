@@ -0,0 +1,53 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package biz
+
+import (
+	loglevelv1 "github.com/ra1n6ow/opsx/internal/usercenter/biz/v1/loglevel"
+	rolev1 "github.com/ra1n6ow/opsx/internal/usercenter/biz/v1/role"
+	userv1 "github.com/ra1n6ow/opsx/internal/usercenter/biz/v1/user"
+	"github.com/ra1n6ow/opsx/internal/usercenter/store"
+	"github.com/ra1n6ow/opsx/pkg/authz"
+)
+
+// IBiz 定义了业务层需要实现的方法.
+type IBiz interface {
+	// UserV1 获取用户业务接口.
+	UserV1() userv1.UserBiz
+	// RoleV1 获取用户角色业务接口.
+	RoleV1() rolev1.RoleBiz
+	// LogLevelV1 获取日志级别业务接口.
+	LogLevelV1() loglevelv1.LogLevelBiz
+}
+
+// biz 是 IBiz 的一个具体实现.
+type biz struct {
+	store store.IStore
+	authz *authz.Authorizer
+}
+
+// 确保 biz 实现了 IBiz 接口.
+var _ IBiz = (*biz)(nil)
+
+// NewBiz 创建一个 IBiz 类型的实例.
+func NewBiz(store store.IStore, authz *authz.Authorizer) *biz {
+	return &biz{store: store, authz: authz}
+}
+
+// UserV1 返回一个实现了 UserBiz 接口的实例.
+func (b *biz) UserV1() userv1.UserBiz {
+	return userv1.New(b.store, b.authz)
+}
+
+// RoleV1 返回一个实现了 RoleBiz 接口的实例.
+func (b *biz) RoleV1() rolev1.RoleBiz {
+	return rolev1.New(b.store, b.authz)
+}
+
+// LogLevelV1 返回一个实现了 LogLevelBiz 接口的实例.
+func (b *biz) LogLevelV1() loglevelv1.LogLevelBiz {
+	return loglevelv1.New()
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package loglevel
+
+import (
+	"context"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/internal/usercenter/pkg/conversion"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// LogLevelBiz 定义处理日志级别请求所需的方法.
+type LogLevelBiz interface {
+	Get(ctx context.Context, rq *ucv1.GetLogLevelRequest) (*ucv1.GetLogLevelResponse, error)
+	Update(ctx context.Context, rq *ucv1.UpdateLogLevelRequest) (*ucv1.UpdateLogLevelResponse, error)
+}
+
+// logLevelBiz 是 LogLevelBiz 接口的实现.
+type logLevelBiz struct{}
+
+// 确保 logLevelBiz 实现了 LogLevelBiz 接口.
+var _ LogLevelBiz = (*logLevelBiz)(nil)
+
+// New 创建 logLevelBiz 的实例.
+func New() *logLevelBiz {
+	return &logLevelBiz{}
+}
+
+// Get 实现 LogLevelBiz 接口中的 Get 方法.
+func (b *logLevelBiz) Get(ctx context.Context, rq *ucv1.GetLogLevelRequest) (*ucv1.GetLogLevelResponse, error) {
+	return conversion.LevelStatusToGetLogLevelResponseV1(log.Levels()), nil
+}
+
+// Update 实现 LogLevelBiz 接口中的 Update 方法.
+func (b *logLevelBiz) Update(ctx context.Context, rq *ucv1.UpdateLogLevelRequest) (*ucv1.UpdateLogLevelResponse, error) {
+	ttl := rq.GetTtl().AsDuration()
+
+	var err error
+	if rq.GetModule() == "" {
+		err = log.SetLevelWithTTL(rq.GetLevel(), ttl)
+	} else {
+		err = log.SetModuleLevel(rq.GetModule(), rq.GetLevel(), ttl)
+	}
+	if err != nil {
+		return nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error())
+	}
+
+	// 使用 warn 级别记录，确保修改日志级别的操作总是被记录
+	log.W(ctx).Warnw("Log level updated", "module", rq.GetModule(), "level", rq.GetLevel(), "ttl", ttl.String())
+	return conversion.LevelStatusToUpdateLogLevelResponseV1(log.Levels()), nil
+}
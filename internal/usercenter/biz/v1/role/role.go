@@ -0,0 +1,82 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package role
+
+import (
+	"context"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/internal/usercenter/pkg/permission"
+	"github.com/ra1n6ow/opsx/internal/usercenter/store"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/authz"
+)
+
+// RoleBiz 定义处理用户角色请求所需的方法.
+type RoleBiz interface {
+	Assign(ctx context.Context, rq *ucv1.AssignRoleRequest) (*ucv1.AssignRoleResponse, error)
+	Revoke(ctx context.Context, rq *ucv1.RevokeRoleRequest) (*ucv1.RevokeRoleResponse, error)
+	List(ctx context.Context, rq *ucv1.ListUserRolesRequest) (*ucv1.ListUserRolesResponse, error)
+}
+
+// roleBiz 是 RoleBiz 接口的实现.
+type roleBiz struct {
+	store store.IStore
+	authz *authz.Authorizer
+}
+
+// 确保 roleBiz 实现了 RoleBiz 接口.
+var _ RoleBiz = (*roleBiz)(nil)
+
+// New 创建 roleBiz 的实例.
+func New(store store.IStore, authz *authz.Authorizer) *roleBiz {
+	return &roleBiz{store: store, authz: authz}
+}
+
+// Assign 实现 RoleBiz 接口中的 Assign 方法.
+func (b *roleBiz) Assign(ctx context.Context, rq *ucv1.AssignRoleRequest) (*ucv1.AssignRoleResponse, error) {
+	// 只能分配已经定义了授权策略的角色
+	if !b.authz.HasRole(rq.GetRole()) {
+		return nil, errno.ErrRoleNotFound
+	}
+
+	// 确保用户存在
+	if _, err := b.store.User().Get(ctx, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	if err := b.store.Role().Add(ctx, rq.GetUserID(), rq.GetRole()); err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Role assigned to user", "userID", rq.GetUserID(), "role", rq.GetRole())
+	return &ucv1.AssignRoleResponse{}, nil
+}
+
+// Revoke 实现 RoleBiz 接口中的 Revoke 方法.
+func (b *roleBiz) Revoke(ctx context.Context, rq *ucv1.RevokeRoleRequest) (*ucv1.RevokeRoleResponse, error) {
+	if err := b.store.Role().Remove(ctx, rq.GetUserID(), rq.GetRole()); err != nil {
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Role revoked from user", "userID", rq.GetUserID(), "role", rq.GetRole())
+	return &ucv1.RevokeRoleResponse{}, nil
+}
+
+// List 实现 RoleBiz 接口中的 List 方法.
+func (b *roleBiz) List(ctx context.Context, rq *ucv1.ListUserRolesRequest) (*ucv1.ListUserRolesResponse, error) {
+	if err := permission.SelfOrAdmin(ctx, b.authz, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	roles, err := b.authz.SubjectRoles(ctx, rq.GetUserID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ucv1.ListUserRolesResponse{Roles: roles}, nil
+}
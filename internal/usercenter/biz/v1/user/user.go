@@ -0,0 +1,219 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/internal/pkg/rid"
+	"github.com/ra1n6ow/opsx/internal/usercenter/model"
+	"github.com/ra1n6ow/opsx/internal/usercenter/pkg/conversion"
+	"github.com/ra1n6ow/opsx/internal/usercenter/pkg/permission"
+	"github.com/ra1n6ow/opsx/internal/usercenter/store"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/auth"
+	"github.com/ra1n6ow/opsx/pkg/authz"
+	"github.com/ra1n6ow/opsx/pkg/token"
+)
+
+// UserBiz 定义处理用户请求所需的方法.
+type UserBiz interface {
+	Create(ctx context.Context, rq *ucv1.CreateUserRequest) (*ucv1.CreateUserResponse, error)
+	Update(ctx context.Context, rq *ucv1.UpdateUserRequest) (*ucv1.UpdateUserResponse, error)
+	Delete(ctx context.Context, rq *ucv1.DeleteUserRequest) (*ucv1.DeleteUserResponse, error)
+	Get(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error)
+	List(ctx context.Context, rq *ucv1.ListUsersRequest) (*ucv1.ListUsersResponse, error)
+
+	UserExpansion
+}
+
+// UserExpansion 定义用户操作的扩展方法.
+type UserExpansion interface {
+	Login(ctx context.Context, rq *ucv1.LoginRequest) (*ucv1.LoginResponse, error)
+	RefreshToken(ctx context.Context, rq *ucv1.RefreshTokenRequest) (*ucv1.RefreshTokenResponse, error)
+}
+
+// userBiz 是 UserBiz 接口的实现.
+type userBiz struct {
+	store store.IStore
+	authz *authz.Authorizer
+}
+
+// 确保 userBiz 实现了 UserBiz 接口.
+var _ UserBiz = (*userBiz)(nil)
+
+// New 创建 userBiz 的实例.
+func New(store store.IStore, authz *authz.Authorizer) *userBiz {
+	return &userBiz{store: store, authz: authz}
+}
+
+// Create 实现 UserBiz 接口中的 Create 方法.
+func (b *userBiz) Create(ctx context.Context, rq *ucv1.CreateUserRequest) (*ucv1.CreateUserResponse, error) {
+	// 数据库中只保存加密后的密码
+	password, err := auth.Encrypt(rq.GetPassword())
+	if err != nil {
+		log.W(ctx).Errorw("Failed to encrypt user password", "err", err)
+		return nil, errno.ErrInternal.WithCause(err)
+	}
+
+	userM := &model.UserM{
+		UserID:   rid.UserID.New(),
+		Username: rq.GetUsername(),
+		Password: password,
+		Nickname: rq.GetNickname(),
+		Email:    rq.GetEmail(),
+		Phone:    rq.GetPhone(),
+	}
+	if err := b.store.User().Create(ctx, userM); err != nil {
+		return nil, err
+	}
+
+	return &ucv1.CreateUserResponse{UserID: userM.UserID}, nil
+}
+
+// Update 实现 UserBiz 接口中的 Update 方法.
+func (b *userBiz) Update(ctx context.Context, rq *ucv1.UpdateUserRequest) (*ucv1.UpdateUserResponse, error) {
+	if err := permission.SelfOrAdmin(ctx, b.authz, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, rq.GetUserID())
+	if err != nil {
+		return nil, err
+	}
+
+	// 只更新请求中显式设置的字段
+	if rq.Username != nil {
+		userM.Username = rq.GetUsername()
+	}
+	if rq.Nickname != nil {
+		userM.Nickname = rq.GetNickname()
+	}
+	if rq.Email != nil {
+		userM.Email = rq.GetEmail()
+	}
+	if rq.Phone != nil {
+		userM.Phone = rq.GetPhone()
+	}
+
+	if err := b.store.User().Update(ctx, userM); err != nil {
+		return nil, err
+	}
+
+	return &ucv1.UpdateUserResponse{}, nil
+}
+
+// Delete 实现 UserBiz 接口中的 Delete 方法.
+func (b *userBiz) Delete(ctx context.Context, rq *ucv1.DeleteUserRequest) (*ucv1.DeleteUserResponse, error) {
+	if err := permission.SelfOrAdmin(ctx, b.authz, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	if err := b.store.User().Delete(ctx, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	// 用户删除后，清理该用户的角色分配关系
+	if err := b.store.Role().RemoveAll(ctx, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	return &ucv1.DeleteUserResponse{}, nil
+}
+
+// Get 实现 UserBiz 接口中的 Get 方法.
+func (b *userBiz) Get(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+	if err := permission.SelfOrAdmin(ctx, b.authz, rq.GetUserID()); err != nil {
+		return nil, err
+	}
+
+	userM, err := b.store.User().Get(ctx, rq.GetUserID())
+	if err != nil {
+		return nil, err
+	}
+
+	return &ucv1.GetUserResponse{User: conversion.UserModelToUserV1(userM)}, nil
+}
+
+// List 实现 UserBiz 接口中的 List 方法.
+func (b *userBiz) List(ctx context.Context, rq *ucv1.ListUsersRequest) (*ucv1.ListUsersResponse, error) {
+	count, userList, err := b.store.User().List(ctx, int(rq.GetOffset()), int(rq.GetLimit()))
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*ucv1.User, 0, len(userList))
+	for _, userM := range userList {
+		users = append(users, conversion.UserModelToUserV1(userM))
+	}
+
+	return &ucv1.ListUsersResponse{TotalCount: count, Users: users}, nil
+}
+
+// Login 实现 UserBiz 接口中的 Login 方法.
+func (b *userBiz) Login(ctx context.Context, rq *ucv1.LoginRequest) (*ucv1.LoginResponse, error) {
+	// 获取登录用户的所有信息
+	userM, err := b.store.User().GetByUsername(ctx, rq.GetUsername())
+	if err != nil {
+		if !errors.Is(err, errno.ErrUserNotFound) {
+			return nil, err
+		}
+		// 用户不存在时同样对比一次密码，使响应时间与密码错误时一致，并返回相同的错误，避免探测用户名是否存在
+		_ = auth.Compare(dummyPassword(), rq.GetPassword())
+		log.W(ctx).Warnw("Failed to login: user not found", "username", rq.GetUsername())
+		return nil, errno.ErrInvalidCredentials
+	}
+
+	// 对比传入的明文密码和数据库中已加密过的密码是否匹配
+	if err := auth.Compare(userM.Password, rq.GetPassword()); err != nil {
+		log.W(ctx).Warnw("Failed to login: password mismatch", "username", rq.GetUsername(), "err", err)
+		return nil, errno.ErrInvalidCredentials
+	}
+
+	// 如果匹配成功，说明登录成功，签发 token 并返回
+	tokenStr, expireAt, err := token.Sign(userM.UserID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign token", "err", err)
+		return nil, errno.ErrSignToken.WithCause(err)
+	}
+
+	return &ucv1.LoginResponse{Token: tokenStr, ExpireAt: timestamppb.New(expireAt)}, nil
+}
+
+// dummyPassword 返回用于用户不存在时对比密码的密文，只在第一次调用时生成.
+var dummyPassword = sync.OnceValue(func() string {
+	hashed, _ := auth.Encrypt("opsx-dummy-password")
+	return hashed
+})
+
+// RefreshToken 用于刷新用户的身份验证令牌.
+// 当用户的令牌即将过期时，可以调用此方法生成一个新的令牌.
+func (b *userBiz) RefreshToken(ctx context.Context, rq *ucv1.RefreshTokenRequest) (*ucv1.RefreshTokenResponse, error) {
+	userID := contextx.UserID(ctx)
+	if userID == "" {
+		return nil, errno.ErrUnauthenticated
+	}
+
+	// 确保令牌所属的用户仍然存在
+	if _, err := b.store.User().Get(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	tokenStr, expireAt, err := token.Sign(userID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to sign token", "err", err)
+		return nil, errno.ErrSignToken.WithCause(err)
+	}
+
+	return &ucv1.RefreshTokenResponse{Token: tokenStr, ExpireAt: timestamppb.New(expireAt)}, nil
+}
@@ -2,35 +2,31 @@ package usercenter
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/proto"
 
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+	"github.com/ra1n6ow/opsx/internal/pkg/health"
+	"github.com/ra1n6ow/opsx/internal/pkg/metrics"
 	mw "github.com/ra1n6ow/opsx/internal/pkg/middleware/grpc"
 	"github.com/ra1n6ow/opsx/internal/pkg/server"
 	handler "github.com/ra1n6ow/opsx/internal/usercenter/handler/grpc"
 	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
 )
 
-// grpcServer 定义一个 gRPC 服务器.
-type grpcServer struct {
-	srv server.Server
-	// stop 为优雅关停函数.
-	stop func(context.Context)
-}
-
-// 确保 *grpcServer 实现了 server.Server 接口.
-var _ server.Server = (*grpcServer)(nil)
-
-// NewGRPCServerOr 创建并初始化 gRPC 或者 gRPC +  gRPC-Gateway 服务器.
-func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
+// NewGRPCServerOr 创建并初始化 gRPC、gRPC +  gRPC-Gateway 或者单端口服务器.
+// 返回的服务器按照依赖顺序排列：gRPC-Gateway 依赖 gRPC 服务器，排在 gRPC 服务器之后.
+func (c *ServerConfig) NewGRPCServerOr() ([]server.Server, error) {
 	// 配置 gRPC 服务器选项，包括拦截器链
 	serverOptions := []grpc.ServerOption{
-		// 注意拦截器顺序！
-		grpc.ChainUnaryInterceptor(
-			// 请求 ID 拦截器
-			mw.RequestIDInterceptor(),
-		),
+		grpc.ChainUnaryInterceptor(c.unaryInterceptors()...),
+		grpc.ChainStreamInterceptor(c.streamInterceptors()...),
 	}
 
 	// 创建 gRPC 服务器
@@ -38,52 +34,161 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 		c.cfg.GRPCOptions,
 		serverOptions,
 		func(s grpc.ServiceRegistrar) {
-			ucv1.RegisterUsercenterServer(s, handler.NewHandler())
+			ucv1.RegisterUsercenterServer(s, handler.NewHandler(c.biz, c.health))
+			// 注册基于健康检查注册表的 gRPC 健康检查服务
+			grpc_health_v1.RegisterHealthServer(s, c.health.HealthServer())
 		},
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	// 如果配置为 gRPC 服务器模式，则直接返回 gRPC 服务器实例
-	if c.cfg.ServerMode == GRPCServerMode {
-		return &grpcServer{
-			srv: grpcsrv,
-			stop: func(ctx context.Context) {
-				grpcsrv.GracefulStop(ctx)
-			},
-		}, nil
+	var servers []server.Server
+
+	// 创建独立的指标服务器（如果启用了指标的话）
+	metricssrv, err := c.NewMetricsServer()
+	if err != nil {
+		return nil, err
+	}
+	if metricssrv != nil {
+		servers = append(servers, metricssrv)
 	}
 
-	// 先启动 gRPC 服务器，因为 HTTP 服务器依赖 gRPC 服务器.
-	go grpcsrv.RunOrDie()
+	switch c.cfg.ServerMode {
+	// 如果配置为 gRPC 服务器模式，则直接返回 gRPC 服务器实例
+	case GRPCServerMode:
+		return append(servers, grpcsrv), nil
+	// 如果配置为单端口模式，gRPC 服务器和 gRPC-Gateway 共用 HTTP 服务器的端口
+	case SinglePortServerMode:
+		muxsrv, err := server.NewMuxServer(c.cfg.HTTPOptions, grpcsrv, c.registerGatewayHandler, c.gatewayMuxOptions()...)
+		if err != nil {
+			return nil, err
+		}
+		return append(servers, muxsrv), nil
+	}
 
-	httpsrv, err := server.NewGRPCGatewayServer(
-		c.cfg.HTTPOptions,
-		c.cfg.GRPCOptions,
-		func(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
-			return ucv1.RegisterUsercenterHandler(context.Background(), mux, conn)
-		},
-	)
+	httpsrv, err := server.NewGRPCGatewayServer(c.cfg.HTTPOptions, c.cfg.GRPCOptions, c.registerGatewayHandler, c.gatewayMuxOptions()...)
 	if err != nil {
 		return nil, err
 	}
 
-	return &grpcServer{
-		srv: httpsrv,
-		stop: func(ctx context.Context) {
-			grpcsrv.GracefulStop(ctx)
-			httpsrv.GracefulStop(ctx)
-		},
-	}, nil
+	return append(servers, grpcsrv, httpsrv), nil
+}
+
+// gatewayMuxOptions 返回 gRPC-Gateway 使用的 ServeMux 选项.
+func (c *ServerConfig) gatewayMuxOptions() []runtime.ServeMuxOption {
+	errorHandler := runtime.ErrorHandlerFunc(core.GatewayErrorHandler)
+	muxOptions := []runtime.ServeMuxOption{
+		// 服务不健康时，健康检查接口返回 503 状态码
+		runtime.WithForwardResponseOption(healthzResponseStatus),
+		// 在请求和响应中传递 x-request-id
+		runtime.WithIncomingHeaderMatcher(core.GatewayHeaderMatcher(true)),
+		runtime.WithOutgoingHeaderMatcher(core.GatewayHeaderMatcher(false)),
+	}
+	if c.metrics != nil {
+		muxOptions = append(muxOptions, runtime.WithMiddlewares(c.metrics.GatewayMiddleware()))
+		errorHandler = metrics.GatewayErrorHandler(errorHandler)
+	}
+	// 返回与 Gin 模式相同格式的错误响应，路由不存在时返回与 Gin 模式相同的 404 错误
+	return append(muxOptions,
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithRoutingErrorHandler(core.GatewayRoutingErrorHandler(errorHandler)),
+	)
 }
 
-// RunOrDie 启动 gRPC 服务器或 HTTP 反向代理服务器，异常时退出.
-func (s *grpcServer) RunOrDie() {
-	s.srv.RunOrDie()
+// registerGatewayHandler 注册 gRPC-Gateway 的路由. conn 为 gRPC-Gateway 连接 gRPC 服务器的客户端连接.
+func (c *ServerConfig) registerGatewayHandler(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	// 检查网关与上游 gRPC 服务器之间的连接
+	c.health.Register("grpc-upstream", health.ConnChecker(conn))
+
+	return ucv1.RegisterUsercenterHandler(context.Background(), mux, conn)
 }
 
-// GracefulStop 优雅停止 HTTP 和 gRPC 服务器.
-func (s *grpcServer) GracefulStop(ctx context.Context) {
-	s.stop(ctx)
+// healthzResponseStatus 在服务不健康时，将健康检查接口的 HTTP 状态码设置为 503，与 Gin 模式保持一致.
+func healthzResponseStatus(ctx context.Context, w http.ResponseWriter, msg proto.Message) error {
+	if resp, ok := msg.(*ucv1.HealthzResponse); ok && resp.GetStatus() == ucv1.ServiceStatus_Unhealthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	return nil
+}
+
+// unaryInterceptors 返回 gRPC 服务器使用的一元拦截器. 注意拦截器顺序！
+func (c *ServerConfig) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	interceptors := []grpc.UnaryServerInterceptor{
+		// 请求 ID 拦截器
+		mw.RequestIDInterceptor(),
+		// 错误本地化拦截器. 根据 Accept-Language 为内层拦截器和处理函数返回的错误附加本地化错误信息
+		mw.LocalizeInterceptor(),
+		// 链路追踪拦截器. 位于访问日志拦截器之前，以便日志中包含 trace_id 和 span_id
+		mw.TracingInterceptor(),
+		// 访问日志拦截器
+		mw.LoggerInterceptor(),
+	}
+	if c.metrics != nil {
+		// 指标拦截器. 位于 Panic 恢复拦截器之前，以便记录 panic 请求
+		interceptors = append(interceptors, mw.MetricsInterceptor(c.metrics))
+	}
+
+	return append(interceptors,
+		// Panic 恢复拦截器
+		mw.RecoveryInterceptor(),
+		// 限流拦截器. 位于认证拦截器之前，避免被限流的请求消耗认证和授权的开销
+		mw.RateLimitInterceptor(c.limiter, rateLimitWhiteList()...),
+		// 认证拦截器
+		mw.AuthnInterceptor(authnWhiteList()...),
+		// 授权拦截器
+		mw.AuthzInterceptor(c.authz, authnWhiteList()...),
+		// 默认值拦截器. 根据请求消息中的 defaults 注解填充默认值，位于请求参数校验拦截器之前
+		mw.DefaulterInterceptor(),
+		// 请求参数校验拦截器. 位于授权拦截器之后，未授权的请求不会暴露参数校验规则
+		mw.ValidatorInterceptor(c.validator),
+	)
+}
+
+// streamInterceptors 返回 gRPC 服务器使用的流式拦截器，与一元拦截器保持相同的顺序.
+func (c *ServerConfig) streamInterceptors() []grpc.StreamServerInterceptor {
+	interceptors := []grpc.StreamServerInterceptor{
+		mw.RequestIDStreamInterceptor(),
+		mw.LocalizeStreamInterceptor(),
+		mw.TracingStreamInterceptor(),
+		mw.LoggerStreamInterceptor(),
+	}
+	if c.metrics != nil {
+		interceptors = append(interceptors, mw.MetricsStreamInterceptor(c.metrics))
+	}
+
+	return append(interceptors,
+		mw.RecoveryStreamInterceptor(),
+		mw.RateLimitStreamInterceptor(c.limiter, rateLimitWhiteList()...),
+		mw.AuthnStreamInterceptor(authnWhiteList()...),
+		mw.AuthzStreamInterceptor(c.authz, authnWhiteList()...),
+		mw.DefaulterStreamInterceptor(),
+		mw.ValidatorStreamInterceptor(c.validator),
+	)
+}
+
+// authnWhiteList 返回无需认证即可调用的 gRPC 方法全名列表.
+func authnWhiteList() []string {
+	return []string{
+		ucv1.Usercenter_Healthz_FullMethodName,
+		ucv1.Usercenter_CreateUser_FullMethodName,
+		ucv1.Usercenter_Login_FullMethodName,
+		// gRPC 健康检查服务
+		grpc_health_v1.Health_Check_FullMethodName,
+		grpc_health_v1.Health_List_FullMethodName,
+		grpc_health_v1.Health_Watch_FullMethodName,
+		// gRPC 反射服务
+		grpc_reflection_v1.ServerReflection_ServerReflectionInfo_FullMethodName,
+		grpc_reflection_v1alpha.ServerReflection_ServerReflectionInfo_FullMethodName,
+	}
+}
+
+// rateLimitWhiteList 返回不受限流的 gRPC 方法全名列表. 健康检查接口不受限流，以免被限流时误判服务不健康.
+func rateLimitWhiteList() []string {
+	return []string{
+		ucv1.Usercenter_Healthz_FullMethodName,
+		grpc_health_v1.Health_Check_FullMethodName,
+		grpc_health_v1.Health_List_FullMethodName,
+		grpc_health_v1.Health_Watch_FullMethodName,
+	}
 }
@@ -6,15 +6,20 @@
 package grpc
 
 import (
+	"github.com/ra1n6ow/opsx/internal/pkg/health"
+	"github.com/ra1n6ow/opsx/internal/usercenter/biz"
 	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
 )
 
-// Handler 负责处理博客模块的请求.
+// Handler 负责处理用户中心模块的请求.
 type Handler struct {
 	ucv1.UnimplementedUsercenterServer
+
+	biz    biz.IBiz
+	health *health.Registry
 }
 
 // NewHandler 创建一个新的 Handler 实例.
-func NewHandler() *Handler {
-	return &Handler{}
+func NewHandler(biz biz.IBiz, health *health.Registry) *Handler {
+	return &Handler{biz: biz, health: health}
 }
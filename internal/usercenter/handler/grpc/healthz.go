@@ -7,19 +7,20 @@ package grpc
 
 import (
 	"context"
-	"time"
 
 	"google.golang.org/protobuf/types/known/emptypb"
 
 	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/internal/usercenter/pkg/conversion"
 	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
 )
 
+// Healthz 服务健康检查，返回汇总后的健康状态以及各组件的健康状态.
 func (h *Handler) Healthz(ctx context.Context, req *emptypb.Empty) (*ucv1.HealthzResponse, error) {
-	log.W(ctx).Infow("Healthz handler is called", "method", "Healthz", "status", "healthy")
-	return &ucv1.HealthzResponse{
-		// 使用 Enum() 方法直接获取常量指针
-		Status:    ucv1.ServiceStatus_Healthy.Enum(),
-		Timestamp: time.Now().Format(time.DateTime),
-	}, nil
+	result := h.health.Check(ctx)
+	if !result.Healthy() {
+		log.W(ctx).Warnw("Service is unhealthy", "method", "Healthz", "err", result.Err)
+	}
+
+	return conversion.HealthResultToHealthzResponseV1(result), nil
 }
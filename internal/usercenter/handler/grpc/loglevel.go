@@ -0,0 +1,22 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package grpc
+
+import (
+	"context"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// GetLogLevel 查询服务运行时的日志级别.
+func (h *Handler) GetLogLevel(ctx context.Context, rq *ucv1.GetLogLevelRequest) (*ucv1.GetLogLevelResponse, error) {
+	return h.biz.LogLevelV1().Get(ctx, rq)
+}
+
+// UpdateLogLevel 修改服务运行时的日志级别.
+func (h *Handler) UpdateLogLevel(ctx context.Context, rq *ucv1.UpdateLogLevelRequest) (*ucv1.UpdateLogLevelResponse, error) {
+	return h.biz.LogLevelV1().Update(ctx, rq)
+}
@@ -0,0 +1,27 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package grpc
+
+import (
+	"context"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// AssignRole 为用户分配角色.
+func (h *Handler) AssignRole(ctx context.Context, rq *ucv1.AssignRoleRequest) (*ucv1.AssignRoleResponse, error) {
+	return h.biz.RoleV1().Assign(ctx, rq)
+}
+
+// RevokeRole 撤销用户的角色.
+func (h *Handler) RevokeRole(ctx context.Context, rq *ucv1.RevokeRoleRequest) (*ucv1.RevokeRoleResponse, error) {
+	return h.biz.RoleV1().Revoke(ctx, rq)
+}
+
+// ListUserRoles 列出用户拥有的角色.
+func (h *Handler) ListUserRoles(ctx context.Context, rq *ucv1.ListUserRolesRequest) (*ucv1.ListUserRolesResponse, error) {
+	return h.biz.RoleV1().List(ctx, rq)
+}
@@ -0,0 +1,47 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package grpc
+
+import (
+	"context"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// Login 用户登录.
+func (h *Handler) Login(ctx context.Context, rq *ucv1.LoginRequest) (*ucv1.LoginResponse, error) {
+	return h.biz.UserV1().Login(ctx, rq)
+}
+
+// RefreshToken 刷新令牌.
+func (h *Handler) RefreshToken(ctx context.Context, rq *ucv1.RefreshTokenRequest) (*ucv1.RefreshTokenResponse, error) {
+	return h.biz.UserV1().RefreshToken(ctx, rq)
+}
+
+// CreateUser 创建新用户.
+func (h *Handler) CreateUser(ctx context.Context, rq *ucv1.CreateUserRequest) (*ucv1.CreateUserResponse, error) {
+	return h.biz.UserV1().Create(ctx, rq)
+}
+
+// UpdateUser 更新用户信息.
+func (h *Handler) UpdateUser(ctx context.Context, rq *ucv1.UpdateUserRequest) (*ucv1.UpdateUserResponse, error) {
+	return h.biz.UserV1().Update(ctx, rq)
+}
+
+// DeleteUser 删除用户.
+func (h *Handler) DeleteUser(ctx context.Context, rq *ucv1.DeleteUserRequest) (*ucv1.DeleteUserResponse, error) {
+	return h.biz.UserV1().Delete(ctx, rq)
+}
+
+// GetUser 获取用户信息.
+func (h *Handler) GetUser(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+	return h.biz.UserV1().Get(ctx, rq)
+}
+
+// ListUsers 列出用户信息.
+func (h *Handler) ListUsers(ctx context.Context, rq *ucv1.ListUsersRequest) (*ucv1.ListUsersResponse, error) {
+	return h.biz.UserV1().List(ctx, rq)
+}
@@ -1,9 +1,17 @@
 package http
 
+import (
+	"github.com/ra1n6ow/opsx/internal/pkg/health"
+	"github.com/ra1n6ow/opsx/internal/usercenter/biz"
+)
+
 // Handler 处理核心模块的请求.
-type Handler struct{}
+type Handler struct {
+	biz    biz.IBiz
+	health *health.Registry
+}
 
 // NewHandler 创建新的 Handler 实例.
-func NewHandler() *Handler {
-	return &Handler{}
+func NewHandler(biz biz.IBiz, health *health.Registry) *Handler {
+	return &Handler{biz: biz, health: health}
 }
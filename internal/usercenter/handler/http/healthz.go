@@ -1,17 +1,25 @@
 package http
 
 import (
-	"time"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
-	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/internal/usercenter/pkg/conversion"
 )
 
-// Healthz 服务健康检查.
+// Healthz 服务健康检查. 服务不健康时返回 503 状态码，以便负载均衡器摘除流量.
 func (h *Handler) Healthz(c *gin.Context) {
+	result := h.health.Check(c.Request.Context())
+
+	code := http.StatusOK
+	if !result.Healthy() {
+		log.W(c.Request.Context()).Warnw("Service is unhealthy", "err", result.Err)
+		code = http.StatusServiceUnavailable
+	}
+
 	// 返回 JSON 响应
-	c.JSON(200, &ucv1.HealthzResponse{
-		Status:    ucv1.ServiceStatus_Healthy.Enum(),
-		Timestamp: time.Now().Format(time.DateTime),
-	})
+	core.WriteResponseWithCode(c, code, conversion.HealthResultToHealthzResponseV1(result))
 }
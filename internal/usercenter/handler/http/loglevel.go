@@ -0,0 +1,17 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+)
+
+// GetLogLevel 查询服务运行时的日志级别.
+func (h *Handler) GetLogLevel(c *gin.Context) {
+	core.HandleRequest(c, h.biz.LogLevelV1().Get)
+}
+
+// UpdateLogLevel 修改服务运行时的日志级别.
+func (h *Handler) UpdateLogLevel(c *gin.Context) {
+	core.HandleRequest(c, h.biz.LogLevelV1().Update, core.BindJSON)
+}
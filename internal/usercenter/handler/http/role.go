@@ -0,0 +1,22 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+)
+
+// AssignRole 为用户分配角色.
+func (h *Handler) AssignRole(c *gin.Context) {
+	core.HandleRequest(c, h.biz.RoleV1().Assign, core.BindJSON, core.BindURI)
+}
+
+// RevokeRole 撤销用户的角色.
+func (h *Handler) RevokeRole(c *gin.Context) {
+	core.HandleRequest(c, h.biz.RoleV1().Revoke, core.BindURI)
+}
+
+// ListUserRoles 列出用户拥有的角色.
+func (h *Handler) ListUserRoles(c *gin.Context) {
+	core.HandleRequest(c, h.biz.RoleV1().List, core.BindURI)
+}
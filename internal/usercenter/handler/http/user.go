@@ -0,0 +1,42 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+)
+
+// Login 用户登录并返回 JWT Token.
+func (h *Handler) Login(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Login, core.BindJSON)
+}
+
+// RefreshToken 刷新 JWT Token.
+func (h *Handler) RefreshToken(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().RefreshToken, core.BindJSON)
+}
+
+// CreateUser 创建新用户.
+func (h *Handler) CreateUser(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Create, core.BindJSON)
+}
+
+// UpdateUser 更新用户信息.
+func (h *Handler) UpdateUser(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Update, core.BindJSON, core.BindURI)
+}
+
+// DeleteUser 删除用户.
+func (h *Handler) DeleteUser(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Delete, core.BindURI)
+}
+
+// GetUser 获取用户信息.
+func (h *Handler) GetUser(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().Get, core.BindURI)
+}
+
+// ListUsers 列出用户信息.
+func (h *Handler) ListUsers(c *gin.Context) {
+	core.HandleRequest(c, h.biz.UserV1().List, core.BindQuery)
+}
@@ -1,35 +1,56 @@
 package usercenter
 
 import (
-	"context"
 	"net/http"
 
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
 
+	"github.com/ra1n6ow/opsx/internal/pkg/core"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	mw "github.com/ra1n6ow/opsx/internal/pkg/middleware/gin"
 	"github.com/ra1n6ow/opsx/internal/pkg/server"
 	handler "github.com/ra1n6ow/opsx/internal/usercenter/handler/http"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
 )
 
-// ginServer 定义一个使用 Gin 框架开发的 HTTP 服务器.
-type ginServer struct {
-	srv server.Server
-}
-
-// 确保 *ginServer 实现了 server.Server 接口.
-var _ server.Server = (*ginServer)(nil)
-
-// NewGinServer 初始化一个新的 Gin 服务器实例.
-func (c *ServerConfig) NewGinServer() server.Server {
+// NewGinServer 初始化一个新的 Gin 服务器实例，以及独立的指标服务器（如果启用了指标的话）.
+func (c *ServerConfig) NewGinServer() ([]server.Server, error) {
 	// 创建 Gin 引擎
 	engine := gin.New()
 
+	// 注册全局中间件，与 gRPC 模式的拦截器保持一致.
+	// 注意中间件顺序：请求 ID 和链路追踪需最先设置，以便后续中间件的日志中包含请求 ID 和 trace_id；
+	// 恢复中间件位于访问日志之后，以便访问日志记录 panic 请求的 500 状态码.
+	engine.Use(mw.RequestIDMiddleware(), mw.TracingMiddleware(), mw.AccessLogMiddleware())
+	if c.metrics != nil {
+		// 指标中间件位于恢复中间件之前，以便记录 panic 请求
+		engine.Use(mw.MetricsMiddleware(c.metrics))
+	}
+	engine.Use(mw.RecoveryMiddleware())
+	// 限流中间件，健康检查接口不受限流
+	engine.Use(mw.RateLimitMiddleware(c.limiter, "/healthz"))
+	// 请求参数校验中间件，与 gRPC 模式使用相同的校验器
+	engine.Use(mw.ValidatorMiddleware(c.validator))
+
 	// 注册 REST API 路由
 	c.InstallRESTAPI(engine)
 
-	httpsrv := server.NewHTTPServer(c.cfg.HTTPOptions, engine)
-
-	return &ginServer{srv: httpsrv}
+	httpsrv, err := server.NewHTTPServer(c.cfg.HTTPOptions, engine)
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建独立的指标服务器（如果启用了指标的话）
+	metricssrv, err := c.NewMetricsServer()
+	if err != nil {
+		return nil, err
+	}
+	if metricssrv != nil {
+		return []server.Server{metricssrv, httpsrv}, nil
+	}
+
+	return []server.Server{httpsrv}, nil
 }
 
 // 注册 API 路由。路由的路径和 HTTP 方法，严格遵循 REST 规范.
@@ -38,10 +59,67 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 	InstallGenericAPI(engine)
 
 	// 创建核心业务处理器
-	handler := handler.NewHandler()
+	handler := handler.NewHandler(c.biz, c.health)
 
 	// 注册健康检查接口
 	engine.GET("/healthz", handler.Healthz)
+
+	// 注册用户登录接口
+	engine.POST("/login", handler.Login)
+
+	// 认证和授权中间件. 授权时使用路由对应的 gRPC 方法全名，与 gRPC 模式共享同一套授权策略
+	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(), mw.AuthzMiddleware(c.authz, restObject)}
+
+	// 注册令牌刷新接口
+	engine.PUT("/refresh-token", append(authMiddlewares, handler.RefreshToken)...)
+
+	// 创建 v1 路由分组
+	v1 := engine.Group("/v1")
+	{
+		// 用户相关路由
+		userv1 := v1.Group("/users")
+		{
+			// 创建用户（注册）无需认证
+			userv1.POST("", handler.CreateUser)
+
+			userv1.Use(authMiddlewares...)
+			userv1.PUT(":userID", handler.UpdateUser)
+			userv1.DELETE(":userID", handler.DeleteUser)
+			userv1.GET(":userID", handler.GetUser)
+			userv1.GET("", handler.ListUsers)
+
+			// 用户角色相关路由
+			userv1.POST(":userID/roles", handler.AssignRole)
+			userv1.DELETE(":userID/roles/:role", handler.RevokeRole)
+			userv1.GET(":userID/roles", handler.ListUserRoles)
+		}
+
+		// 管理相关路由，默认只有管理员可以调用
+		adminv1 := v1.Group("/admin", authMiddlewares...)
+		{
+			adminv1.GET("/log-level", handler.GetLogLevel)
+			adminv1.PUT("/log-level", handler.UpdateLogLevel)
+		}
+	}
+}
+
+// restObjects 保存 REST 路由到 gRPC 方法全名的映射，作为 Gin 模式下的授权资源.
+var restObjects = map[string]string{
+	http.MethodPut + " /refresh-token":                   ucv1.Usercenter_RefreshToken_FullMethodName,
+	http.MethodPut + " /v1/users/:userID":                ucv1.Usercenter_UpdateUser_FullMethodName,
+	http.MethodDelete + " /v1/users/:userID":             ucv1.Usercenter_DeleteUser_FullMethodName,
+	http.MethodGet + " /v1/users/:userID":                ucv1.Usercenter_GetUser_FullMethodName,
+	http.MethodGet + " /v1/users":                        ucv1.Usercenter_ListUsers_FullMethodName,
+	http.MethodPost + " /v1/users/:userID/roles":         ucv1.Usercenter_AssignRole_FullMethodName,
+	http.MethodDelete + " /v1/users/:userID/roles/:role": ucv1.Usercenter_RevokeRole_FullMethodName,
+	http.MethodGet + " /v1/users/:userID/roles":          ucv1.Usercenter_ListUserRoles_FullMethodName,
+	http.MethodGet + " /v1/admin/log-level":              ucv1.Usercenter_GetLogLevel_FullMethodName,
+	http.MethodPut + " /v1/admin/log-level":              ucv1.Usercenter_UpdateLogLevel_FullMethodName,
+}
+
+// restObject 返回请求对应的授权资源.
+func restObject(c *gin.Context) string {
+	return restObjects[c.Request.Method+" "+c.FullPath()]
 }
 
 // InstallGenericAPI 注册业务无关的路由，例如 pprof、404 处理等.
@@ -51,16 +129,6 @@ func InstallGenericAPI(engine *gin.Engine) {
 
 	// 注册 404 路由处理
 	engine.NoRoute(func(c *gin.Context) {
-		c.JSON(http.StatusNotFound, "Page not found.")
+		core.WriteResponse(c, nil, errno.ErrPageNotFound)
 	})
 }
-
-// RunOrDie 启动 Gin 服务器，出错则程序崩溃退出.
-func (s *ginServer) RunOrDie() {
-	s.srv.RunOrDie()
-}
-
-// GracefulStop 优雅停止服务器.
-func (s *ginServer) GracefulStop(ctx context.Context) {
-	s.srv.GracefulStop(ctx)
-}
@@ -0,0 +1,22 @@
+package usercenter
+
+import (
+	"net/http"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/server"
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+// NewMetricsServer 根据配置创建独立暴露 Prometheus 指标的 HTTP 服务器.
+// 指标只通过该服务器暴露，对外提供 API 的 HTTP 服务器上不注册指标接口.
+// 没有启用指标或没有配置指标服务器地址时，返回 nil.
+func (c *ServerConfig) NewMetricsServer() (*server.HTTPServer, error) {
+	if c.metrics == nil || c.cfg.MetricsOptions.Addr == "" {
+		return nil, nil
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(c.cfg.MetricsOptions.Path, c.metrics.Handler())
+
+	return server.NewHTTPServer(&genericoptions.HTTPOptions{Network: "tcp", Addr: c.cfg.MetricsOptions.Addr}, mux)
+}
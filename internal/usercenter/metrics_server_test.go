@@ -0,0 +1,61 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package usercenter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+func TestMetricsOnlyServedOnMetricsServer(t *testing.T) {
+	metricsOptions := genericoptions.NewMetricsOptions()
+	metricsOptions.Addr = "127.0.0.1:0"
+	cfg := &Config{
+		EnableMemoryStore: true,
+		MetricsOptions:    metricsOptions,
+		RateLimitOptions:  genericoptions.NewRateLimitOptions(),
+	}
+	serverConfig, err := cfg.NewServerConfig()
+	require.NoError(t, err)
+	require.NotNil(t, serverConfig.metrics)
+
+	// 对外提供 API 的 Gin 服务器和 gRPC-Gateway 上不注册指标接口
+	engine := gin.New()
+	serverConfig.InstallRESTAPI(engine)
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, metricsOptions.Path, nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	conn, err := grpc.NewClient("127.0.0.1:0", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	mux := runtime.NewServeMux(serverConfig.gatewayMuxOptions()...)
+	require.NoError(t, serverConfig.registerGatewayHandler(mux, conn))
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, metricsOptions.Path, nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// 指标只通过独立的指标服务器暴露
+	metricssrv, err := serverConfig.NewMetricsServer()
+	require.NoError(t, err)
+	assert.NotNil(t, metricssrv)
+
+	// 没有配置指标服务器地址时不启用指标
+	metricsOptions.Addr = ""
+	serverConfig, err = cfg.NewServerConfig()
+	require.NoError(t, err)
+	assert.Nil(t, serverConfig.metrics)
+}
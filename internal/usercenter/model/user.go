@@ -0,0 +1,31 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package model
+
+import (
+	"time"
+)
+
+// TableNameUserM 定义 UserM 对应的数据库表名.
+const TableNameUserM = "user"
+
+// UserM 定义了用户的数据库模型.
+type UserM struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true;comment:主键 ID" json:"id"`
+	UserID    string    `gorm:"column:userID;not null;uniqueIndex:idx_user_userID;comment:用户唯一 ID" json:"userID"`
+	Username  string    `gorm:"column:username;not null;uniqueIndex:idx_user_username;comment:用户名（唯一）" json:"username"`
+	Password  string    `gorm:"column:password;not null;comment:用户密码（加密后）" json:"-"`
+	Nickname  string    `gorm:"column:nickname;not null;comment:用户昵称" json:"nickname"`
+	Email     string    `gorm:"column:email;not null;comment:用户电子邮箱地址" json:"email"`
+	Phone     string    `gorm:"column:phone;not null;comment:用户手机号" json:"phone"`
+	CreatedAt time.Time `gorm:"column:createdAt;not null;default:current_timestamp;comment:用户创建时间" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"column:updatedAt;not null;default:current_timestamp;comment:用户最后修改时间" json:"updatedAt"`
+}
+
+// TableName 返回 UserM 对应的数据库表名.
+func (*UserM) TableName() string {
+	return TableNameUserM
+}
@@ -0,0 +1,26 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package model
+
+import (
+	"time"
+)
+
+// TableNameUserRoleM 定义 UserRoleM 对应的数据库表名.
+const TableNameUserRoleM = "user_role"
+
+// UserRoleM 定义了用户和角色之间的分配关系.
+type UserRoleM struct {
+	ID        int64     `gorm:"column:id;primaryKey;autoIncrement:true;comment:主键 ID" json:"id"`
+	UserID    string    `gorm:"column:userID;not null;uniqueIndex:idx_user_role_userID_role;comment:用户 ID" json:"userID"`
+	Role      string    `gorm:"column:role;not null;uniqueIndex:idx_user_role_userID_role;comment:角色名称" json:"role"`
+	CreatedAt time.Time `gorm:"column:createdAt;not null;default:current_timestamp;comment:角色分配时间" json:"createdAt"`
+}
+
+// TableName 返回 UserRoleM 对应的数据库表名.
+func (*UserRoleM) TableName() string {
+	return TableNameUserRoleM
+}
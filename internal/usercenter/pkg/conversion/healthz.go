@@ -0,0 +1,43 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package conversion
+
+import (
+	"time"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/health"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// HealthResultToHealthzResponseV1 将健康检查结果转换为 Protobuf 层的 HealthzResponse.
+func HealthResultToHealthzResponseV1(result *health.Result) *ucv1.HealthzResponse {
+	resp := &ucv1.HealthzResponse{
+		Status:     serviceStatus(result.Err).Enum(),
+		Timestamp:  time.Now().Format(time.DateTime),
+		Components: make([]*ucv1.ComponentStatus, 0, len(result.Components)),
+	}
+	if result.Err != nil {
+		resp.Message = result.Err.Error()
+	}
+
+	for _, component := range result.Components {
+		status := &ucv1.ComponentStatus{Name: component.Name, Status: serviceStatus(component.Err).Enum()}
+		if component.Err != nil {
+			status.Message = component.Err.Error()
+		}
+		resp.Components = append(resp.Components, status)
+	}
+
+	return resp
+}
+
+// serviceStatus 根据检查错误返回对应的服务状态.
+func serviceStatus(err error) ucv1.ServiceStatus {
+	if err != nil {
+		return ucv1.ServiceStatus_Unhealthy
+	}
+	return ucv1.ServiceStatus_Healthy
+}
@@ -0,0 +1,54 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package conversion
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// LevelStatusToGetLogLevelResponseV1 将日志级别配置转换为 Protobuf 层的 GetLogLevelResponse.
+func LevelStatusToGetLogLevelResponseV1(status log.LevelStatus) *ucv1.GetLogLevelResponse {
+	return &ucv1.GetLogLevelResponse{
+		Level:    status.Level,
+		ExpireAt: expireAt(status.ExpireAt),
+		Modules:  moduleLogLevels(status.Modules),
+	}
+}
+
+// LevelStatusToUpdateLogLevelResponseV1 将日志级别配置转换为 Protobuf 层的 UpdateLogLevelResponse.
+func LevelStatusToUpdateLogLevelResponseV1(status log.LevelStatus) *ucv1.UpdateLogLevelResponse {
+	return &ucv1.UpdateLogLevelResponse{
+		Level:    status.Level,
+		ExpireAt: expireAt(status.ExpireAt),
+		Modules:  moduleLogLevels(status.Modules),
+	}
+}
+
+// moduleLogLevels 将模块级别的日志级别转换为 Protobuf 层的 ModuleLogLevel 列表.
+func moduleLogLevels(modules []log.ModuleLevel) []*ucv1.ModuleLogLevel {
+	levels := make([]*ucv1.ModuleLogLevel, 0, len(modules))
+	for _, module := range modules {
+		levels = append(levels, &ucv1.ModuleLogLevel{
+			Module:   module.Module,
+			Level:    module.Level,
+			ExpireAt: expireAt(module.ExpireAt),
+		})
+	}
+	return levels
+}
+
+// expireAt 转换自动恢复的时间. 零值表示不会自动恢复，返回 nil.
+func expireAt(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
@@ -0,0 +1,26 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package conversion
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ra1n6ow/opsx/internal/usercenter/model"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// UserModelToUserV1 将模型层的 UserM（用户模型对象）转换为 Protobuf 层的 User（v1 用户对象）.
+func UserModelToUserV1(userModel *model.UserM) *ucv1.User {
+	return &ucv1.User{
+		UserID:    userModel.UserID,
+		Username:  userModel.Username,
+		Nickname:  userModel.Nickname,
+		Email:     userModel.Email,
+		Phone:     userModel.Phone,
+		CreatedAt: timestamppb.New(userModel.CreatedAt),
+		UpdatedAt: timestamppb.New(userModel.UpdatedAt),
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package permission
+
+import (
+	"context"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/contextx"
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/known"
+	"github.com/ra1n6ow/opsx/pkg/authz"
+)
+
+// SelfOrAdmin 校验当前请求的用户是否可以操作 userID 对应的用户资源.
+// 只有用户本人和管理员可以操作，否则返回 errno.ErrPermissionDenied.
+func SelfOrAdmin(ctx context.Context, a *authz.Authorizer, userID string) error {
+	callerID := contextx.UserID(ctx)
+	if callerID != "" && callerID == userID {
+		return nil
+	}
+
+	isAdmin, err := a.HasSubjectRole(ctx, callerID, known.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return errno.ErrPermissionDenied
+	}
+
+	return nil
+}
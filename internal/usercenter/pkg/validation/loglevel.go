@@ -0,0 +1,32 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package validation
+
+import (
+	"context"
+
+	"go.uber.org/zap/zapcore"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/validation"
+)
+
+// ValidateUpdateLogLevelRequest 校验修改日志级别请求. 修改全局日志级别时必须指定日志级别，
+// 修改模块的日志级别时，日志级别为空表示删除该模块的日志级别.
+func (v *Validator) ValidateUpdateLogLevelRequest(ctx context.Context, rq *ucv1.UpdateLogLevelRequest) error {
+	var violations validation.Violations
+	if rq.GetLevel() == "" {
+		if rq.GetModule() == "" {
+			violations.Add("level", "must not be empty")
+		}
+	} else if _, err := zapcore.ParseLevel(rq.GetLevel()); err != nil {
+		violations.Add("level", "must be one of debug, info, warn, error, dpanic, panic, fatal")
+	}
+	if rq.Ttl != nil && rq.GetTtl().AsDuration() < 0 {
+		violations.Add("ttl", "must not be negative")
+	}
+	return violations.Err()
+}
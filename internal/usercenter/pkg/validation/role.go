@@ -0,0 +1,43 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package validation
+
+import (
+	"context"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/validation"
+)
+
+// ValidateAssignRoleRequest 校验分配角色请求. 角色是否存在由 biz 层根据授权策略判断.
+func (v *Validator) ValidateAssignRoleRequest(ctx context.Context, rq *ucv1.AssignRoleRequest) error {
+	var violations validation.Violations
+	validateUserID(&violations, rq.GetUserID())
+	validateRole(&violations, rq.GetRole())
+	return violations.Err()
+}
+
+// ValidateRevokeRoleRequest 校验撤销角色请求.
+func (v *Validator) ValidateRevokeRoleRequest(ctx context.Context, rq *ucv1.RevokeRoleRequest) error {
+	var violations validation.Violations
+	validateUserID(&violations, rq.GetUserID())
+	validateRole(&violations, rq.GetRole())
+	return violations.Err()
+}
+
+// ValidateListUserRolesRequest 校验列出用户角色请求.
+func (v *Validator) ValidateListUserRolesRequest(ctx context.Context, rq *ucv1.ListUserRolesRequest) error {
+	var violations validation.Violations
+	validateUserID(&violations, rq.GetUserID())
+	return violations.Err()
+}
+
+// validateRole 校验角色名称.
+func validateRole(violations *validation.Violations, role string) {
+	if role == "" {
+		violations.Add("role", "must not be empty")
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package validation
+
+import (
+	"context"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/validation"
+)
+
+// ValidateLoginRequest 校验登录请求. 登录时只校验必填字段，不校验格式，以兼容校验规则变更之前创建的用户.
+func (v *Validator) ValidateLoginRequest(ctx context.Context, rq *ucv1.LoginRequest) error {
+	var violations validation.Violations
+	if rq.GetUsername() == "" {
+		violations.Add("username", "must not be empty")
+	}
+	if rq.GetPassword() == "" {
+		violations.Add("password", "must not be empty")
+	}
+	return violations.Err()
+}
+
+// ValidateCreateUserRequest 校验创建用户请求.
+func (v *Validator) ValidateCreateUserRequest(ctx context.Context, rq *ucv1.CreateUserRequest) error {
+	var violations validation.Violations
+	if !isValidUsername(rq.GetUsername()) {
+		violations.AddError("username", errno.ErrUsernameInvalid)
+	}
+	if !isValidPassword(rq.GetPassword()) {
+		violations.Add("password", "length must be between %d and %d characters", minPasswordLength, maxPasswordLength)
+	}
+	validateProfile(&violations, rq.Nickname, &rq.Email, &rq.Phone)
+	return violations.Err()
+}
+
+// ValidateUpdateUserRequest 校验更新用户请求. 只校验请求中显式设置的字段.
+func (v *Validator) ValidateUpdateUserRequest(ctx context.Context, rq *ucv1.UpdateUserRequest) error {
+	var violations validation.Violations
+	validateUserID(&violations, rq.GetUserID())
+	if rq.Username != nil && !isValidUsername(rq.GetUsername()) {
+		violations.AddError("username", errno.ErrUsernameInvalid)
+	}
+	validateProfile(&violations, rq.Nickname, rq.Email, rq.Phone)
+	return violations.Err()
+}
+
+// ValidateDeleteUserRequest 校验删除用户请求.
+func (v *Validator) ValidateDeleteUserRequest(ctx context.Context, rq *ucv1.DeleteUserRequest) error {
+	var violations validation.Violations
+	validateUserID(&violations, rq.GetUserID())
+	return violations.Err()
+}
+
+// ValidateGetUserRequest 校验获取用户请求.
+func (v *Validator) ValidateGetUserRequest(ctx context.Context, rq *ucv1.GetUserRequest) error {
+	var violations validation.Violations
+	validateUserID(&violations, rq.GetUserID())
+	return violations.Err()
+}
+
+// ValidateListUsersRequest 校验列出用户请求.
+func (v *Validator) ValidateListUsersRequest(ctx context.Context, rq *ucv1.ListUsersRequest) error {
+	var violations validation.Violations
+	if rq.GetOffset() < 0 {
+		violations.Add("offset", "must not be negative")
+	}
+	if rq.GetLimit() < 0 {
+		violations.Add("limit", "must not be negative")
+	}
+	return violations.Err()
+}
+
+// validateUserID 校验路径参数中的用户 ID.
+func validateUserID(violations *validation.Violations, userID string) {
+	if userID == "" {
+		violations.Add("userID", "must not be empty")
+	}
+}
+
+// validateProfile 校验用户的昵称、邮箱和手机号. 字段为 nil 或者空字符串时不做校验.
+func validateProfile(violations *validation.Violations, nickname, email, phone *string) {
+	if nickname != nil && !isValidNickname(*nickname) {
+		violations.Add("nickname", "length must not exceed %d characters", maxNicknameLength)
+	}
+	if email != nil && *email != "" && !isValidEmail(*email) {
+		violations.Add("email", "must be a valid email address")
+	}
+	if phone != nil && *phone != "" && !isValidPhone(*phone) {
+		violations.Add("phone", "must be a valid 11-digit mobile phone number")
+	}
+}
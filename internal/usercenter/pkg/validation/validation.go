@@ -0,0 +1,66 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package validation 实现用户中心各接口请求的参数校验.
+// 校验在 gRPC 拦截器（gRPC 和 gRPC-Gateway 模式）和 core.HandleRequest（Gin 模式）中执行，
+// 校验失败时返回带有字段校验错误的 errno.ErrInvalidArgument.
+package validation
+
+import (
+	"net/mail"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/ra1n6ow/opsx/pkg/validation"
+)
+
+var (
+	// usernameRegexp 为用户名的格式：只能包含字母、数字和下划线，长度为 3 到 20 个字符.
+	usernameRegexp = regexp.MustCompile(`^[A-Za-z0-9_]{3,20}$`)
+	// phoneRegexp 为手机号的格式：11 位中国大陆手机号.
+	phoneRegexp = regexp.MustCompile(`^1[3-9]\d{9}$`)
+)
+
+const (
+	// minPasswordLength 和 maxPasswordLength 为密码的长度范围.
+	minPasswordLength = 6
+	maxPasswordLength = 18
+	// maxNicknameLength 为昵称的最大长度（字符数）.
+	maxNicknameLength = 30
+)
+
+// Validator 包含用户中心各接口请求的校验方法，方法名为 Validate + 请求消息名.
+type Validator struct{}
+
+// New 创建用户中心的请求校验器.
+func New() *validation.Validator {
+	return validation.New(&Validator{})
+}
+
+// isValidUsername 判断用户名是否合法.
+func isValidUsername(username string) bool {
+	return usernameRegexp.MatchString(username)
+}
+
+// isValidPassword 判断密码的长度是否合法.
+func isValidPassword(password string) bool {
+	return len(password) >= minPasswordLength && len(password) <= maxPasswordLength
+}
+
+// isValidNickname 判断昵称的长度是否合法.
+func isValidNickname(nickname string) bool {
+	return utf8.RuneCountInString(nickname) <= maxNicknameLength
+}
+
+// isValidEmail 判断邮箱地址是否合法. 只允许不带显示名称的地址，例如 alice@example.com.
+func isValidEmail(email string) bool {
+	addr, err := mail.ParseAddress(email)
+	return err == nil && addr.Address == email
+}
+
+// isValidPhone 判断手机号是否合法.
+func isValidPhone(phone string) bool {
+	return phoneRegexp.MatchString(phone)
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package validation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+func TestValidator(t *testing.T) {
+	v := New()
+
+	tests := []struct {
+		name   string
+		rq     any
+		fields []string
+	}{
+		{"valid create", &ucv1.CreateUserRequest{Username: "alice_01", Password: "password", Email: "alice@example.com", Phone: "13800000000"}, nil},
+		{"create without optional fields", &ucv1.CreateUserRequest{Username: "alice", Password: "password"}, nil},
+		{"invalid create", &ucv1.CreateUserRequest{Username: "al", Password: "123", Email: "Alice <alice@example.com>", Phone: "123"}, []string{"username", "password", "email", "phone"}},
+		{"invalid username characters", &ucv1.CreateUserRequest{Username: "alice-01", Password: "password"}, []string{"username"}},
+		{"update without fields", &ucv1.UpdateUserRequest{UserID: "user-1"}, nil},
+		{"invalid update", &ucv1.UpdateUserRequest{Username: proto.String("a b"), Nickname: proto.String(string(make([]rune, 31)))}, []string{"userID", "username", "nickname"}},
+		{"empty login", &ucv1.LoginRequest{}, []string{"username", "password"}},
+		{"login with legacy username", &ucv1.LoginRequest{Username: "a", Password: "p"}, nil},
+		{"get without user id", &ucv1.GetUserRequest{}, []string{"userID"}},
+		{"negative list", &ucv1.ListUsersRequest{Offset: -1, Limit: -1}, []string{"offset", "limit"}},
+		{"assign without role", &ucv1.AssignRoleRequest{UserID: "user-1"}, []string{"role"}},
+		{"set module log level", &ucv1.UpdateLogLevelRequest{Module: "middleware", Level: "debug", Ttl: durationpb.New(time.Minute)}, nil},
+		{"remove module log level", &ucv1.UpdateLogLevelRequest{Module: "middleware"}, nil},
+		{"invalid log level", &ucv1.UpdateLogLevelRequest{Level: "verbose", Ttl: durationpb.New(-time.Minute)}, []string{"level", "ttl"}},
+		{"empty global log level", &ucv1.UpdateLogLevelRequest{}, []string{"level"}},
+		{"request without validator", &ucv1.RefreshTokenRequest{}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.Validate(context.Background(), tt.rq)
+			if len(tt.fields) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, errno.ErrInvalidArgument)
+			var fields []string
+			for _, violation := range errorsx.FromError(err).FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+
+	// 用户名不合法时，字段校验错误的原因为 errno.ErrUsernameInvalid
+	err := v.Validate(context.Background(), &ucv1.CreateUserRequest{Username: "a", Password: "password"})
+	assert.Equal(t, errno.ErrUsernameInvalid.Reason, errorsx.FromError(err).FieldViolations[0].Reason)
+}
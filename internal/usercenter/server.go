@@ -7,36 +7,58 @@ package usercenter
 
 import (
 	"context"
-	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
 
+	"github.com/ra1n6ow/opsx/internal/pkg/health"
 	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/internal/pkg/metrics"
+	"github.com/ra1n6ow/opsx/internal/pkg/ratelimit"
 	"github.com/ra1n6ow/opsx/internal/pkg/server"
+	"github.com/ra1n6ow/opsx/internal/pkg/tracing"
+	"github.com/ra1n6ow/opsx/internal/usercenter/biz"
+	ucvalidation "github.com/ra1n6ow/opsx/internal/usercenter/pkg/validation"
+	"github.com/ra1n6ow/opsx/internal/usercenter/store"
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/authz"
+	"github.com/ra1n6ow/opsx/pkg/token"
+	"github.com/ra1n6ow/opsx/pkg/validation"
 )
 
+// serviceName 为链路追踪中使用的服务名称.
+const serviceName = "opsx-usercenter"
+
 const (
 	// GRPCServerMode 定义 gRPC 服务模式.
 	// 使用 gRPC 框架启动一个 gRPC 服务器.
 	GRPCServerMode = "grpc"
-	// GRPCServerMode 定义 gRPC + HTTP 服务模式.
+	// GRPCGatewayServerMode 定义 gRPC + HTTP 服务模式.
 	// 使用 gRPC 框架启动一个 gRPC 服务器 + HTTP 反向代理服务器.
 	GRPCGatewayServerMode = "grpc-gateway"
 	// GinServerMode 定义 Gin 服务模式.
 	// 使用 Gin Web 框架启动一个 HTTP 服务器.
 	GinServerMode = "gin"
+	// SinglePortServerMode 定义单端口服务模式.
+	// 使用同一个端口同时提供 gRPC 服务和 gRPC-Gateway HTTP 反向代理服务.
+	SinglePortServerMode = "single-port"
 )
 
 // Config 运行时配置.
 type Config struct {
-	ServerMode  string
-	JWTKey      string
-	Expiration  time.Duration
-	GRPCOptions *genericoptions.GRPCOptions
-	HTTPOptions *genericoptions.HTTPOptions
+	ServerMode        string
+	JWTKey            string
+	Expiration        time.Duration
+	GRPCOptions       *genericoptions.GRPCOptions
+	HTTPOptions       *genericoptions.HTTPOptions
+	EnableMemoryStore bool
+	SQLOptions        *genericoptions.SQLOptions
+	AdminUserIDs      []string
+	MetricsOptions    *genericoptions.MetricsOptions
+	TracingOptions    *genericoptions.TracingOptions
+	RateLimitOptions  *genericoptions.RateLimitOptions
 }
 
 // UnionServer 定义一个联合服务器. 根据 ServerMode 决定要启动的服务器类型.
@@ -47,20 +69,45 @@ type Config struct {
 //  2. GRPC 服务器：由 gRPC 框架创建的标准 RPC 服务器
 //  3. HTTP 反向代理服务器：由 grpc-gateway 框架创建的 HTTP 反向代理服务器。
 //     根据是否开启 TLS，来判断启动 HTTP 或者 HTTPS；
+//  4. 单端口服务器：在 HTTP 服务器的端口上同时提供 gRPC 服务和 HTTP 反向代理服务；
 //
 // HTTP 反向代理服务器依赖 gRPC 服务器，所以在开启 HTTP 反向代理服务器时，会先启动 gRPC 服务器.
 type UnionServer struct {
-	srv server.Server
+	// manager 管理所有服务器的生命周期.
+	manager *server.Manager
+	// health 为服务的健康检查注册表.
+	health *health.Registry
+	// limiter 为服务端限流器，用于热加载限流配置.
+	limiter *ratelimit.Limiter
+	// shutdownTracing 用于刷新并关闭链路追踪导出器.
+	shutdownTracing func(context.Context) error
 }
 
 // ServerConfig 包含服务器的核心依赖和配置. 通过运行时配置生成服务器创建或启动时需要的服务器配置
 type ServerConfig struct {
-	cfg *Config
+	cfg   *Config
+	biz   biz.IBiz
+	authz *authz.Authorizer
+	// metrics 为服务器的 Prometheus 指标，没有启用指标时为 nil.
+	metrics *metrics.Metrics
+	// health 为服务的健康检查注册表.
+	health *health.Registry
+	// limiter 为服务端限流器，gRPC、gRPC-Gateway 和 Gin 服务器共用.
+	limiter *ratelimit.Limiter
+	// validator 为请求校验器，gRPC、gRPC-Gateway 和 Gin 服务器共用.
+	validator *validation.Validator
 }
 
 // NewUnionServer 根据配置创建联合服务器(http,grpc,grpc-gateway)
 func (cfg *Config) NewUnionServer() (*UnionServer, error) {
-	// 一些初始化代码
+	// 初始化 token 包的签名密钥、过期时间
+	token.Init(cfg.JWTKey, cfg.Expiration)
+
+	// 初始化链路追踪
+	shutdownTracing, err := cfg.InitTracing()
+	if err != nil {
+		return nil, err
+	}
 
 	// 创建服务配置，这些配置可用来创建服务器
 	serverConfig, err := cfg.NewServerConfig()
@@ -72,50 +119,155 @@ func (cfg *Config) NewUnionServer() (*UnionServer, error) {
 
 	// 根据服务模式创建对应的服务实例
 	// 默认为 gRPC 服务器模式.
-	var srv server.Server
+	var servers []server.Server
 	switch cfg.ServerMode {
 	case GinServerMode:
-		srv, err = serverConfig.NewGinServer(), nil
+		servers, err = serverConfig.NewGinServer()
 	default:
-		srv, err = serverConfig.NewGRPCServerOr()
+		servers, err = serverConfig.NewGRPCServerOr()
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	return &UnionServer{srv: srv}, nil
+	return &UnionServer{
+		manager:         server.NewManager(servers...),
+		health:          serverConfig.health,
+		limiter:         serverConfig.limiter,
+		shutdownTracing: shutdownTracing,
+	}, nil
 }
 
-// Run 运行应用.
-func (s *UnionServer) Run() error {
-	// 协程运行服务器
-	go s.srv.RunOrDie()
+// Reload 在运行时应用 cfg 中支持热加载的配置：JWT Token 的过期时间和限流配置.
+// cfg 中的其他配置需要重启服务才能生效，会被忽略.
+func (s *UnionServer) Reload(cfg *Config) {
+	token.SetExpiration(cfg.Expiration)
+	s.limiter.Update(cfg.RateLimitOptions)
+}
 
-	// 创建一个 os.Signal 类型的 channel，用于接收系统信号
-	quit := make(chan os.Signal, 1)
+// Run 运行应用. 服务器启动或运行失败时，优雅关停已经启动的服务器并返回错误.
+func (s *UnionServer) Run() error {
+	// 创建一个接收到系统信号时被取消的 context
 	// 当执行 kill 命令时（不带参数），默认会发送 syscall.SIGTERM 信号
 	// 使用 kill -2 命令会发送 syscall.SIGINT 信号（例如按 CTRL+C 触发）
 	// 使用 kill -9 命令会发送 syscall.SIGKILL 信号，但 SIGKILL 信号无法被捕获，因此无需监听和处理
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	// 阻塞主线程，等待从 quit channel 中接收到信号
-	<-quit
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	// 按依赖顺序启动所有服务器，并等待所有服务器监听成功
+	err := s.manager.Start(ctx)
+	if err == nil {
+		// 阻塞主线程，直到接收到系统信号或任一服务器运行失败
+		err = s.manager.Wait()
+	}
 
 	log.Infow("Shutting down server ...")
 
+	// 关停服务器之前，先将健康状态置为不健康，以便负载均衡器摘除流量
+	s.health.Shutdown()
+
 	// 优雅关闭服务
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	// 先关闭依赖的服务，再关闭被依赖的服务
-	s.srv.GracefulStop(ctx)
+	if stopErr := s.manager.Stop(shutdownCtx); err == nil {
+		err = stopErr
+	}
+
+	// 服务器关闭后，将尚未导出的 Span 全部导出
+	if err := s.shutdownTracing(shutdownCtx); err != nil {
+		log.Errorw("Failed to shutdown tracer provider", "err", err)
+	}
 
 	log.Infow("Server exited")
 
-	return nil
+	return err
+}
+
+// InitTracing 根据配置初始化全局的链路追踪，返回用于关闭链路追踪的函数.
+// 没有启用链路追踪时，使用 OpenTelemetry 默认的空实现.
+func (c *Config) InitTracing() (func(context.Context) error, error) {
+	if !c.TracingOptions.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	tp, err := c.TracingOptions.NewTracerProvider(context.Background(), serviceName)
+	if err != nil {
+		return nil, err
+	}
+	tracing.Init(tp)
+
+	log.Infow("Initializing tracing", "exporter", c.TracingOptions.Exporter)
+	return tp.Shutdown, nil
 }
 
 // NewServerConfig 创建一个 *ServerConfig 实例.
 // 进阶：这里其实可以使用依赖注入的方式，来创建 *ServerConfig.
 func (c *Config) NewServerConfig() (*ServerConfig, error) {
-	return &ServerConfig{cfg: c}, nil
+	store, err := c.NewStore()
+	if err != nil {
+		return nil, err
+	}
+
+	// 创建授权器，用于 RBAC 访问控制
+	authz := c.NewAuthorizer(store)
+
+	serverConfig := &ServerConfig{
+		cfg:       c,
+		biz:       biz.NewBiz(store, authz),
+		authz:     authz,
+		health:    c.NewHealthRegistry(store),
+		limiter:   ratelimit.New(c.RateLimitOptions),
+		validator: ucvalidation.New(),
+	}
+	if c.MetricsOptions.Enabled {
+		// 指标只通过独立的指标服务器暴露，避免在对外提供 API 的端口上暴露路由等内部信息. 没有配置指标服务器地址时不启用指标
+		if c.MetricsOptions.Addr == "" {
+			log.Warnw("Metrics are disabled because metrics.addr is not set")
+		} else {
+			serverConfig.metrics = metrics.New()
+		}
+	}
+
+	return serverConfig, nil
+}
+
+// NewHealthRegistry 创建健康检查注册表，并注册存储层和 Token 签发器的检查器.
+func (c *Config) NewHealthRegistry(store store.IStore) *health.Registry {
+	registry := health.NewRegistry(ucv1.Usercenter_ServiceDesc.ServiceName)
+
+	registry.Register("store", health.CheckerFunc(store.Ping))
+	registry.Register("token", health.CheckerFunc(func(ctx context.Context) error {
+		// 签发并解析一个 token，确保签发器可用
+		tokenString, _, err := token.Sign(serviceName)
+		if err != nil {
+			return err
+		}
+		_, err = token.Parse(tokenString)
+		return err
+	}))
+
+	return registry
+}
+
+// NewStore 根据配置创建存储层实例. 启用内存存储时使用内存实现，否则连接 SQL 数据库.
+func (c *Config) NewStore() (store.IStore, error) {
+	if c.EnableMemoryStore {
+		log.Infow("Initializing store", "type", "memory")
+		return store.NewMemoryStore(), nil
+	}
+
+	log.Infow("Initializing store", "type", "sql", "driver", c.SQLOptions.Driver)
+	db, err := c.SQLOptions.NewDB()
+	if err != nil {
+		return nil, err
+	}
+
+	// 自动创建或更新表结构
+	if err := store.AutoMigrate(db); err != nil {
+		return nil, err
+	}
+
+	return store.NewStore(db), nil
 }
@@ -0,0 +1,225 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/usercenter/model"
+)
+
+// memoryStore 是 IStore 接口基于内存的实现，数据不会持久化，适用于开发和测试场景.
+type memoryStore struct {
+	users *memoryUserStore
+	roles *memoryRoleStore
+}
+
+// 确保 memoryStore 实现了 IStore 接口.
+var _ IStore = (*memoryStore)(nil)
+
+// NewMemoryStore 创建一个基于内存的 IStore 实例.
+func NewMemoryStore() *memoryStore {
+	return &memoryStore{
+		users: &memoryUserStore{items: make(map[string]*model.UserM)},
+		roles: &memoryRoleStore{items: make(map[string]sets.Set[string])},
+	}
+}
+
+// User 返回一个实现了 UserStore 接口的实例.
+func (store *memoryStore) User() UserStore {
+	return store.users
+}
+
+// Role 返回一个实现了 RoleStore 接口的实例.
+func (store *memoryStore) Role() RoleStore {
+	return store.roles
+}
+
+// Ping 内存存储总是可用.
+func (store *memoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+// memoryUserStore 是 UserStore 接口基于内存的实现.
+type memoryUserStore struct {
+	mu sync.RWMutex
+	// nextID 用于模拟数据库自增主键.
+	nextID int64
+	// items 以用户 ID 为键保存用户.
+	items map[string]*model.UserM
+}
+
+// 确保 memoryUserStore 实现了 UserStore 接口.
+var _ UserStore = (*memoryUserStore)(nil)
+
+// Create 保存一个新用户.
+func (s *memoryUserStore) Create(ctx context.Context, obj *model.UserM) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[obj.UserID]; ok || s.findByUsername(obj.Username) != nil {
+		return errno.ErrUserAlreadyExists
+	}
+
+	now := time.Now()
+	s.nextID++
+	obj.ID = s.nextID
+	obj.CreatedAt, obj.UpdatedAt = now, now
+
+	s.items[obj.UserID] = clone(obj)
+	return nil
+}
+
+// Update 更新一个已存在的用户.
+func (s *memoryUserStore) Update(ctx context.Context, obj *model.UserM) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	old, ok := s.items[obj.UserID]
+	if !ok {
+		return errno.ErrUserNotFound
+	}
+	if other := s.findByUsername(obj.Username); other != nil && other.UserID != obj.UserID {
+		return errno.ErrUserAlreadyExists
+	}
+
+	obj.ID, obj.CreatedAt, obj.UpdatedAt = old.ID, old.CreatedAt, time.Now()
+	s.items[obj.UserID] = clone(obj)
+	return nil
+}
+
+// Delete 根据用户 ID 删除用户.
+func (s *memoryUserStore) Delete(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.items[userID]; !ok {
+		return errno.ErrUserNotFound
+	}
+	delete(s.items, userID)
+	return nil
+}
+
+// Get 根据用户 ID 查询用户.
+func (s *memoryUserStore) Get(ctx context.Context, userID string) (*model.UserM, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	obj, ok := s.items[userID]
+	if !ok {
+		return nil, errno.ErrUserNotFound
+	}
+	return clone(obj), nil
+}
+
+// GetByUsername 根据用户名查询用户.
+func (s *memoryUserStore) GetByUsername(ctx context.Context, username string) (*model.UserM, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	obj := s.findByUsername(username)
+	if obj == nil {
+		return nil, errno.ErrUserNotFound
+	}
+	return clone(obj), nil
+}
+
+// List 按创建顺序分页列出用户.
+func (s *memoryUserStore) List(ctx context.Context, offset, limit int) (int64, []*model.UserM, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	all := make([]*model.UserM, 0, len(s.items))
+	for _, obj := range s.items {
+		all = append(all, obj)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+
+	total := int64(len(all))
+	if offset < 0 || offset >= len(all) {
+		return total, []*model.UserM{}, nil
+	}
+	all = all[offset:]
+	if limit > 0 && limit < len(all) {
+		all = all[:limit]
+	}
+
+	ret := make([]*model.UserM, 0, len(all))
+	for _, obj := range all {
+		ret = append(ret, clone(obj))
+	}
+	return total, ret, nil
+}
+
+// findByUsername 根据用户名查找用户，调用方需要持有锁.
+func (s *memoryUserStore) findByUsername(username string) *model.UserM {
+	for _, obj := range s.items {
+		if obj.Username == username {
+			return obj
+		}
+	}
+	return nil
+}
+
+// clone 拷贝用户对象，避免调用方修改存储中的数据.
+func clone(obj *model.UserM) *model.UserM {
+	copied := *obj
+	return &copied
+}
+
+// memoryRoleStore 是 RoleStore 接口基于内存的实现.
+type memoryRoleStore struct {
+	mu sync.RWMutex
+	// items 以用户 ID 为键保存用户拥有的角色.
+	items map[string]sets.Set[string]
+}
+
+// 确保 memoryRoleStore 实现了 RoleStore 接口.
+var _ RoleStore = (*memoryRoleStore)(nil)
+
+// Add 为用户添加角色.
+func (s *memoryRoleStore) Add(ctx context.Context, userID string, role string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.items[userID] == nil {
+		s.items[userID] = sets.New[string]()
+	}
+	s.items[userID].Insert(role)
+	return nil
+}
+
+// Remove 删除用户的角色.
+func (s *memoryRoleStore) Remove(ctx context.Context, userID string, role string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.items[userID].Delete(role)
+	return nil
+}
+
+// RemoveAll 删除用户的所有角色.
+func (s *memoryRoleStore) RemoveAll(ctx context.Context, userID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.items, userID)
+	return nil
+}
+
+// List 查询用户拥有的角色.
+func (s *memoryRoleStore) List(ctx context.Context, userID string) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return sets.List(s.items[userID]), nil
+}
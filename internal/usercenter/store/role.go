@@ -0,0 +1,71 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/internal/usercenter/model"
+)
+
+// roleStore 是 RoleStore 接口基于 gorm 的实现.
+type roleStore struct {
+	store *datastore
+}
+
+// 确保 roleStore 实现了 RoleStore 接口.
+var _ RoleStore = (*roleStore)(nil)
+
+// newRoleStore 创建 roleStore 的实例.
+func newRoleStore(store *datastore) *roleStore {
+	return &roleStore{store: store}
+}
+
+// Add 为用户添加角色.
+func (s *roleStore) Add(ctx context.Context, userID string, role string) error {
+	obj := &model.UserRoleM{UserID: userID, Role: role}
+	if err := s.store.DB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(obj).Error; err != nil {
+		log.W(ctx).Errorw("Failed to insert user role into database", "err", err, "userID", userID, "role", role)
+		return errno.ErrAddRole.WithCause(err)
+	}
+
+	return nil
+}
+
+// Remove 删除用户的角色.
+func (s *roleStore) Remove(ctx context.Context, userID string, role string) error {
+	if err := s.store.DB(ctx).Where("userID = ? AND role = ?", userID, role).Delete(&model.UserRoleM{}).Error; err != nil {
+		log.W(ctx).Errorw("Failed to delete user role from database", "err", err, "userID", userID, "role", role)
+		return errno.ErrRemoveRole.WithCause(err)
+	}
+
+	return nil
+}
+
+// RemoveAll 删除用户的所有角色.
+func (s *roleStore) RemoveAll(ctx context.Context, userID string) error {
+	if err := s.store.DB(ctx).Where("userID = ?", userID).Delete(&model.UserRoleM{}).Error; err != nil {
+		log.W(ctx).Errorw("Failed to delete user roles from database", "err", err, "userID", userID)
+		return errno.ErrRemoveRole.WithCause(err)
+	}
+
+	return nil
+}
+
+// List 查询用户拥有的角色.
+func (s *roleStore) List(ctx context.Context, userID string) ([]string, error) {
+	var roles []string
+	if err := s.store.DB(ctx).Model(&model.UserRoleM{}).Where("userID = ?", userID).Order("role").Pluck("role", &roles).Error; err != nil {
+		log.W(ctx).Errorw("Failed to list user roles from database", "err", err, "userID", userID)
+		return nil, errno.ErrDBRead.WithCause(err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/ra1n6ow/opsx/internal/usercenter/model"
+)
+
+// IStore 定义了 Store 层需要实现的方法.
+// Store 层有两种实现：基于 gorm 的 SQL 存储（见 NewStore）和基于内存的存储（见 NewMemoryStore），
+// 上层业务代码只依赖 IStore 接口，因此可以在二者之间自由切换.
+type IStore interface {
+	// User 返回用户资源的存储接口.
+	User() UserStore
+	// Role 返回用户角色分配关系的存储接口.
+	Role() RoleStore
+	// Ping 检查存储是否可用，用于健康检查.
+	Ping(ctx context.Context) error
+}
+
+// UserStore 定义了 user 模块在 store 层所实现的方法.
+type UserStore interface {
+	// Create 创建用户. 用户名或用户 ID 已存在时返回 errno.ErrUserAlreadyExists.
+	Create(ctx context.Context, obj *model.UserM) error
+	// Update 更新用户. 用户不存在时返回 errno.ErrUserNotFound.
+	Update(ctx context.Context, obj *model.UserM) error
+	// Delete 根据用户 ID 删除用户. 用户不存在时返回 errno.ErrUserNotFound.
+	Delete(ctx context.Context, userID string) error
+	// Get 根据用户 ID 获取用户. 用户不存在时返回 errno.ErrUserNotFound.
+	Get(ctx context.Context, userID string) (*model.UserM, error)
+	// GetByUsername 根据用户名获取用户. 用户不存在时返回 errno.ErrUserNotFound.
+	GetByUsername(ctx context.Context, username string) (*model.UserM, error)
+	// List 按创建顺序分页列出用户，返回用户总数和当前页的用户列表.
+	// limit 小于等于 0 时表示不限制返回数量.
+	List(ctx context.Context, offset, limit int) (int64, []*model.UserM, error)
+}
+
+// RoleStore 定义了用户角色分配关系在 store 层所实现的方法.
+type RoleStore interface {
+	// Add 为用户添加角色，角色已存在时不做任何操作. 失败时返回 errno.ErrAddRole.
+	Add(ctx context.Context, userID string, role string) error
+	// Remove 删除用户的角色，角色不存在时不做任何操作. 失败时返回 errno.ErrRemoveRole.
+	Remove(ctx context.Context, userID string, role string) error
+	// RemoveAll 删除用户的所有角色. 失败时返回 errno.ErrRemoveRole.
+	RemoveAll(ctx context.Context, userID string) error
+	// List 按名称排序返回用户拥有的角色.
+	List(ctx context.Context, userID string) ([]string, error)
+}
+
+// datastore 是 IStore 接口基于 gorm 的具体实现.
+type datastore struct {
+	db *gorm.DB
+}
+
+// 确保 datastore 实现了 IStore 接口.
+var _ IStore = (*datastore)(nil)
+
+// NewStore 创建一个基于 SQL 数据库的 IStore 实例.
+func NewStore(db *gorm.DB) *datastore {
+	return &datastore{db: db}
+}
+
+// User 返回一个实现了 UserStore 接口的实例.
+func (store *datastore) User() UserStore {
+	return newUserStore(store)
+}
+
+// Role 返回一个实现了 RoleStore 接口的实例.
+func (store *datastore) Role() RoleStore {
+	return newRoleStore(store)
+}
+
+// Ping 检查数据库连接是否可用.
+func (store *datastore) Ping(ctx context.Context) error {
+	db, err := store.db.DB()
+	if err != nil {
+		return err
+	}
+	return db.PingContext(ctx)
+}
+
+// DB 根据传入的上下文返回 gorm 数据库实例.
+func (store *datastore) DB(ctx context.Context) *gorm.DB {
+	return store.db.WithContext(ctx)
+}
+
+// AutoMigrate 根据数据库模型自动创建或更新表结构.
+func AutoMigrate(db *gorm.DB) error {
+	return db.AutoMigrate(&model.UserM{}, &model.UserRoleM{})
+}
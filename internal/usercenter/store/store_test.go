@@ -0,0 +1,138 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package store_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/usercenter/model"
+	"github.com/ra1n6ow/opsx/internal/usercenter/store"
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+// newSQLiteStore 创建一个基于临时 SQLite 文件的 IStore 实例.
+func newSQLiteStore(t *testing.T) store.IStore {
+	t.Helper()
+
+	opts := genericoptions.NewSQLOptions()
+	opts.DSN = filepath.Join(t.TempDir(), "opsx.db")
+	db, err := opts.NewDB()
+	require.NoError(t, err)
+	require.NoError(t, store.AutoMigrate(db))
+
+	return store.NewStore(db)
+}
+
+// backends 返回所有需要测试的存储实现.
+func backends() map[string]func(t *testing.T) store.IStore {
+	return map[string]func(t *testing.T) store.IStore{
+		"memory": func(*testing.T) store.IStore { return store.NewMemoryStore() },
+		"sqlite": newSQLiteStore,
+	}
+}
+
+func TestUserStore(t *testing.T) {
+	for name, newStore := range backends() {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			users := newStore(t).User()
+
+			// 创建用户
+			for _, username := range []string{"alice", "bob", "carol"} {
+				err := users.Create(ctx, &model.UserM{UserID: "user-" + username, Username: username, Password: "x"})
+				require.NoError(t, err)
+			}
+
+			// 重复的用户名返回 ErrUserAlreadyExists
+			err := users.Create(ctx, &model.UserM{UserID: "user-other", Username: "alice", Password: "x"})
+			assert.ErrorIs(t, err, errno.ErrUserAlreadyExists)
+
+			// 查询用户
+			alice, err := users.Get(ctx, "user-alice")
+			require.NoError(t, err)
+			assert.Equal(t, "alice", alice.Username)
+			assert.False(t, alice.CreatedAt.IsZero())
+
+			bob, err := users.GetByUsername(ctx, "bob")
+			require.NoError(t, err)
+			assert.Equal(t, "user-bob", bob.UserID)
+
+			_, err = users.Get(ctx, "user-nobody")
+			assert.ErrorIs(t, err, errno.ErrUserNotFound)
+
+			// 更新用户
+			alice.Nickname = "Alice"
+			require.NoError(t, users.Update(ctx, alice))
+			alice, err = users.Get(ctx, "user-alice")
+			require.NoError(t, err)
+			assert.Equal(t, "Alice", alice.Nickname)
+
+			bob.Username = "alice"
+			assert.ErrorIs(t, users.Update(ctx, bob), errno.ErrUserAlreadyExists)
+			assert.ErrorIs(t, users.Update(ctx, &model.UserM{UserID: "user-nobody", Username: "nobody"}), errno.ErrUserNotFound)
+
+			// 分页列出用户
+			count, list, err := users.List(ctx, 1, 1)
+			require.NoError(t, err)
+			assert.EqualValues(t, 3, count)
+			require.Len(t, list, 1)
+			assert.Equal(t, "user-bob", list[0].UserID)
+
+			_, list, err = users.List(ctx, 0, 0)
+			require.NoError(t, err)
+			assert.Len(t, list, 3)
+
+			// 删除用户
+			require.NoError(t, users.Delete(ctx, "user-bob"))
+			assert.ErrorIs(t, users.Delete(ctx, "user-bob"), errno.ErrUserNotFound)
+			count, _, err = users.List(ctx, 0, 0)
+			require.NoError(t, err)
+			assert.EqualValues(t, 2, count)
+		})
+	}
+}
+
+func TestRoleStore(t *testing.T) {
+	for name, newStore := range backends() {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			roles := newStore(t).Role()
+
+			// 重复添加相同的角色不报错
+			require.NoError(t, roles.Add(ctx, "user-alice", "user"))
+			require.NoError(t, roles.Add(ctx, "user-alice", "admin"))
+			require.NoError(t, roles.Add(ctx, "user-alice", "admin"))
+			require.NoError(t, roles.Add(ctx, "user-bob", "user"))
+
+			list, err := roles.List(ctx, "user-alice")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"admin", "user"}, list)
+
+			// 删除不存在的角色不报错
+			require.NoError(t, roles.Remove(ctx, "user-alice", "admin"))
+			require.NoError(t, roles.Remove(ctx, "user-alice", "admin"))
+			list, err = roles.List(ctx, "user-alice")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"user"}, list)
+
+			require.NoError(t, roles.RemoveAll(ctx, "user-alice"))
+			list, err = roles.List(ctx, "user-alice")
+			require.NoError(t, err)
+			assert.Empty(t, list)
+
+			// 其他用户的角色不受影响
+			list, err = roles.List(ctx, "user-bob")
+			require.NoError(t, err)
+			assert.Equal(t, []string{"user"}, list)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package store
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+	"github.com/ra1n6ow/opsx/internal/pkg/log"
+	"github.com/ra1n6ow/opsx/internal/usercenter/model"
+)
+
+// userStore 是 UserStore 接口基于 gorm 的实现.
+type userStore struct {
+	store *datastore
+}
+
+// 确保 userStore 实现了 UserStore 接口.
+var _ UserStore = (*userStore)(nil)
+
+// newUserStore 创建 userStore 的实例.
+func newUserStore(store *datastore) *userStore {
+	return &userStore{store: store}
+}
+
+// Create 插入一条用户记录.
+func (s *userStore) Create(ctx context.Context, obj *model.UserM) error {
+	if err := s.store.DB(ctx).Create(obj).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errno.ErrUserAlreadyExists
+		}
+		log.W(ctx).Errorw("Failed to insert user into database", "err", err, "username", obj.Username)
+		return errno.ErrDBWrite.WithCause(err)
+	}
+
+	return nil
+}
+
+// Update 更新用户数据库记录.
+func (s *userStore) Update(ctx context.Context, obj *model.UserM) error {
+	result := s.store.DB(ctx).Model(&model.UserM{}).Where("userID = ?", obj.UserID).Select("*").Omit("id", "createdAt").Updates(obj)
+	if err := result.Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return errno.ErrUserAlreadyExists
+		}
+		log.W(ctx).Errorw("Failed to update user in database", "err", err, "userID", obj.UserID)
+		return errno.ErrDBWrite.WithCause(err)
+	}
+	if result.RowsAffected == 0 {
+		return errno.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Delete 根据用户 ID 删除用户.
+func (s *userStore) Delete(ctx context.Context, userID string) error {
+	result := s.store.DB(ctx).Where("userID = ?", userID).Delete(&model.UserM{})
+	if err := result.Error; err != nil {
+		log.W(ctx).Errorw("Failed to delete user from database", "err", err, "userID", userID)
+		return errno.ErrDBWrite.WithCause(err)
+	}
+	if result.RowsAffected == 0 {
+		return errno.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Get 根据用户 ID 查询用户.
+func (s *userStore) Get(ctx context.Context, userID string) (*model.UserM, error) {
+	return s.take(ctx, "userID = ?", userID)
+}
+
+// GetByUsername 根据用户名查询用户.
+func (s *userStore) GetByUsername(ctx context.Context, username string) (*model.UserM, error) {
+	return s.take(ctx, "username = ?", username)
+}
+
+// List 分页查询用户列表.
+func (s *userStore) List(ctx context.Context, offset, limit int) (int64, []*model.UserM, error) {
+	var (
+		count int64
+		ret   []*model.UserM
+	)
+
+	db := s.store.DB(ctx).Model(&model.UserM{})
+	if err := db.Count(&count).Error; err != nil {
+		log.W(ctx).Errorw("Failed to count users from database", "err", err)
+		return 0, nil, errno.ErrDBRead.WithCause(err)
+	}
+
+	if limit <= 0 {
+		// gorm 中 Limit(-1) 表示取消数量限制
+		limit = -1
+	}
+	if err := db.Order("id").Offset(offset).Limit(limit).Find(&ret).Error; err != nil {
+		log.W(ctx).Errorw("Failed to list users from database", "err", err)
+		return 0, nil, errno.ErrDBRead.WithCause(err)
+	}
+
+	return count, ret, nil
+}
+
+// take 根据查询条件获取一条用户记录.
+func (s *userStore) take(ctx context.Context, query string, args ...any) (*model.UserM, error) {
+	var obj model.UserM
+	if err := s.store.DB(ctx).Where(query, args...).Take(&obj).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrUserNotFound
+		}
+		log.W(ctx).Errorw("Failed to retrieve user from database", "err", err, "query", query)
+		return nil, errno.ErrDBRead.WithCause(err)
+	}
+
+	return &obj, nil
+}
@@ -86,7 +86,9 @@ type HealthzResponse struct {
 	// timestamp 表示请求的时间戳
 	Timestamp string `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	// message 表示可选的状态消息，描述服务健康的更多信息
-	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Message string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	// components 表示各个组件（例如数据库、Token 签发器等）的健康状态
+	Components    []*ComponentStatus `protobuf:"bytes,4,rep,name=components,proto3" json:"components,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -142,14 +144,93 @@ func (x *HealthzResponse) GetMessage() string {
 	return ""
 }
 
+func (x *HealthzResponse) GetComponents() []*ComponentStatus {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+// ComponentStatus 表示单个组件的健康状态
+type ComponentStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name 表示组件名称
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// status 表示组件的健康状态
+	Status *ServiceStatus `protobuf:"varint,2,opt,name=status,proto3,enum=v1.ServiceStatus,oneof" json:"status,omitempty"`
+	// message 表示组件不健康的原因
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComponentStatus) Reset() {
+	*x = ComponentStatus{}
+	mi := &file_usercenter_v1_healthz_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComponentStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentStatus) ProtoMessage() {}
+
+func (x *ComponentStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_usercenter_v1_healthz_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentStatus.ProtoReflect.Descriptor instead.
+func (*ComponentStatus) Descriptor() ([]byte, []int) {
+	return file_usercenter_v1_healthz_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ComponentStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ComponentStatus) GetStatus() ServiceStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ServiceStatus_Healthy
+}
+
+func (x *ComponentStatus) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
 var File_usercenter_v1_healthz_proto protoreflect.FileDescriptor
 
 const file_usercenter_v1_healthz_proto_rawDesc = "" +
 	"\n" +
-	"\x1busercenter/v1/healthz.proto\x12\x02v1\"\x84\x01\n" +
+	"\x1busercenter/v1/healthz.proto\x12\x02v1\"\xb9\x01\n" +
 	"\x0fHealthzResponse\x12.\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x11.v1.ServiceStatusH\x00R\x06status\x88\x01\x01\x12\x1c\n" +
 	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x123\n" +
+	"\n" +
+	"components\x18\x04 \x03(\v2\x13.v1.ComponentStatusR\n" +
+	"componentsB\t\n" +
+	"\a_status\"z\n" +
+	"\x0fComponentStatus\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12.\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x11.v1.ServiceStatusH\x00R\x06status\x88\x01\x01\x12\x18\n" +
 	"\amessage\x18\x03 \x01(\tR\amessageB\t\n" +
 	"\a_status*+\n" +
 	"\rServiceStatus\x12\v\n" +
@@ -169,18 +250,21 @@ func file_usercenter_v1_healthz_proto_rawDescGZIP() []byte {
 }
 
 var file_usercenter_v1_healthz_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_usercenter_v1_healthz_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_usercenter_v1_healthz_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_usercenter_v1_healthz_proto_goTypes = []any{
 	(ServiceStatus)(0),      // 0: v1.ServiceStatus
 	(*HealthzResponse)(nil), // 1: v1.HealthzResponse
+	(*ComponentStatus)(nil), // 2: v1.ComponentStatus
 }
 var file_usercenter_v1_healthz_proto_depIdxs = []int32{
 	0, // 0: v1.HealthzResponse.status:type_name -> v1.ServiceStatus
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 1: v1.HealthzResponse.components:type_name -> v1.ComponentStatus
+	0, // 2: v1.ComponentStatus.status:type_name -> v1.ServiceStatus
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_usercenter_v1_healthz_proto_init() }
@@ -189,13 +273,14 @@ func file_usercenter_v1_healthz_proto_init() {
 		return
 	}
 	file_usercenter_v1_healthz_proto_msgTypes[0].OneofWrappers = []any{}
+	file_usercenter_v1_healthz_proto_msgTypes[1].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_usercenter_v1_healthz_proto_rawDesc), len(file_usercenter_v1_healthz_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,408 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// LogLevel API 定义，包含查询和修改服务运行时日志级别的请求和响应的相关消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.4
+// source: usercenter/v1/loglevel.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ModuleLogLevel 表示一个模块的日志级别
+type ModuleLogLevel struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// module 表示模块名，与打印日志的代码所在的包路径匹配，例如：middleware
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	// level 表示该模块的日志级别
+	Level string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	// expireAt 表示该模块的日志级别自动恢复的时间，为空表示不会自动恢复
+	ExpireAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ModuleLogLevel) Reset() {
+	*x = ModuleLogLevel{}
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModuleLogLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModuleLogLevel) ProtoMessage() {}
+
+func (x *ModuleLogLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModuleLogLevel.ProtoReflect.Descriptor instead.
+func (*ModuleLogLevel) Descriptor() ([]byte, []int) {
+	return file_usercenter_v1_loglevel_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ModuleLogLevel) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *ModuleLogLevel) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *ModuleLogLevel) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+// GetLogLevelRequest 表示查询日志级别的请求
+type GetLogLevelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelRequest) Reset() {
+	*x = GetLogLevelRequest{}
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelRequest) ProtoMessage() {}
+
+func (x *GetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*GetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_usercenter_v1_loglevel_proto_rawDescGZIP(), []int{1}
+}
+
+// GetLogLevelResponse 表示查询日志级别的响应
+type GetLogLevelResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// level 表示全局日志级别
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// expireAt 表示全局日志级别自动恢复的时间，为空表示不会自动恢复
+	ExpireAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	// modules 表示模块级别的日志级别
+	Modules       []*ModuleLogLevel `protobuf:"bytes,3,rep,name=modules,proto3" json:"modules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLogLevelResponse) Reset() {
+	*x = GetLogLevelResponse{}
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLogLevelResponse) ProtoMessage() {}
+
+func (x *GetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*GetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_usercenter_v1_loglevel_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetLogLevelResponse) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *GetLogLevelResponse) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *GetLogLevelResponse) GetModules() []*ModuleLogLevel {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+// UpdateLogLevelRequest 表示修改日志级别的请求
+type UpdateLogLevelRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// module 表示要修改日志级别的模块，为空时修改全局日志级别
+	Module string `protobuf:"bytes,1,opt,name=module,proto3" json:"module,omitempty"`
+	// level 表示新的日志级别，可选值：debug、info、warn、error、dpanic、panic、fatal.
+	// module 不为空且 level 为空时，删除该模块的日志级别
+	Level string `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	// ttl 表示日志级别的有效期，过期后自动恢复为修改前的日志级别，为空表示永久生效
+	Ttl           *durationpb.Duration `protobuf:"bytes,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLogLevelRequest) Reset() {
+	*x = UpdateLogLevelRequest{}
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLogLevelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLogLevelRequest) ProtoMessage() {}
+
+func (x *UpdateLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_usercenter_v1_loglevel_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UpdateLogLevelRequest) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *UpdateLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *UpdateLogLevelRequest) GetTtl() *durationpb.Duration {
+	if x != nil {
+		return x.Ttl
+	}
+	return nil
+}
+
+// UpdateLogLevelResponse 表示修改日志级别的响应，包含修改后的日志级别
+type UpdateLogLevelResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// level 表示全局日志级别
+	Level string `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	// expireAt 表示全局日志级别自动恢复的时间，为空表示不会自动恢复
+	ExpireAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expireAt,proto3" json:"expireAt,omitempty"`
+	// modules 表示模块级别的日志级别
+	Modules       []*ModuleLogLevel `protobuf:"bytes,3,rep,name=modules,proto3" json:"modules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLogLevelResponse) Reset() {
+	*x = UpdateLogLevelResponse{}
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLogLevelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLogLevelResponse) ProtoMessage() {}
+
+func (x *UpdateLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_usercenter_v1_loglevel_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_usercenter_v1_loglevel_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UpdateLogLevelResponse) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *UpdateLogLevelResponse) GetExpireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpireAt
+	}
+	return nil
+}
+
+func (x *UpdateLogLevelResponse) GetModules() []*ModuleLogLevel {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+var File_usercenter_v1_loglevel_proto protoreflect.FileDescriptor
+
+const file_usercenter_v1_loglevel_proto_rawDesc = "" +
+	"\n" +
+	"\x1cusercenter/v1/loglevel.proto\x12\x02v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"v\n" +
+	"\x0eModuleLogLevel\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x126\n" +
+	"\bexpireAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\"\x14\n" +
+	"\x12GetLogLevelRequest\"\x91\x01\n" +
+	"\x13GetLogLevelResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x126\n" +
+	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12,\n" +
+	"\amodules\x18\x03 \x03(\v2\x12.v1.ModuleLogLevelR\amodules\"r\n" +
+	"\x15UpdateLogLevelRequest\x12\x16\n" +
+	"\x06module\x18\x01 \x01(\tR\x06module\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12+\n" +
+	"\x03ttl\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03ttl\"\x94\x01\n" +
+	"\x16UpdateLogLevelResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x126\n" +
+	"\bexpireAt\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bexpireAt\x12,\n" +
+	"\amodules\x18\x03 \x03(\v2\x12.v1.ModuleLogLevelR\amodulesB2Z0github.com/ra1n6ow/opsx/pkg/api/usercenter/v1;v1b\x06proto3"
+
+var (
+	file_usercenter_v1_loglevel_proto_rawDescOnce sync.Once
+	file_usercenter_v1_loglevel_proto_rawDescData []byte
+)
+
+func file_usercenter_v1_loglevel_proto_rawDescGZIP() []byte {
+	file_usercenter_v1_loglevel_proto_rawDescOnce.Do(func() {
+		file_usercenter_v1_loglevel_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_usercenter_v1_loglevel_proto_rawDesc), len(file_usercenter_v1_loglevel_proto_rawDesc)))
+	})
+	return file_usercenter_v1_loglevel_proto_rawDescData
+}
+
+var file_usercenter_v1_loglevel_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_usercenter_v1_loglevel_proto_goTypes = []any{
+	(*ModuleLogLevel)(nil),         // 0: v1.ModuleLogLevel
+	(*GetLogLevelRequest)(nil),     // 1: v1.GetLogLevelRequest
+	(*GetLogLevelResponse)(nil),    // 2: v1.GetLogLevelResponse
+	(*UpdateLogLevelRequest)(nil),  // 3: v1.UpdateLogLevelRequest
+	(*UpdateLogLevelResponse)(nil), // 4: v1.UpdateLogLevelResponse
+	(*timestamppb.Timestamp)(nil),  // 5: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),    // 6: google.protobuf.Duration
+}
+var file_usercenter_v1_loglevel_proto_depIdxs = []int32{
+	5, // 0: v1.ModuleLogLevel.expireAt:type_name -> google.protobuf.Timestamp
+	5, // 1: v1.GetLogLevelResponse.expireAt:type_name -> google.protobuf.Timestamp
+	0, // 2: v1.GetLogLevelResponse.modules:type_name -> v1.ModuleLogLevel
+	6, // 3: v1.UpdateLogLevelRequest.ttl:type_name -> google.protobuf.Duration
+	5, // 4: v1.UpdateLogLevelResponse.expireAt:type_name -> google.protobuf.Timestamp
+	0, // 5: v1.UpdateLogLevelResponse.modules:type_name -> v1.ModuleLogLevel
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_usercenter_v1_loglevel_proto_init() }
+func file_usercenter_v1_loglevel_proto_init() {
+	if File_usercenter_v1_loglevel_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_usercenter_v1_loglevel_proto_rawDesc), len(file_usercenter_v1_loglevel_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_usercenter_v1_loglevel_proto_goTypes,
+		DependencyIndexes: file_usercenter_v1_loglevel_proto_depIdxs,
+		MessageInfos:      file_usercenter_v1_loglevel_proto_msgTypes,
+	}.Build()
+	File_usercenter_v1_loglevel_proto = out.File
+	file_usercenter_v1_loglevel_proto_goTypes = nil
+	file_usercenter_v1_loglevel_proto_depIdxs = nil
+}
@@ -34,28 +34,110 @@ var File_usercenter_v1_usercenter_proto protoreflect.FileDescriptor
 
 const file_usercenter_v1_usercenter_proto_rawDesc = "" +
 	"\n" +
-	"\x1eusercenter/v1/usercenter.proto\x12\x02v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1busercenter/v1/healthz.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\x84\x01\n" +
+	"\x1eusercenter/v1/usercenter.proto\x12\x02v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1busercenter/v1/healthz.proto\x1a\x18usercenter/v1/user.proto\x1a\x18usercenter/v1/role.proto\x1a\x1cusercenter/v1/loglevel.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xfe\r\n" +
 	"\n" +
 	"Usercenter\x12v\n" +
 	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x13.v1.HealthzResponse\">\x92A+\n" +
 	"\f服务治理\x12\x12服务健康检查*\aHealthz\x82\xd3\xe4\x93\x02\n" +
-	"\x12\b/healthzB\xfb\x01\x92A\xc5\x01\x12\x9b\x01\n" +
+	"\x12\b/healthz\x12e\n" +
+	"\x05Login\x12\x10.v1.LoginRequest\x1a\x11.v1.LoginResponse\"7\x92A#\n" +
+	"\f用户管理\x12\f用户登录*\x05Login\x82\xd3\xe4\x93\x02\v:\x01*\"\x06/login\x12\x89\x01\n" +
+	"\fRefreshToken\x12\x17.v1.RefreshTokenRequest\x1a\x18.v1.RefreshTokenResponse\"F\x92A*\n" +
+	"\f用户管理\x12\f刷新令牌*\fRefreshToken\x82\xd3\xe4\x93\x02\x13:\x01*\x1a\x0e/refresh-token\x12|\n" +
+	"\n" +
+	"CreateUser\x12\x15.v1.CreateUserRequest\x1a\x16.v1.CreateUserResponse\"?\x92A(\n" +
+	"\f用户管理\x12\f创建用户*\n" +
+	"CreateUser\x82\xd3\xe4\x93\x02\x0e:\x01*\"\t/v1/users\x12\x8b\x01\n" +
+	"\n" +
+	"UpdateUser\x12\x15.v1.UpdateUserRequest\x1a\x16.v1.UpdateUserResponse\"N\x92A.\n" +
+	"\f用户管理\x12\x12更新用户信息*\n" +
+	"UpdateUser\x82\xd3\xe4\x93\x02\x17:\x01*\x1a\x12/v1/users/{userID}\x12\x82\x01\n" +
+	"\n" +
+	"DeleteUser\x12\x15.v1.DeleteUserRequest\x1a\x16.v1.DeleteUserResponse\"E\x92A(\n" +
+	"\f用户管理\x12\f删除用户*\n" +
+	"DeleteUser\x82\xd3\xe4\x93\x02\x14*\x12/v1/users/{userID}\x12|\n" +
+	"\aGetUser\x12\x12.v1.GetUserRequest\x1a\x13.v1.GetUserResponse\"H\x92A+\n" +
+	"\f用户管理\x12\x12获取用户信息*\aGetUser\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/users/{userID}\x12{\n" +
+	"\tListUsers\x12\x14.v1.ListUsersRequest\x1a\x15.v1.ListUsersResponse\"A\x92A-\n" +
+	"\f用户管理\x12\x12列出所有用户*\tListUsers\x82\xd3\xe4\x93\x02\v\x12\t/v1/users\x12\x94\x01\n" +
+	"\n" +
+	"AssignRole\x12\x15.v1.AssignRoleRequest\x1a\x16.v1.AssignRoleResponse\"W\x92A1\n" +
+	"\f权限管理\x12\x15为用户分配角色*\n" +
+	"AssignRole\x82\xd3\xe4\x93\x02\x1d:\x01*\"\x18/v1/users/{userID}/roles\x12\x95\x01\n" +
+	"\n" +
+	"RevokeRole\x12\x15.v1.RevokeRoleRequest\x1a\x16.v1.RevokeRoleResponse\"X\x92A.\n" +
+	"\f权限管理\x12\x12撤销用户角色*\n" +
+	"RevokeRole\x82\xd3\xe4\x93\x02!*\x1f/v1/users/{userID}/roles/{role}\x12\x9a\x01\n" +
+	"\rListUserRoles\x12\x18.v1.ListUserRolesRequest\x1a\x19.v1.ListUserRolesResponse\"T\x92A1\n" +
+	"\f权限管理\x12\x12列出用户角色*\rListUserRoles\x82\xd3\xe4\x93\x02\x1a\x12\x18/v1/users/{userID}/roles\x12\x8d\x01\n" +
+	"\vGetLogLevel\x12\x16.v1.GetLogLevelRequest\x1a\x17.v1.GetLogLevelResponse\"M\x92A/\n" +
+	"\f服务治理\x12\x12查询日志级别*\vGetLogLevel\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/admin/log-level\x12\x9c\x01\n" +
+	"\x0eUpdateLogLevel\x12\x19.v1.UpdateLogLevelRequest\x1a\x1a.v1.UpdateLogLevelResponse\"S\x92A2\n" +
+	"\f服务治理\x12\x12修改日志级别*\x0eUpdateLogLevel\x82\xd3\xe4\x93\x02\x18:\x01*\x1a\x13/v1/admin/log-levelB\xfb\x01\x92A\xc5\x01\x12\x9b\x01\n" +
 	"\x13opsx-usercenter API\";\n" +
 	"\x04opsx\x12\x1fhttps://github.com/Ra1n6ow/opsx\x1a\x12jeffduuu@gmail.com*B\n" +
 	"\vMIT License\x123https://github.com/Ra1n6ow/opsx/blob/master/LICENSE2\x031.0*\x01\x022\x10application/json:\x10application/jsonZ0github.com/ra1n6ow/opsx/pkg/api/usercenter/v1;v1b\x06proto3"
 
 var file_usercenter_v1_usercenter_proto_goTypes = []any{
-	(*emptypb.Empty)(nil),   // 0: google.protobuf.Empty
-	(*HealthzResponse)(nil), // 1: v1.HealthzResponse
+	(*emptypb.Empty)(nil),          // 0: google.protobuf.Empty
+	(*LoginRequest)(nil),           // 1: v1.LoginRequest
+	(*RefreshTokenRequest)(nil),    // 2: v1.RefreshTokenRequest
+	(*CreateUserRequest)(nil),      // 3: v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),      // 4: v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),      // 5: v1.DeleteUserRequest
+	(*GetUserRequest)(nil),         // 6: v1.GetUserRequest
+	(*ListUsersRequest)(nil),       // 7: v1.ListUsersRequest
+	(*AssignRoleRequest)(nil),      // 8: v1.AssignRoleRequest
+	(*RevokeRoleRequest)(nil),      // 9: v1.RevokeRoleRequest
+	(*ListUserRolesRequest)(nil),   // 10: v1.ListUserRolesRequest
+	(*GetLogLevelRequest)(nil),     // 11: v1.GetLogLevelRequest
+	(*UpdateLogLevelRequest)(nil),  // 12: v1.UpdateLogLevelRequest
+	(*HealthzResponse)(nil),        // 13: v1.HealthzResponse
+	(*LoginResponse)(nil),          // 14: v1.LoginResponse
+	(*RefreshTokenResponse)(nil),   // 15: v1.RefreshTokenResponse
+	(*CreateUserResponse)(nil),     // 16: v1.CreateUserResponse
+	(*UpdateUserResponse)(nil),     // 17: v1.UpdateUserResponse
+	(*DeleteUserResponse)(nil),     // 18: v1.DeleteUserResponse
+	(*GetUserResponse)(nil),        // 19: v1.GetUserResponse
+	(*ListUsersResponse)(nil),      // 20: v1.ListUsersResponse
+	(*AssignRoleResponse)(nil),     // 21: v1.AssignRoleResponse
+	(*RevokeRoleResponse)(nil),     // 22: v1.RevokeRoleResponse
+	(*ListUserRolesResponse)(nil),  // 23: v1.ListUserRolesResponse
+	(*GetLogLevelResponse)(nil),    // 24: v1.GetLogLevelResponse
+	(*UpdateLogLevelResponse)(nil), // 25: v1.UpdateLogLevelResponse
 }
 var file_usercenter_v1_usercenter_proto_depIdxs = []int32{
-	0, // 0: v1.Usercenter.Healthz:input_type -> google.protobuf.Empty
-	1, // 1: v1.Usercenter.Healthz:output_type -> v1.HealthzResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	0,  // 0: v1.Usercenter.Healthz:input_type -> google.protobuf.Empty
+	1,  // 1: v1.Usercenter.Login:input_type -> v1.LoginRequest
+	2,  // 2: v1.Usercenter.RefreshToken:input_type -> v1.RefreshTokenRequest
+	3,  // 3: v1.Usercenter.CreateUser:input_type -> v1.CreateUserRequest
+	4,  // 4: v1.Usercenter.UpdateUser:input_type -> v1.UpdateUserRequest
+	5,  // 5: v1.Usercenter.DeleteUser:input_type -> v1.DeleteUserRequest
+	6,  // 6: v1.Usercenter.GetUser:input_type -> v1.GetUserRequest
+	7,  // 7: v1.Usercenter.ListUsers:input_type -> v1.ListUsersRequest
+	8,  // 8: v1.Usercenter.AssignRole:input_type -> v1.AssignRoleRequest
+	9,  // 9: v1.Usercenter.RevokeRole:input_type -> v1.RevokeRoleRequest
+	10, // 10: v1.Usercenter.ListUserRoles:input_type -> v1.ListUserRolesRequest
+	11, // 11: v1.Usercenter.GetLogLevel:input_type -> v1.GetLogLevelRequest
+	12, // 12: v1.Usercenter.UpdateLogLevel:input_type -> v1.UpdateLogLevelRequest
+	13, // 13: v1.Usercenter.Healthz:output_type -> v1.HealthzResponse
+	14, // 14: v1.Usercenter.Login:output_type -> v1.LoginResponse
+	15, // 15: v1.Usercenter.RefreshToken:output_type -> v1.RefreshTokenResponse
+	16, // 16: v1.Usercenter.CreateUser:output_type -> v1.CreateUserResponse
+	17, // 17: v1.Usercenter.UpdateUser:output_type -> v1.UpdateUserResponse
+	18, // 18: v1.Usercenter.DeleteUser:output_type -> v1.DeleteUserResponse
+	19, // 19: v1.Usercenter.GetUser:output_type -> v1.GetUserResponse
+	20, // 20: v1.Usercenter.ListUsers:output_type -> v1.ListUsersResponse
+	21, // 21: v1.Usercenter.AssignRole:output_type -> v1.AssignRoleResponse
+	22, // 22: v1.Usercenter.RevokeRole:output_type -> v1.RevokeRoleResponse
+	23, // 23: v1.Usercenter.ListUserRoles:output_type -> v1.ListUserRolesResponse
+	24, // 24: v1.Usercenter.GetLogLevel:output_type -> v1.GetLogLevelResponse
+	25, // 25: v1.Usercenter.UpdateLogLevel:output_type -> v1.UpdateLogLevelResponse
+	13, // [13:26] is the sub-list for method output_type
+	0,  // [0:13] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
 }
 
 func init() { file_usercenter_v1_usercenter_proto_init() }
@@ -64,6 +146,9 @@ func file_usercenter_v1_usercenter_proto_init() {
 		return
 	}
 	file_usercenter_v1_healthz_proto_init()
+	file_usercenter_v1_user_proto_init()
+	file_usercenter_v1_role_proto_init()
+	file_usercenter_v1_loglevel_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
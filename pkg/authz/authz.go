@@ -0,0 +1,143 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package authz 实现了一个进程内的、Casbin 风格的 RBAC 授权器.
+//
+// 授权模型等价于如下 Casbin 模型：
+//
+//	[request_definition]
+//	r = sub, obj
+//	[policy_definition]
+//	p = role, obj
+//	[role_definition]
+//	g = _, _
+//	[matchers]
+//	m = g(r.sub, p.role) && keyMatch(r.obj, p.obj)
+//
+// 其中 p 规则通过 Policy 静态配置，g 规则（主体拥有的角色）由 RoleResolver 在运行时提供.
+package authz
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Policy 定义一条授权策略：拥有 Role 角色的主体可以访问与 Object 匹配的资源.
+// Object 支持以 `*` 结尾的前缀匹配，单独的 `*` 表示匹配所有资源.
+type Policy struct {
+	Role   string
+	Object string
+}
+
+// RoleResolver 用于查询主体（通常为用户 ID）拥有的角色.
+type RoleResolver interface {
+	Roles(ctx context.Context, subject string) ([]string, error)
+}
+
+// RoleResolverFunc 是 RoleResolver 的函数适配器.
+type RoleResolverFunc func(ctx context.Context, subject string) ([]string, error)
+
+// Roles 实现 RoleResolver 接口.
+func (f RoleResolverFunc) Roles(ctx context.Context, subject string) ([]string, error) {
+	return f(ctx, subject)
+}
+
+// Authorizer 是一个基于角色的授权器.
+type Authorizer struct {
+	mu sync.RWMutex
+	// policies 以角色为键保存该角色可以访问的资源模式.
+	policies map[string][]string
+	resolver RoleResolver
+}
+
+// NewAuthorizer 创建一个 Authorizer 实例.
+func NewAuthorizer(resolver RoleResolver, policies ...Policy) *Authorizer {
+	a := &Authorizer{policies: make(map[string][]string), resolver: resolver}
+	a.AddPolicies(policies...)
+	return a
+}
+
+// AddPolicies 添加授权策略.
+func (a *Authorizer) AddPolicies(policies ...Policy) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, p := range policies {
+		a.policies[p.Role] = append(a.policies[p.Role], p.Object)
+	}
+}
+
+// HasRole 判断 role 是否为已定义（即配置了至少一条策略）的角色.
+func (a *Authorizer) HasRole(role string) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	_, ok := a.policies[role]
+	return ok
+}
+
+// Roles 返回所有已定义的角色，按名称排序.
+func (a *Authorizer) Roles() []string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	roles := make([]string, 0, len(a.policies))
+	for role := range a.policies {
+		roles = append(roles, role)
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// Authorize 判断 subject 是否有权限访问 object.
+func (a *Authorizer) Authorize(ctx context.Context, subject, object string) (bool, error) {
+	roles, err := a.SubjectRoles(ctx, subject)
+	if err != nil {
+		return false, err
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, role := range roles {
+		for _, pattern := range a.policies[role] {
+			if keyMatch(object, pattern) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// SubjectRoles 返回 subject 拥有的全部角色.
+func (a *Authorizer) SubjectRoles(ctx context.Context, subject string) ([]string, error) {
+	return a.resolver.Roles(ctx, subject)
+}
+
+// HasSubjectRole 判断 subject 是否拥有 role 角色.
+func (a *Authorizer) HasSubjectRole(ctx context.Context, subject, role string) (bool, error) {
+	roles, err := a.SubjectRoles(ctx, subject)
+	if err != nil {
+		return false, err
+	}
+
+	for _, r := range roles {
+		if r == role {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// keyMatch 判断 key 是否与 pattern 匹配，pattern 中结尾的 `*` 可以匹配任意字符.
+func keyMatch(key, pattern string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(key, prefix)
+	}
+	return key == pattern
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package authz_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/pkg/authz"
+)
+
+func TestAuthorize(t *testing.T) {
+	roles := map[string][]string{
+		"alice": {"admin"},
+		"bob":   {"user"},
+		"carol": {"auditor", "user"},
+	}
+	resolver := authz.RoleResolverFunc(func(_ context.Context, subject string) ([]string, error) {
+		return roles[subject], nil
+	})
+
+	a := authz.NewAuthorizer(resolver,
+		authz.Policy{Role: "admin", Object: "*"},
+		authz.Policy{Role: "user", Object: "/v1.Usercenter/GetUser"},
+		authz.Policy{Role: "auditor", Object: "/v1.Usercenter/List*"},
+	)
+
+	tests := []struct {
+		subject string
+		object  string
+		want    bool
+	}{
+		{subject: "alice", object: "/v1.Usercenter/DeleteUser", want: true},
+		{subject: "bob", object: "/v1.Usercenter/GetUser", want: true},
+		{subject: "bob", object: "/v1.Usercenter/GetUserX", want: false},
+		{subject: "bob", object: "/v1.Usercenter/ListUsers", want: false},
+		{subject: "carol", object: "/v1.Usercenter/ListUsers", want: true},
+		{subject: "carol", object: "/v1.Usercenter/ListUserRoles", want: true},
+		{subject: "dave", object: "/v1.Usercenter/GetUser", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.subject+tt.object, func(t *testing.T) {
+			got, err := a.Authorize(context.Background(), tt.subject, tt.object)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, []string{"admin", "auditor", "user"}, a.Roles())
+	assert.True(t, a.HasRole("auditor"))
+	assert.False(t, a.HasRole("root"))
+
+	isAdmin, err := a.HasSubjectRole(context.Background(), "alice", "admin")
+	require.NoError(t, err)
+	assert.True(t, isAdmin)
+}
+
+func TestAuthorizeResolverError(t *testing.T) {
+	wantErr := errors.New("store unavailable")
+	a := authz.NewAuthorizer(authz.RoleResolverFunc(func(context.Context, string) ([]string, error) {
+		return nil, wantErr
+	}), authz.Policy{Role: "admin", Object: "*"})
+
+	allowed, err := a.Authorize(context.Background(), "alice", "/v1.Usercenter/GetUser")
+	assert.ErrorIs(t, err, wantErr)
+	assert.False(t, allowed)
+}
@@ -0,0 +1,107 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package usercenter 提供访问 Usercenter 服务的 Go 客户端 SDK.
+//
+// Client 封装了 ucv1.NewUsercenterClient，并通过拦截器提供以下能力：
+//   - 认证：通过 TokenSource 在每个请求中携带 Bearer 令牌，WithPassword 会自动登录并在令牌过期前刷新令牌；
+//   - 请求 ID：请求中没有 x-request-id 时自动生成，同一次调用的多次重试使用相同的请求 ID；
+//   - 重试：对可重试的错误码（默认为 Unavailable 和 ResourceExhausted）按指数退避重试；
+//   - 超时：调用方的 context 没有设置超时时间时，使用 WithTimeout 设置的超时时间（默认为 DefaultTimeout）作为单次调用的超时时间；
+//   - 错误转换：返回的错误均为 *errorsx.ErrorX，调用方可以使用 errors.Is(err, usercenter.ErrUserNotFound) 判断错误类型.
+package usercenter
+
+import (
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+// Client 是 Usercenter 服务的客户端，可以直接调用 ucv1.UsercenterClient 中的所有方法.
+type Client struct {
+	ucv1.UsercenterClient
+
+	conn *grpc.ClientConn
+}
+
+// NewClient 创建一个连接 grpcOptions 指定的 Usercenter gRPC 服务器的客户端.
+// 默认使用 grpcOptions.TLSOptions 中的 TLS 配置，可以通过 WithTLSConfig 覆盖.
+// 与 grpc.NewClient 一样，NewClient 不会立即建立连接.
+func NewClient(grpcOptions *genericoptions.GRPCOptions, opts ...Option) (*Client, error) {
+	o := newClientOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	creds, err := transportCredentials(grpcOptions, o.tlsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{}
+	// 使用密码登录时，令牌源通过客户端自身调用 Login 和 RefreshToken 接口
+	if o.username != "" {
+		o.tokenSource = newPasswordTokenSource(c, o.username, o.password)
+	}
+
+	dialOptions := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		// 注意拦截器顺序：请求 ID 和超时时间在重试之外设置，以便多次重试共用；
+		// 错误转换在重试之外，以便重试拦截器根据 gRPC 错误码判断是否可以重试.
+		grpc.WithChainUnaryInterceptor(
+			timeoutInterceptor(o.timeout),
+			requestIDInterceptor(o.requestIDFunc),
+			errorInterceptor(),
+			retryInterceptor(o.retryPolicy),
+			authInterceptor(o.tokenSource),
+		),
+	}, o.dialOptions...)
+
+	conn, err := grpc.NewClient(dialTarget(grpcOptions), dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.UsercenterClient, c.conn = ucv1.NewUsercenterClient(conn), conn
+	return c, nil
+}
+
+// Close 关闭客户端的连接.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// transportCredentials 返回连接服务器使用的传输凭证. tlsConfig 不为 nil 时优先使用 tlsConfig.
+func transportCredentials(grpcOptions *genericoptions.GRPCOptions, tlsConfig *tls.Config) (credentials.TransportCredentials, error) {
+	if tlsConfig == nil {
+		addr := grpcOptions.Addr
+		// 通过 unix socket 连接时，地址中没有主机名，使用 localhost 校验服务端证书（除非配置了 ServerName）
+		if grpcOptions.Network == "unix" {
+			addr = "localhost"
+		}
+
+		var err error
+		if tlsConfig, err = grpcOptions.TLSOptions.ClientTLSConfig(addr); err != nil {
+			return nil, err
+		}
+	}
+	if tlsConfig == nil {
+		return insecure.NewCredentials(), nil
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// dialTarget 返回连接服务器使用的目标地址. 服务器监听 unix socket 时，使用 unix:// 格式的地址.
+func dialTarget(grpcOptions *genericoptions.GRPCOptions) string {
+	if grpcOptions.Network == "unix" {
+		return "unix://" + grpcOptions.Addr
+	}
+	return grpcOptions.Addr
+}
@@ -0,0 +1,228 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package usercenter
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	genericoptions "github.com/ra1n6ow/opsx/pkg/options"
+)
+
+// fakeServer 是用于测试的 Usercenter 服务，各方法的行为由对应的函数字段决定.
+type fakeServer struct {
+	ucv1.UnimplementedUsercenterServer
+
+	login        func(ctx context.Context, rq *ucv1.LoginRequest) (*ucv1.LoginResponse, error)
+	refreshToken func(ctx context.Context, rq *ucv1.RefreshTokenRequest) (*ucv1.RefreshTokenResponse, error)
+	getUser      func(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error)
+}
+
+func (s *fakeServer) Login(ctx context.Context, rq *ucv1.LoginRequest) (*ucv1.LoginResponse, error) {
+	return s.login(ctx, rq)
+}
+
+func (s *fakeServer) RefreshToken(ctx context.Context, rq *ucv1.RefreshTokenRequest) (*ucv1.RefreshTokenResponse, error) {
+	return s.refreshToken(ctx, rq)
+}
+
+func (s *fakeServer) GetUser(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+	return s.getUser(ctx, rq)
+}
+
+// newTestClient 启动 srv 并返回连接 srv 的客户端.
+func newTestClient(t *testing.T, srv ucv1.UsercenterServer, opts ...Option) *Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	grpcsrv := grpc.NewServer()
+	ucv1.RegisterUsercenterServer(grpcsrv, srv)
+	go func() { _ = grpcsrv.Serve(lis) }()
+	t.Cleanup(grpcsrv.Stop)
+
+	grpcOptions := genericoptions.NewGRPCOptions()
+	grpcOptions.Addr = "passthrough:///bufnet"
+	opts = append([]Option{
+		WithDialOptions(grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) })),
+	}, opts...)
+
+	client, err := NewClient(grpcOptions, opts...)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+// incoming 返回请求元数据中 key 对应的第一个值.
+func incoming(ctx context.Context, key string) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func TestClientErrorAndRequestID(t *testing.T) {
+	var requestID string
+	client := newTestClient(t, &fakeServer{
+		getUser: func(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+			requestID = incoming(ctx, xRequestID)
+			return nil, ErrUserNotFound
+		},
+	}, WithToken("token"), WithRequestIDFunc(func(context.Context) string { return "request-1" }))
+
+	_, err := client.GetUser(context.Background(), &ucv1.GetUserRequest{UserID: "user-1"})
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.Equal(t, "request-1", requestID)
+
+	// 调用方设置的请求 ID 不会被覆盖
+	ctx := metadata.AppendToOutgoingContext(context.Background(), xRequestID, "request-2")
+	_, _ = client.GetUser(ctx, &ucv1.GetUserRequest{UserID: "user-1"})
+	assert.Equal(t, "request-2", requestID)
+}
+
+func TestClientRetry(t *testing.T) {
+	var attempts atomic.Int32
+	requestIDs := make(map[string]struct{})
+	client := newTestClient(t, &fakeServer{
+		getUser: func(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+			requestIDs[incoming(ctx, xRequestID)] = struct{}{}
+			if attempts.Add(1) < 3 {
+				return nil, status.Error(codes.Unavailable, "try again")
+			}
+			return &ucv1.GetUserResponse{User: &ucv1.User{UserID: rq.GetUserID()}}, nil
+		},
+	}, WithRetryPolicy(RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+		Multiplier:     2,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}))
+
+	resp, err := client.GetUser(context.Background(), &ucv1.GetUserRequest{UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", resp.GetUser().GetUserID())
+	assert.EqualValues(t, 3, attempts.Load())
+	// 多次重试使用相同的请求 ID
+	assert.Len(t, requestIDs, 1)
+
+	// 不可重试的错误码直接返回
+	attempts.Store(10)
+	client = newTestClient(t, &fakeServer{
+		getUser: func(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+			attempts.Add(1)
+			return nil, ErrPermissionDenied
+		},
+	})
+	_, err = client.GetUser(context.Background(), &ucv1.GetUserRequest{UserID: "user-1"})
+	assert.ErrorIs(t, err, ErrPermissionDenied)
+	assert.EqualValues(t, 11, attempts.Load())
+}
+
+func TestClientTimeout(t *testing.T) {
+	client := newTestClient(t, &fakeServer{
+		getUser: func(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}, WithTimeout(50*time.Millisecond))
+
+	start := time.Now()
+	_, err := client.GetUser(context.Background(), &ucv1.GetUserRequest{UserID: "user-1"})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// 没有设置 WithTimeout 时使用 DefaultTimeout，与 GRPCOptions.Timeout 无关
+	client = newTestClient(t, &fakeServer{
+		getUser: func(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+			deadline, ok := ctx.Deadline()
+			require.True(t, ok)
+			return &ucv1.GetUserResponse{User: &ucv1.User{UserID: time.Until(deadline).String()}}, nil
+		},
+	})
+	resp, err := client.GetUser(context.Background(), &ucv1.GetUserRequest{UserID: "user-1"})
+	require.NoError(t, err)
+	remaining, err := time.ParseDuration(resp.GetUser().GetUserID())
+	require.NoError(t, err)
+	assert.LessOrEqual(t, remaining, DefaultTimeout)
+	assert.Greater(t, remaining, DefaultTimeout-5*time.Second)
+}
+
+func TestPasswordTokenSource(t *testing.T) {
+	now := time.Now()
+	var logins, refreshes atomic.Int32
+	srv := &fakeServer{
+		login: func(ctx context.Context, rq *ucv1.LoginRequest) (*ucv1.LoginResponse, error) {
+			if rq.GetPassword() != "password" {
+				return nil, ErrInvalidCredentials
+			}
+			logins.Add(1)
+			return &ucv1.LoginResponse{Token: "token-1", ExpireAt: timestamppb.New(now.Add(time.Hour))}, nil
+		},
+		refreshToken: func(ctx context.Context, rq *ucv1.RefreshTokenRequest) (*ucv1.RefreshTokenResponse, error) {
+			if incoming(ctx, "authorization") != "Bearer token-1" {
+				return nil, ErrTokenInvalid
+			}
+			refreshes.Add(1)
+			return &ucv1.RefreshTokenResponse{Token: "token-2", ExpireAt: timestamppb.New(now.Add(2 * time.Hour))}, nil
+		},
+		getUser: func(ctx context.Context, rq *ucv1.GetUserRequest) (*ucv1.GetUserResponse, error) {
+			return &ucv1.GetUserResponse{User: &ucv1.User{UserID: incoming(ctx, "authorization")}}, nil
+		},
+	}
+
+	// 通过 Client 自动登录并携带令牌
+	client := newTestClient(t, srv, WithPassword("alice", "password"))
+	resp, err := client.GetUser(context.Background(), &ucv1.GetUserRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1", resp.GetUser().GetUserID())
+
+	source := newPasswordTokenSource(newTestClient(t, srv), "alice", "password")
+	source.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	token, err := source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// 令牌有效期内使用缓存的令牌
+	source.now = func() time.Time { return now.Add(30 * time.Minute) }
+	token, err = source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	assert.EqualValues(t, 2, logins.Load())
+
+	// 令牌即将过期时刷新令牌
+	source.now = func() time.Time { return now.Add(50 * time.Minute) }
+	token, err = source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.EqualValues(t, 1, refreshes.Load())
+
+	// 令牌过期后重新登录
+	source.now = func() time.Time { return now.Add(3 * time.Hour) }
+	token, err = source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+	assert.EqualValues(t, 3, logins.Load())
+
+	// 登录失败时返回 *errorsx.ErrorX
+	_, err = newPasswordTokenSource(client, "alice", "wrong").Token(ctx)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
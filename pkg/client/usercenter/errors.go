@@ -0,0 +1,54 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package usercenter
+
+import (
+	"github.com/ra1n6ow/opsx/internal/pkg/errno"
+)
+
+// 以下为 Usercenter 服务可能返回的错误.
+// 模块外的调用方无法导入 internal/pkg/errno，可以使用这些错误通过 errors.Is 判断错误类型，
+// 例如 errors.Is(err, usercenter.ErrUserNotFound).
+var (
+	// ErrInternal 表示服务器内部错误.
+	ErrInternal = errno.ErrInternal
+
+	// ErrNotFound 表示资源不存在.
+	ErrNotFound = errno.ErrNotFound
+
+	// ErrInvalidArgument 表示参数校验失败.
+	ErrInvalidArgument = errno.ErrInvalidArgument
+
+	// ErrUnauthenticated 表示认证失败.
+	ErrUnauthenticated = errno.ErrUnauthenticated
+
+	// ErrPermissionDenied 表示没有权限访问请求的资源.
+	ErrPermissionDenied = errno.ErrPermissionDenied
+
+	// ErrTooManyRequests 表示请求过于频繁.
+	ErrTooManyRequests = errno.ErrTooManyRequests
+
+	// ErrTokenInvalid 表示令牌无效.
+	ErrTokenInvalid = errno.ErrTokenInvalid
+
+	// ErrInvalidCredentials 表示登录时用户名或密码错误.
+	ErrInvalidCredentials = errno.ErrInvalidCredentials
+
+	// ErrUsernameInvalid 表示用户名不合法.
+	ErrUsernameInvalid = errno.ErrUsernameInvalid
+
+	// ErrPasswordInvalid 表示密码不合法.
+	ErrPasswordInvalid = errno.ErrPasswordInvalid
+
+	// ErrUserAlreadyExists 表示用户已存在.
+	ErrUserAlreadyExists = errno.ErrUserAlreadyExists
+
+	// ErrUserNotFound 表示用户不存在.
+	ErrUserNotFound = errno.ErrUserNotFound
+
+	// ErrRoleNotFound 表示角色不存在.
+	ErrRoleNotFound = errno.ErrRoleNotFound
+)
@@ -0,0 +1,97 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package usercenter
+
+import (
+	"context"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// xRequestID 为请求 ID 在 gRPC 元数据中的键，与服务端保持一致.
+const xRequestID = "x-request-id"
+
+// timeoutInterceptor 在调用方的 context 没有设置超时时间时，为调用设置 timeout 超时时间.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// requestIDInterceptor 在请求中没有 x-request-id 时，使用 fn 生成请求 ID 并设置到请求的元数据中.
+func requestIDInterceptor(fn func(ctx context.Context) string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get(xRequestID)) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, xRequestID, fn(ctx))
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// errorInterceptor 将返回的错误转换为 *errorsx.ErrorX.
+func errorInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+			return errorsx.FromError(err)
+		}
+		return nil
+	}
+}
+
+// retryInterceptor 在请求返回可重试的错误码时，按照 policy 重试请求.
+func retryInterceptor(policy RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		backoff := policy.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= policy.MaxAttempts || !slices.Contains(policy.RetryableCodes, status.Code(err)) {
+				return err
+			}
+
+			// 加入 ±20% 的随机抖动，避免大量客户端同时重试
+			wait := time.Duration(float64(backoff) * (0.8 + 0.4*rand.Float64()))
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(wait):
+			}
+			backoff = min(time.Duration(float64(backoff)*policy.Multiplier), policy.MaxBackoff)
+		}
+	}
+}
+
+// authInterceptor 从 tokenSource 获取令牌，并设置到请求的 authorization 元数据中.
+// 调用 Login 接口或者请求中已经设置了 authorization 时，不会覆盖.
+func authInterceptor(tokenSource TokenSource) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		if tokenSource == nil || method == ucv1.Usercenter_Login_FullMethodName || len(md.Get("authorization")) > 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		token, err := tokenSource.Token(ctx)
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package usercenter
+
+import (
+	"context"
+	"crypto/tls"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// DefaultTimeout 为单次调用（包含重试）的默认超时时间，可以通过 WithTimeout 修改.
+const DefaultTimeout = 10 * time.Second
+
+// Option 定义创建 Client 时使用的函数式选项.
+type Option func(*clientOptions)
+
+// clientOptions 包含 Client 的配置.
+type clientOptions struct {
+	tlsConfig     *tls.Config
+	tokenSource   TokenSource
+	username      string
+	password      string
+	requestIDFunc func(ctx context.Context) string
+	retryPolicy   RetryPolicy
+	timeout       time.Duration
+	dialOptions   []grpc.DialOption
+}
+
+// newClientOptions 返回默认的 Client 配置.
+func newClientOptions() *clientOptions {
+	return &clientOptions{
+		requestIDFunc: func(context.Context) string { return uuid.New().String() },
+		retryPolicy:   DefaultRetryPolicy(),
+		timeout:       DefaultTimeout,
+	}
+}
+
+// RetryPolicy 定义请求失败时的重试策略. 重试间隔从 InitialBackoff 开始，每次乘以 Multiplier，
+// 最大不超过 MaxBackoff，并加入随机抖动.
+type RetryPolicy struct {
+	// MaxAttempts 为最大尝试次数（包含第一次请求）. 小于等于 1 时不重试.
+	MaxAttempts int
+	// InitialBackoff 为第一次重试前的等待时间.
+	InitialBackoff time.Duration
+	// MaxBackoff 为两次重试之间的最大等待时间.
+	MaxBackoff time.Duration
+	// Multiplier 为每次重试后等待时间的增长倍数.
+	Multiplier float64
+	// RetryableCodes 为可以重试的 gRPC 错误码.
+	RetryableCodes []codes.Code
+}
+
+// DefaultRetryPolicy 返回默认的重试策略：最多尝试 3 次，仅重试 Unavailable（服务器不可达）和
+// ResourceExhausted（请求被限流）错误，这两种错误表示请求没有被服务器处理，重试是安全的.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		RetryableCodes: []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+	}
+}
+
+// WithTLSConfig 设置连接服务器使用的 TLS 配置，覆盖 GRPCOptions.TLSOptions 中的配置.
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(o *clientOptions) {
+		o.tlsConfig = tlsConfig
+	}
+}
+
+// WithTokenSource 设置获取访问令牌的令牌源.
+func WithTokenSource(tokenSource TokenSource) Option {
+	return func(o *clientOptions) {
+		o.tokenSource = tokenSource
+	}
+}
+
+// WithToken 使用固定的访问令牌.
+func WithToken(token string) Option {
+	return WithTokenSource(StaticTokenSource(token))
+}
+
+// WithPassword 使用用户名和密码登录获取访问令牌，并在令牌过期前自动刷新令牌.
+// 该选项会覆盖 WithTokenSource 和 WithToken.
+func WithPassword(username, password string) Option {
+	return func(o *clientOptions) {
+		o.username, o.password = username, password
+	}
+}
+
+// WithRequestIDFunc 设置生成请求 ID 的函数. 默认生成 UUID.
+func WithRequestIDFunc(fn func(ctx context.Context) string) Option {
+	return func(o *clientOptions) {
+		o.requestIDFunc = fn
+	}
+}
+
+// WithRetryPolicy 设置请求失败时的重试策略.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *clientOptions) {
+		o.retryPolicy = policy
+	}
+}
+
+// WithTimeout 设置单次调用（包含重试）的超时时间，默认为 DefaultTimeout. 0 表示不设置超时时间.
+// 调用方的 context 已经设置了超时时间时，使用调用方的超时时间.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *clientOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithDialOptions 设置额外的 gRPC 连接选项.
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *clientOptions) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
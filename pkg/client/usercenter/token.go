@@ -0,0 +1,93 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package usercenter
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+
+	ucv1 "github.com/ra1n6ow/opsx/pkg/api/usercenter/v1"
+)
+
+// TokenSource 用于获取访问 Usercenter 服务的令牌.
+type TokenSource interface {
+	// Token 返回当前有效的令牌. 返回空字符串表示不携带令牌.
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc 是 TokenSource 的函数适配器.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token 实现 TokenSource 接口.
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// StaticTokenSource 返回总是返回 token 的令牌源.
+func StaticTokenSource(token string) TokenSource {
+	return TokenSourceFunc(func(context.Context) (string, error) {
+		return token, nil
+	})
+}
+
+// refreshRatio 表示令牌的有效期过去该比例后刷新令牌.
+const refreshRatio = 0.8
+
+// passwordTokenSource 使用用户名和密码登录获取令牌，并在令牌过期前通过 RefreshToken 接口刷新令牌.
+// 刷新失败时重新登录.
+type passwordTokenSource struct {
+	client   ucv1.UsercenterClient
+	username string
+	password string
+	// now 返回当前时间，便于测试.
+	now func() time.Time
+
+	mu        sync.Mutex
+	token     string
+	refreshAt time.Time
+	expireAt  time.Time
+}
+
+// newPasswordTokenSource 创建一个 passwordTokenSource 实例.
+func newPasswordTokenSource(client ucv1.UsercenterClient, username, password string) *passwordTokenSource {
+	return &passwordTokenSource{client: client, username: username, password: password, now: time.Now}
+}
+
+// Token 实现 TokenSource 接口.
+func (s *passwordTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if s.token != "" && now.Before(s.refreshAt) {
+		return s.token, nil
+	}
+
+	// 令牌即将过期时刷新令牌，刷新失败（例如服务端更换了签名密钥）时重新登录
+	if s.token != "" && now.Before(s.expireAt) {
+		ctx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+s.token)
+		if resp, err := s.client.RefreshToken(ctx, &ucv1.RefreshTokenRequest{}); err == nil {
+			s.update(now, resp.GetToken(), resp.GetExpireAt().AsTime())
+			return s.token, nil
+		}
+	}
+
+	resp, err := s.client.Login(ctx, &ucv1.LoginRequest{Username: s.username, Password: s.password})
+	if err != nil {
+		return "", err
+	}
+	s.update(now, resp.GetToken(), resp.GetExpireAt().AsTime())
+	return s.token, nil
+}
+
+// update 保存新的令牌，并计算下次刷新令牌的时间.
+func (s *passwordTokenSource) update(now time.Time, token string, expireAt time.Time) {
+	s.token, s.expireAt = token, expireAt
+	s.refreshAt = now.Add(time.Duration(float64(expireAt.Sub(now)) * refreshRatio))
+}
@@ -0,0 +1,288 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package defaulter 在运行时根据 Protobuf 消息描述符中的 defaults 注解
+// （third_party/protobuf/github.com/onexstack/defaults/defaults.proto），为消息中未设置的字段填充默认值.
+//
+// 支持的注解：
+//   - 字段级别的 (defaults.value)：标量、枚举、google.protobuf.Duration 和 google.protobuf.Timestamp 字段的默认值，
+//     以及消息字段的 initialize（未设置时初始化为空消息）和 defaults（为消息字段填充默认值）.
+//   - oneof 级别的 (defaults.oneof)：oneof 中没有设置任何字段时，设置指定的字段.
+//   - 消息级别的 (defaults.disabled) 和 (defaults.ignored)：不为该消息填充默认值.
+//
+// 对于没有显式 presence 的 proto3 标量字段，零值即视为未设置.
+package defaulter
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ra1n6ow/opsx/pkg/api/defaults"
+)
+
+// plans 缓存每种消息类型的默认值填充计划，键为消息的全名.
+var plans sync.Map
+
+// plan 为一种消息类型的默认值填充计划.
+type plan struct {
+	// fields 为有默认值的字段（不包括 oneof 中的字段）.
+	fields []*fieldPlan
+	// oneofs 为设置了 (defaults.oneof) 的 oneof 中，需要设置的字段.
+	oneofs []*fieldPlan
+	// err 为解析注解时发生的错误，例如默认值与字段类型不匹配.
+	err error
+}
+
+// fieldPlan 为一个字段的默认值填充方式.
+type fieldPlan struct {
+	fd protoreflect.FieldDescriptor
+	// value 返回字段的默认值. 消息类型的字段（Duration 和 Timestamp 除外）为 nil.
+	value func() protoreflect.Value
+	// initialize 表示消息类型的字段未设置时初始化为空消息.
+	initialize bool
+	// defaults 表示为消息类型的字段填充默认值.
+	defaults bool
+}
+
+// Apply 为 msg 及其嵌套消息中未设置的字段填充默认值. 注解不合法（例如默认值与字段类型不匹配）时返回错误.
+func Apply(msg proto.Message) error {
+	if msg == nil {
+		return nil
+	}
+	return apply(msg.ProtoReflect())
+}
+
+// apply 为 m 中未设置的字段填充默认值.
+func apply(m protoreflect.Message) error {
+	// 消息为 nil 指针时无法设置字段
+	if !m.IsValid() {
+		return nil
+	}
+
+	p := planFor(m.Descriptor())
+	if p.err != nil {
+		return p.err
+	}
+
+	for _, f := range p.fields {
+		if err := f.apply(m); err != nil {
+			return err
+		}
+	}
+	for _, f := range p.oneofs {
+		// oneof 中已经设置了其他字段时不做处理
+		if m.WhichOneof(f.fd.ContainingOneof()) != nil {
+			continue
+		}
+		if err := f.apply(m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply 为 m 中的字段 f 填充默认值.
+func (f *fieldPlan) apply(m protoreflect.Message) error {
+	if !m.Has(f.fd) {
+		if f.value != nil {
+			m.Set(f.fd, f.value())
+			return nil
+		}
+		if !f.initialize {
+			return nil
+		}
+		m.Set(f.fd, m.NewField(f.fd))
+	}
+
+	if f.defaults {
+		return apply(m.Mutable(f.fd).Message())
+	}
+	return nil
+}
+
+// planFor 返回消息类型 md 的默认值填充计划.
+func planFor(md protoreflect.MessageDescriptor) *plan {
+	if p, ok := plans.Load(md.FullName()); ok {
+		return p.(*plan)
+	}
+	p, _ := plans.LoadOrStore(md.FullName(), compile(md))
+	return p.(*plan)
+}
+
+// compile 解析消息类型 md 的 defaults 注解，生成默认值填充计划.
+func compile(md protoreflect.MessageDescriptor) *plan {
+	p := &plan{}
+
+	opts := md.Options()
+	if proto.GetExtension(opts, defaults.E_Disabled).(bool) || proto.GetExtension(opts, defaults.E_Ignored).(bool) {
+		return p
+	}
+
+	fields := md.Fields()
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+		// oneof 中的字段只通过 (defaults.oneof) 设置，避免覆盖 oneof 中已经设置的其他字段
+		if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+			continue
+		}
+
+		f, err := compileField(fd)
+		if err != nil {
+			p.err = err
+			return p
+		}
+		if f != nil {
+			p.fields = append(p.fields, f)
+		}
+	}
+
+	oneofs := md.Oneofs()
+	for i := range oneofs.Len() {
+		od := oneofs.Get(i)
+		name := proto.GetExtension(od.Options(), defaults.E_Oneof).(string)
+		if od.IsSynthetic() || name == "" {
+			continue
+		}
+
+		fd := od.Fields().ByName(protoreflect.Name(name))
+		if fd == nil {
+			p.err = fmt.Errorf("defaulter: oneof %s has no field named %q", od.FullName(), name)
+			return p
+		}
+		f, err := compileField(fd)
+		if err != nil {
+			p.err = err
+			return p
+		}
+		if f == nil {
+			f = &fieldPlan{fd: fd}
+		}
+		// 没有默认值的字段设置为零值（消息类型的字段设置为空消息），以选中该字段
+		if fd.Message() != nil {
+			f.initialize = true
+		} else if f.value == nil {
+			f.value = fd.Default
+		}
+		p.oneofs = append(p.oneofs, f)
+	}
+
+	return p
+}
+
+// compileField 解析字段 fd 的 (defaults.value) 注解. 字段没有默认值时返回 nil.
+func compileField(fd protoreflect.FieldDescriptor) (*fieldPlan, error) {
+	if !proto.HasExtension(fd.Options(), defaults.E_Value) {
+		return nil, nil
+	}
+	if fd.IsList() || fd.IsMap() {
+		return nil, fmt.Errorf("defaulter: field %s: defaults are not supported for repeated and map fields", fd.FullName())
+	}
+
+	fdv := proto.GetExtension(fd.Options(), defaults.E_Value).(*defaults.FieldDefaults)
+	f := &fieldPlan{fd: fd}
+
+	// scalar 设置标量字段的默认值，字段类型必须为 kind
+	scalar := func(kind protoreflect.Kind, v protoreflect.Value) error {
+		if fd.Kind() != kind {
+			return mismatch(fd, fdv)
+		}
+		f.value = func() protoreflect.Value { return v }
+		return nil
+	}
+
+	var err error
+	switch typed := fdv.GetType().(type) {
+	case *defaults.FieldDefaults_Float:
+		err = scalar(protoreflect.FloatKind, protoreflect.ValueOfFloat32(typed.Float))
+	case *defaults.FieldDefaults_Double:
+		err = scalar(protoreflect.DoubleKind, protoreflect.ValueOfFloat64(typed.Double))
+	case *defaults.FieldDefaults_Int32:
+		err = scalar(protoreflect.Int32Kind, protoreflect.ValueOfInt32(typed.Int32))
+	case *defaults.FieldDefaults_Int64:
+		err = scalar(protoreflect.Int64Kind, protoreflect.ValueOfInt64(typed.Int64))
+	case *defaults.FieldDefaults_Uint32:
+		err = scalar(protoreflect.Uint32Kind, protoreflect.ValueOfUint32(typed.Uint32))
+	case *defaults.FieldDefaults_Uint64:
+		err = scalar(protoreflect.Uint64Kind, protoreflect.ValueOfUint64(typed.Uint64))
+	case *defaults.FieldDefaults_Sint32:
+		err = scalar(protoreflect.Sint32Kind, protoreflect.ValueOfInt32(typed.Sint32))
+	case *defaults.FieldDefaults_Sint64:
+		err = scalar(protoreflect.Sint64Kind, protoreflect.ValueOfInt64(typed.Sint64))
+	case *defaults.FieldDefaults_Fixed32:
+		err = scalar(protoreflect.Fixed32Kind, protoreflect.ValueOfUint32(typed.Fixed32))
+	case *defaults.FieldDefaults_Fixed64:
+		err = scalar(protoreflect.Fixed64Kind, protoreflect.ValueOfUint64(typed.Fixed64))
+	case *defaults.FieldDefaults_Sfixed32:
+		err = scalar(protoreflect.Sfixed32Kind, protoreflect.ValueOfInt32(typed.Sfixed32))
+	case *defaults.FieldDefaults_Sfixed64:
+		err = scalar(protoreflect.Sfixed64Kind, protoreflect.ValueOfInt64(typed.Sfixed64))
+	case *defaults.FieldDefaults_Bool:
+		err = scalar(protoreflect.BoolKind, protoreflect.ValueOfBool(typed.Bool))
+	case *defaults.FieldDefaults_String_:
+		err = scalar(protoreflect.StringKind, protoreflect.ValueOfString(typed.String_))
+	case *defaults.FieldDefaults_Enum:
+		err = scalar(protoreflect.EnumKind, protoreflect.ValueOfEnum(protoreflect.EnumNumber(typed.Enum)))
+	case *defaults.FieldDefaults_Bytes:
+		if fd.Kind() != protoreflect.BytesKind {
+			return nil, mismatch(fd, fdv)
+		}
+		// 每次返回默认值的副本，避免多个消息共享同一个字节切片
+		f.value = func() protoreflect.Value { return protoreflect.ValueOfBytes(bytes.Clone(typed.Bytes)) }
+	case *defaults.FieldDefaults_Message:
+		if fd.Kind() != protoreflect.MessageKind {
+			return nil, mismatch(fd, fdv)
+		}
+		f.initialize = typed.Message.GetInitialize()
+		f.defaults = typed.Message.GetDefaults()
+	case *defaults.FieldDefaults_Duration:
+		if !isMessage(fd, "google.protobuf.Duration") {
+			return nil, mismatch(fd, fdv)
+		}
+		d, perr := time.ParseDuration(typed.Duration)
+		if perr != nil {
+			return nil, fmt.Errorf("defaulter: field %s: invalid duration %q: %w", fd.FullName(), typed.Duration, perr)
+		}
+		f.value = func() protoreflect.Value { return protoreflect.ValueOfMessage(durationpb.New(d).ProtoReflect()) }
+	case *defaults.FieldDefaults_Timestamp:
+		if !isMessage(fd, "google.protobuf.Timestamp") {
+			return nil, mismatch(fd, fdv)
+		}
+		// now 表示填充默认值时的当前时间，其他值必须为 RFC 3339 格式
+		if typed.Timestamp == "now" {
+			f.value = func() protoreflect.Value { return protoreflect.ValueOfMessage(timestamppb.Now().ProtoReflect()) }
+			break
+		}
+		t, perr := time.Parse(time.RFC3339Nano, typed.Timestamp)
+		if perr != nil {
+			return nil, fmt.Errorf("defaulter: field %s: invalid timestamp %q: %w", fd.FullName(), typed.Timestamp, perr)
+		}
+		f.value = func() protoreflect.Value { return protoreflect.ValueOfMessage(timestamppb.New(t).ProtoReflect()) }
+	default:
+		// 注解中没有设置默认值
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// isMessage 判断字段 fd 的类型是否为全名为 name 的消息.
+func isMessage(fd protoreflect.FieldDescriptor, name protoreflect.FullName) bool {
+	return fd.Message() != nil && fd.Message().FullName() == name
+}
+
+// mismatch 返回默认值类型与字段类型不匹配的错误.
+func mismatch(fd protoreflect.FieldDescriptor, fdv *defaults.FieldDefaults) error {
+	return fmt.Errorf("defaulter: field %s: default value %T does not match field type %s", fd.FullName(), fdv.GetType(), fd.Kind())
+}
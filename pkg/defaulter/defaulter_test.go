@@ -0,0 +1,116 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package defaulter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/ra1n6ow/opsx/pkg/defaulter/internal/testpb"
+)
+
+// defaultScalars 返回填充了所有默认值的 Scalars.
+func defaultScalars() *testpb.Scalars {
+	return &testpb.Scalars{
+		Float:          1.5,
+		Double:         2.5,
+		Int32:          -3,
+		Int64:          -4,
+		Uint32:         5,
+		Uint64:         6,
+		Sint32:         -7,
+		Sint64:         -8,
+		Fixed32:        9,
+		Fixed64:        10,
+		Sfixed32:       -11,
+		Sfixed64:       -12,
+		Bool:           true,
+		String_:        "default",
+		Bytes:          []byte("bytes"),
+		Color:          testpb.Color_COLOR_BLUE,
+		Duration:       durationpb.New(90 * time.Second),
+		Timestamp:      timestamppb.New(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)),
+		OptionalString: proto.String("optional"),
+	}
+}
+
+func TestApplyScalars(t *testing.T) {
+	msg := &testpb.Scalars{}
+	require.NoError(t, Apply(msg))
+	assertProtoEqual(t, defaultScalars(), msg)
+
+	// 已经设置的字段保持不变
+	msg = &testpb.Scalars{Int64: 100, String_: "set", OptionalString: proto.String(""), Duration: durationpb.New(time.Second)}
+	require.NoError(t, Apply(msg))
+	assert.EqualValues(t, 100, msg.GetInt64())
+	assert.Equal(t, "set", msg.GetString_())
+	assert.Equal(t, "", msg.GetOptionalString())
+	assert.Equal(t, time.Second, msg.GetDuration().AsDuration())
+	assert.Equal(t, float32(1.5), msg.GetFloat())
+
+	// 多个消息不共享默认值
+	other := &testpb.Scalars{}
+	require.NoError(t, Apply(other))
+	other.Bytes[0] = 'B'
+	assert.Equal(t, []byte("bytes"), msg.GetBytes())
+}
+
+func TestApplyNested(t *testing.T) {
+	msg := &testpb.Nested{DefaultsIfSet: &testpb.Scalars{Int64: 1}}
+	require.NoError(t, Apply(msg))
+
+	// initialize 和 defaults 均设置时，初始化字段并填充默认值
+	assertProtoEqual(t, defaultScalars(), msg.GetInitialized())
+	// 只设置 initialize 时，只初始化字段
+	assertProtoEqual(t, &testpb.Scalars{}, msg.GetOnlyInitialized())
+	// 只设置 defaults 时，为已经设置的字段填充默认值
+	assert.EqualValues(t, 1, msg.GetDefaultsIfSet().GetInt64())
+	assert.Equal(t, "default", msg.GetDefaultsIfSet().GetString_())
+	// 没有注解的字段不做处理
+	assert.Nil(t, msg.GetUntouched())
+	// 禁用了默认值的消息只初始化，不填充默认值
+	assert.NotNil(t, msg.GetDisabled())
+	assert.Empty(t, msg.GetDisabled().GetString_())
+	// oneof 中没有设置字段时，设置 (defaults.oneof) 指定的字段
+	assert.EqualValues(t, 42, msg.GetNumber())
+
+	// oneof 中已经设置了其他字段时保持不变
+	msg = &testpb.Nested{Choice: &testpb.Nested_Text{Text: ""}}
+	require.NoError(t, Apply(msg))
+	assert.Equal(t, &testpb.Nested_Text{Text: ""}, msg.GetChoice())
+
+	// 没有注解的消息字段，即使已经设置也不填充默认值
+	msg = &testpb.Nested{Untouched: &testpb.Scalars{}}
+	require.NoError(t, Apply(msg))
+	assert.Empty(t, msg.GetUntouched().GetString_())
+}
+
+func TestApplyDisabled(t *testing.T) {
+	msg := &testpb.Disabled{}
+	require.NoError(t, Apply(msg))
+	assert.Empty(t, msg.GetString_())
+}
+
+func TestApplyInvalid(t *testing.T) {
+	err := Apply(&testpb.Invalid{})
+	assert.ErrorContains(t, err, "field opsx.defaulter.testpb.Invalid.count")
+
+	// nil 消息不做处理
+	assert.NoError(t, Apply(nil))
+	assert.NoError(t, Apply((*testpb.Scalars)(nil)))
+}
+
+// assertProtoEqual 断言两个 Protobuf 消息相等.
+func assertProtoEqual(t *testing.T, want, got proto.Message) {
+	t.Helper()
+	assert.True(t, proto.Equal(want, got), "want: %v\ngot:  %v", want, got)
+}
@@ -0,0 +1,619 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// defaulter 包的测试消息，覆盖 defaults 注解支持的所有字段类型
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.4
+// source: testpb.proto
+
+package testpb
+
+import (
+	_ "github.com/ra1n6ow/opsx/pkg/api/defaults"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Color int32
+
+const (
+	Color_COLOR_UNSPECIFIED Color = 0
+	Color_COLOR_RED         Color = 1
+	Color_COLOR_BLUE        Color = 2
+)
+
+// Enum value maps for Color.
+var (
+	Color_name = map[int32]string{
+		0: "COLOR_UNSPECIFIED",
+		1: "COLOR_RED",
+		2: "COLOR_BLUE",
+	}
+	Color_value = map[string]int32{
+		"COLOR_UNSPECIFIED": 0,
+		"COLOR_RED":         1,
+		"COLOR_BLUE":        2,
+	}
+)
+
+func (x Color) Enum() *Color {
+	p := new(Color)
+	*p = x
+	return p
+}
+
+func (x Color) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Color) Descriptor() protoreflect.EnumDescriptor {
+	return file_testpb_proto_enumTypes[0].Descriptor()
+}
+
+func (Color) Type() protoreflect.EnumType {
+	return &file_testpb_proto_enumTypes[0]
+}
+
+func (x Color) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Color.Descriptor instead.
+func (Color) EnumDescriptor() ([]byte, []int) {
+	return file_testpb_proto_rawDescGZIP(), []int{0}
+}
+
+// Scalars 包含所有支持默认值的字段类型
+type Scalars struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Float          float32                `protobuf:"fixed32,1,opt,name=float,proto3" json:"float,omitempty"`
+	Double         float64                `protobuf:"fixed64,2,opt,name=double,proto3" json:"double,omitempty"`
+	Int32          int32                  `protobuf:"varint,3,opt,name=int32,proto3" json:"int32,omitempty"`
+	Int64          int64                  `protobuf:"varint,4,opt,name=int64,proto3" json:"int64,omitempty"`
+	Uint32         uint32                 `protobuf:"varint,5,opt,name=uint32,proto3" json:"uint32,omitempty"`
+	Uint64         uint64                 `protobuf:"varint,6,opt,name=uint64,proto3" json:"uint64,omitempty"`
+	Sint32         int32                  `protobuf:"zigzag32,7,opt,name=sint32,proto3" json:"sint32,omitempty"`
+	Sint64         int64                  `protobuf:"zigzag64,8,opt,name=sint64,proto3" json:"sint64,omitempty"`
+	Fixed32        uint32                 `protobuf:"fixed32,9,opt,name=fixed32,proto3" json:"fixed32,omitempty"`
+	Fixed64        uint64                 `protobuf:"fixed64,10,opt,name=fixed64,proto3" json:"fixed64,omitempty"`
+	Sfixed32       int32                  `protobuf:"fixed32,11,opt,name=sfixed32,proto3" json:"sfixed32,omitempty"`
+	Sfixed64       int64                  `protobuf:"fixed64,12,opt,name=sfixed64,proto3" json:"sfixed64,omitempty"`
+	Bool           bool                   `protobuf:"varint,13,opt,name=bool,proto3" json:"bool,omitempty"`
+	String_        string                 `protobuf:"bytes,14,opt,name=string,proto3" json:"string,omitempty"`
+	Bytes          []byte                 `protobuf:"bytes,15,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Color          Color                  `protobuf:"varint,16,opt,name=color,proto3,enum=opsx.defaulter.testpb.Color" json:"color,omitempty"`
+	Duration       *durationpb.Duration   `protobuf:"bytes,17,opt,name=duration,proto3" json:"duration,omitempty"`
+	Timestamp      *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	OptionalString *string                `protobuf:"bytes,19,opt,name=optional_string,json=optionalString,proto3,oneof" json:"optional_string,omitempty"`
+	NoDefault      string                 `protobuf:"bytes,20,opt,name=no_default,json=noDefault,proto3" json:"no_default,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Scalars) Reset() {
+	*x = Scalars{}
+	mi := &file_testpb_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Scalars) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Scalars) ProtoMessage() {}
+
+func (x *Scalars) ProtoReflect() protoreflect.Message {
+	mi := &file_testpb_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Scalars.ProtoReflect.Descriptor instead.
+func (*Scalars) Descriptor() ([]byte, []int) {
+	return file_testpb_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Scalars) GetFloat() float32 {
+	if x != nil {
+		return x.Float
+	}
+	return 0
+}
+
+func (x *Scalars) GetDouble() float64 {
+	if x != nil {
+		return x.Double
+	}
+	return 0
+}
+
+func (x *Scalars) GetInt32() int32 {
+	if x != nil {
+		return x.Int32
+	}
+	return 0
+}
+
+func (x *Scalars) GetInt64() int64 {
+	if x != nil {
+		return x.Int64
+	}
+	return 0
+}
+
+func (x *Scalars) GetUint32() uint32 {
+	if x != nil {
+		return x.Uint32
+	}
+	return 0
+}
+
+func (x *Scalars) GetUint64() uint64 {
+	if x != nil {
+		return x.Uint64
+	}
+	return 0
+}
+
+func (x *Scalars) GetSint32() int32 {
+	if x != nil {
+		return x.Sint32
+	}
+	return 0
+}
+
+func (x *Scalars) GetSint64() int64 {
+	if x != nil {
+		return x.Sint64
+	}
+	return 0
+}
+
+func (x *Scalars) GetFixed32() uint32 {
+	if x != nil {
+		return x.Fixed32
+	}
+	return 0
+}
+
+func (x *Scalars) GetFixed64() uint64 {
+	if x != nil {
+		return x.Fixed64
+	}
+	return 0
+}
+
+func (x *Scalars) GetSfixed32() int32 {
+	if x != nil {
+		return x.Sfixed32
+	}
+	return 0
+}
+
+func (x *Scalars) GetSfixed64() int64 {
+	if x != nil {
+		return x.Sfixed64
+	}
+	return 0
+}
+
+func (x *Scalars) GetBool() bool {
+	if x != nil {
+		return x.Bool
+	}
+	return false
+}
+
+func (x *Scalars) GetString_() string {
+	if x != nil {
+		return x.String_
+	}
+	return ""
+}
+
+func (x *Scalars) GetBytes() []byte {
+	if x != nil {
+		return x.Bytes
+	}
+	return nil
+}
+
+func (x *Scalars) GetColor() Color {
+	if x != nil {
+		return x.Color
+	}
+	return Color_COLOR_UNSPECIFIED
+}
+
+func (x *Scalars) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *Scalars) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *Scalars) GetOptionalString() string {
+	if x != nil && x.OptionalString != nil {
+		return *x.OptionalString
+	}
+	return ""
+}
+
+func (x *Scalars) GetNoDefault() string {
+	if x != nil {
+		return x.NoDefault
+	}
+	return ""
+}
+
+// Nested 包含嵌套消息和 oneof 的默认值
+type Nested struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// initialized 未设置时初始化，并填充其字段的默认值
+	Initialized *Scalars `protobuf:"bytes,1,opt,name=initialized,proto3" json:"initialized,omitempty"`
+	// only_initialized 未设置时初始化，但不填充其字段的默认值
+	OnlyInitialized *Scalars `protobuf:"bytes,2,opt,name=only_initialized,json=onlyInitialized,proto3" json:"only_initialized,omitempty"`
+	// defaults_if_set 设置时填充其字段的默认值
+	DefaultsIfSet *Scalars `protobuf:"bytes,3,opt,name=defaults_if_set,json=defaultsIfSet,proto3" json:"defaults_if_set,omitempty"`
+	// untouched 没有默认值注解
+	Untouched *Scalars `protobuf:"bytes,4,opt,name=untouched,proto3" json:"untouched,omitempty"`
+	// disabled 的消息类型禁用了默认值
+	Disabled *Disabled `protobuf:"bytes,5,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	// Types that are valid to be assigned to Choice:
+	//
+	//	*Nested_Text
+	//	*Nested_Number
+	Choice        isNested_Choice `protobuf_oneof:"choice"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Nested) Reset() {
+	*x = Nested{}
+	mi := &file_testpb_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nested) ProtoMessage() {}
+
+func (x *Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_testpb_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nested.ProtoReflect.Descriptor instead.
+func (*Nested) Descriptor() ([]byte, []int) {
+	return file_testpb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Nested) GetInitialized() *Scalars {
+	if x != nil {
+		return x.Initialized
+	}
+	return nil
+}
+
+func (x *Nested) GetOnlyInitialized() *Scalars {
+	if x != nil {
+		return x.OnlyInitialized
+	}
+	return nil
+}
+
+func (x *Nested) GetDefaultsIfSet() *Scalars {
+	if x != nil {
+		return x.DefaultsIfSet
+	}
+	return nil
+}
+
+func (x *Nested) GetUntouched() *Scalars {
+	if x != nil {
+		return x.Untouched
+	}
+	return nil
+}
+
+func (x *Nested) GetDisabled() *Disabled {
+	if x != nil {
+		return x.Disabled
+	}
+	return nil
+}
+
+func (x *Nested) GetChoice() isNested_Choice {
+	if x != nil {
+		return x.Choice
+	}
+	return nil
+}
+
+func (x *Nested) GetText() string {
+	if x != nil {
+		if x, ok := x.Choice.(*Nested_Text); ok {
+			return x.Text
+		}
+	}
+	return ""
+}
+
+func (x *Nested) GetNumber() int64 {
+	if x != nil {
+		if x, ok := x.Choice.(*Nested_Number); ok {
+			return x.Number
+		}
+	}
+	return 0
+}
+
+type isNested_Choice interface {
+	isNested_Choice()
+}
+
+type Nested_Text struct {
+	Text string `protobuf:"bytes,6,opt,name=text,proto3,oneof"`
+}
+
+type Nested_Number struct {
+	Number int64 `protobuf:"varint,7,opt,name=number,proto3,oneof"`
+}
+
+func (*Nested_Text) isNested_Choice() {}
+
+func (*Nested_Number) isNested_Choice() {}
+
+// Disabled 禁用了默认值
+type Disabled struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	String_       string                 `protobuf:"bytes,1,opt,name=string,proto3" json:"string,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Disabled) Reset() {
+	*x = Disabled{}
+	mi := &file_testpb_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Disabled) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Disabled) ProtoMessage() {}
+
+func (x *Disabled) ProtoReflect() protoreflect.Message {
+	mi := &file_testpb_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Disabled.ProtoReflect.Descriptor instead.
+func (*Disabled) Descriptor() ([]byte, []int) {
+	return file_testpb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Disabled) GetString_() string {
+	if x != nil {
+		return x.String_
+	}
+	return ""
+}
+
+// Invalid 的默认值类型与字段类型不匹配
+type Invalid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Invalid) Reset() {
+	*x = Invalid{}
+	mi := &file_testpb_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invalid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invalid) ProtoMessage() {}
+
+func (x *Invalid) ProtoReflect() protoreflect.Message {
+	mi := &file_testpb_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invalid.ProtoReflect.Descriptor instead.
+func (*Invalid) Descriptor() ([]byte, []int) {
+	return file_testpb_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Invalid) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+var File_testpb_proto protoreflect.FileDescriptor
+
+const file_testpb_proto_rawDesc = "" +
+	"\n" +
+	"\ftestpb.proto\x12\x15opsx.defaulter.testpb\x1a,github.com/onexstack/defaults/defaults.proto\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xdc\x06\n" +
+	"\aScalars\x12\x1e\n" +
+	"\x05float\x18\x01 \x01(\x02B\b\x9aI\x05\r\x00\x00\xc0?R\x05float\x12$\n" +
+	"\x06double\x18\x02 \x01(\x01B\f\x9aI\t\x11\x00\x00\x00\x00\x00\x00\x04@R\x06double\x12$\n" +
+	"\x05int32\x18\x03 \x01(\x05B\x0e\x9aI\v\x18\xfd\xff\xff\xff\xff\xff\xff\xff\xff\x01R\x05int32\x12$\n" +
+	"\x05int64\x18\x04 \x01(\x03B\x0e\x9aI\v \xfc\xff\xff\xff\xff\xff\xff\xff\xff\x01R\x05int64\x12\x1d\n" +
+	"\x06uint32\x18\x05 \x01(\rB\x05\x9aI\x02(\x05R\x06uint32\x12\x1d\n" +
+	"\x06uint64\x18\x06 \x01(\x04B\x05\x9aI\x020\x06R\x06uint64\x12\x1d\n" +
+	"\x06sint32\x18\a \x01(\x11B\x05\x9aI\x028\rR\x06sint32\x12\x1d\n" +
+	"\x06sint64\x18\b \x01(\x12B\x05\x9aI\x02@\x0fR\x06sint64\x12\"\n" +
+	"\afixed32\x18\t \x01(\aB\b\x9aI\x05M\t\x00\x00\x00R\afixed32\x12&\n" +
+	"\afixed64\x18\n" +
+	" \x01(\x06B\f\x9aI\tQ\n" +
+	"\x00\x00\x00\x00\x00\x00\x00R\afixed64\x12$\n" +
+	"\bsfixed32\x18\v \x01(\x0fB\b\x9aI\x05]\xf5\xff\xff\xffR\bsfixed32\x12(\n" +
+	"\bsfixed64\x18\f \x01(\x10B\f\x9aI\ta\xf4\xff\xff\xff\xff\xff\xff\xffR\bsfixed64\x12\x19\n" +
+	"\x04bool\x18\r \x01(\bB\x05\x9aI\x02h\x01R\x04bool\x12$\n" +
+	"\x06string\x18\x0e \x01(\tB\f\x9aI\tr\adefaultR\x06string\x12 \n" +
+	"\x05bytes\x18\x0f \x01(\fB\n" +
+	"\x9aI\az\x05bytesR\x05bytes\x12:\n" +
+	"\x05color\x18\x10 \x01(\x0e2\x1c.opsx.defaulter.testpb.ColorB\x06\x9aI\x03\x80\x01\x02R\x05color\x12B\n" +
+	"\bduration\x18\x11 \x01(\v2\x19.google.protobuf.DurationB\v\x9aI\b\xaa\x01\x051m30sR\bduration\x12T\n" +
+	"\ttimestamp\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampB\x1a\x9aI\x17\xb2\x01\x142025-01-02T03:04:05ZR\ttimestamp\x12;\n" +
+	"\x0foptional_string\x18\x13 \x01(\tB\r\x9aI\n" +
+	"r\boptionalH\x00R\x0eoptionalString\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"no_default\x18\x14 \x01(\tR\tnoDefaultB\x12\n" +
+	"\x10_optional_string\"\xdb\x03\n" +
+	"\x06Nested\x12L\n" +
+	"\vinitialized\x18\x01 \x01(\v2\x1e.opsx.defaulter.testpb.ScalarsB\n" +
+	"\x9aI\a\x8a\x01\x04\b\x01\x10\x01R\vinitialized\x12S\n" +
+	"\x10only_initialized\x18\x02 \x01(\v2\x1e.opsx.defaulter.testpb.ScalarsB\b\x9aI\x05\x8a\x01\x02\b\x01R\x0fonlyInitialized\x12P\n" +
+	"\x0fdefaults_if_set\x18\x03 \x01(\v2\x1e.opsx.defaulter.testpb.ScalarsB\b\x9aI\x05\x8a\x01\x02\x10\x01R\rdefaultsIfSet\x12<\n" +
+	"\tuntouched\x18\x04 \x01(\v2\x1e.opsx.defaulter.testpb.ScalarsR\tuntouched\x12G\n" +
+	"\bdisabled\x18\x05 \x01(\v2\x1f.opsx.defaulter.testpb.DisabledB\n" +
+	"\x9aI\a\x8a\x01\x04\b\x01\x10\x01R\bdisabled\x12\x1f\n" +
+	"\x04text\x18\x06 \x01(\tB\t\x9aI\x06r\x04textH\x00R\x04text\x12\x1f\n" +
+	"\x06number\x18\a \x01(\x03B\x05\x9aI\x02 *H\x00R\x06numberB\x13\n" +
+	"\x06choice\x12\t\x9aI\x06number\"5\n" +
+	"\bDisabled\x12$\n" +
+	"\x06string\x18\x01 \x01(\tB\f\x9aI\tr\adefaultR\x06string:\x03\x98I\x01\"+\n" +
+	"\aInvalid\x12 \n" +
+	"\x05count\x18\x01 \x01(\x03B\n" +
+	"\x9aI\ar\x05wrongR\x05count*=\n" +
+	"\x05Color\x12\x15\n" +
+	"\x11COLOR_UNSPECIFIED\x10\x00\x12\r\n" +
+	"\tCOLOR_RED\x10\x01\x12\x0e\n" +
+	"\n" +
+	"COLOR_BLUE\x10\x02B>Z<github.com/ra1n6ow/opsx/pkg/defaulter/internal/testpb;testpbb\x06proto3"
+
+var (
+	file_testpb_proto_rawDescOnce sync.Once
+	file_testpb_proto_rawDescData []byte
+)
+
+func file_testpb_proto_rawDescGZIP() []byte {
+	file_testpb_proto_rawDescOnce.Do(func() {
+		file_testpb_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_testpb_proto_rawDesc), len(file_testpb_proto_rawDesc)))
+	})
+	return file_testpb_proto_rawDescData
+}
+
+var file_testpb_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_testpb_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_testpb_proto_goTypes = []any{
+	(Color)(0),                    // 0: opsx.defaulter.testpb.Color
+	(*Scalars)(nil),               // 1: opsx.defaulter.testpb.Scalars
+	(*Nested)(nil),                // 2: opsx.defaulter.testpb.Nested
+	(*Disabled)(nil),              // 3: opsx.defaulter.testpb.Disabled
+	(*Invalid)(nil),               // 4: opsx.defaulter.testpb.Invalid
+	(*durationpb.Duration)(nil),   // 5: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
+}
+var file_testpb_proto_depIdxs = []int32{
+	0, // 0: opsx.defaulter.testpb.Scalars.color:type_name -> opsx.defaulter.testpb.Color
+	5, // 1: opsx.defaulter.testpb.Scalars.duration:type_name -> google.protobuf.Duration
+	6, // 2: opsx.defaulter.testpb.Scalars.timestamp:type_name -> google.protobuf.Timestamp
+	1, // 3: opsx.defaulter.testpb.Nested.initialized:type_name -> opsx.defaulter.testpb.Scalars
+	1, // 4: opsx.defaulter.testpb.Nested.only_initialized:type_name -> opsx.defaulter.testpb.Scalars
+	1, // 5: opsx.defaulter.testpb.Nested.defaults_if_set:type_name -> opsx.defaulter.testpb.Scalars
+	1, // 6: opsx.defaulter.testpb.Nested.untouched:type_name -> opsx.defaulter.testpb.Scalars
+	3, // 7: opsx.defaulter.testpb.Nested.disabled:type_name -> opsx.defaulter.testpb.Disabled
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_testpb_proto_init() }
+func file_testpb_proto_init() {
+	if File_testpb_proto != nil {
+		return
+	}
+	file_testpb_proto_msgTypes[0].OneofWrappers = []any{}
+	file_testpb_proto_msgTypes[1].OneofWrappers = []any{
+		(*Nested_Text)(nil),
+		(*Nested_Number)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_testpb_proto_rawDesc), len(file_testpb_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_testpb_proto_goTypes,
+		DependencyIndexes: file_testpb_proto_depIdxs,
+		EnumInfos:         file_testpb_proto_enumTypes,
+		MessageInfos:      file_testpb_proto_msgTypes,
+	}.Build()
+	File_testpb_proto = out.File
+	file_testpb_proto_goTypes = nil
+	file_testpb_proto_depIdxs = nil
+}
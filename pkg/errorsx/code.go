@@ -2,29 +2,29 @@ package errorsx
 
 import "net/http"
 
-// errorsx 预定义标准的错误.
+// errorsx 预定义标准的错误. 除 OK 外，所有错误均已注册.
 var (
 	// OK 代表请求成功.
 	OK = &ErrorX{Code: http.StatusOK, Message: ""}
 
 	// ErrInternal 表示所有未知的服务器端错误.
-	ErrInternal = &ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError", Message: "Internal server error."}
+	ErrInternal = Register(http.StatusInternalServerError, "InternalError", "Internal server error.")
 
 	// ErrNotFound 表示资源未找到.
-	ErrNotFound = &ErrorX{Code: http.StatusNotFound, Reason: "NotFound", Message: "Resource not found."}
+	ErrNotFound = Register(http.StatusNotFound, "NotFound", "Resource not found.")
 
 	// ErrBind 表示请求体绑定错误.
-	ErrBind = &ErrorX{Code: http.StatusBadRequest, Reason: "BindError", Message: "Error occurred while binding the request body to the struct."}
+	ErrBind = Register(http.StatusBadRequest, "BindError", "Error occurred while binding the request body to the struct.")
 
 	// ErrInvalidArgument 表示参数验证失败.
-	ErrInvalidArgument = &ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument", Message: "Argument verification failed."}
+	ErrInvalidArgument = Register(http.StatusBadRequest, "InvalidArgument", "Argument verification failed.")
 
 	// ErrUnauthenticated 表示认证失败.
-	ErrUnauthenticated = &ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated", Message: "Unauthenticated."}
+	ErrUnauthenticated = Register(http.StatusUnauthorized, "Unauthenticated", "Unauthenticated.")
 
 	// ErrPermissionDenied 表示请求没有权限.
-	ErrPermissionDenied = &ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied", Message: "Permission denied. Access to the requested resource is forbidden."}
+	ErrPermissionDenied = Register(http.StatusForbidden, "PermissionDenied", "Permission denied. Access to the requested resource is forbidden.")
 
 	// ErrOperationFailed 表示操作失败.
-	ErrOperationFailed = &ErrorX{Code: http.StatusConflict, Reason: "OperationFailed", Message: "The requested operation has failed. Please try again later."}
+	ErrOperationFailed = Register(http.StatusConflict, "OperationFailed", "The requested operation has failed. Please try again later.")
 )
@@ -0,0 +1,181 @@
+package errorsx
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"golang.org/x/text/language"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// FieldViolation 描述请求中某个字段的校验错误，对应 gRPC 错误详情中的 errdetails.BadRequest_FieldViolation.
+type FieldViolation struct {
+	// Field 为校验失败的字段路径，例如 username 或者 user.email.
+	Field string `json:"field"`
+
+	// Description 为字段校验失败的原因.
+	Description string `json:"description"`
+
+	// Reason 为字段校验失败的错误原因，例如 InvalidArgument.UsernameInvalid，可选.
+	Reason string `json:"reason,omitempty"`
+}
+
+// LocalizedMessage 为本地化后的错误信息，对应 gRPC 错误详情中的 errdetails.LocalizedMessage.
+type LocalizedMessage struct {
+	// Locale 为错误信息的语言，例如 zh-CN.
+	Locale string `json:"locale"`
+
+	// Message 为本地化后的错误信息.
+	Message string `json:"message"`
+}
+
+// WithFieldViolations 返回一个追加了字段校验错误的错误副本.
+func (err *ErrorX) WithFieldViolations(violations ...FieldViolation) *ErrorX {
+	errx := err.Clone()
+	errx.FieldViolations = append(errx.FieldViolations, violations...)
+	return errx
+}
+
+// WithRetryDelay 返回一个设置了重试等待时间的错误副本，客户端应至少等待 delay 后再重试请求.
+func (err *ErrorX) WithRetryDelay(delay time.Duration) *ErrorX {
+	errx := err.Clone()
+	errx.RetryDelay = delay
+	return errx
+}
+
+// WithLocalizedMessage 返回一个设置了本地化错误信息的错误副本.
+func (err *ErrorX) WithLocalizedMessage(locale, message string) *ErrorX {
+	errx := err.Clone()
+	errx.LocalizedMessage = &LocalizedMessage{Locale: locale, Message: message}
+	return errx
+}
+
+// catalog 保存通过 RegisterLocalizedMessages 注册的本地化错误信息.
+var catalog = struct {
+	sync.RWMutex
+	// tags 为已注册的语言，顺序与注册顺序一致.
+	tags []language.Tag
+	// messages 的键为语言，值为错误原因到本地化错误信息的映射.
+	messages map[language.Tag]map[string]string
+}{messages: make(map[language.Tag]map[string]string)}
+
+// RegisterLocalizedMessages 注册 locale 语言的本地化错误信息，messages 的键为错误原因（Reason）.
+// 多次注册同一种语言时，会合并错误信息. 通常在 init 函数中调用.
+func RegisterLocalizedMessages(locale string, messages map[string]string) {
+	tag := language.Make(locale)
+
+	catalog.Lock()
+	defer catalog.Unlock()
+
+	if _, ok := catalog.messages[tag]; !ok {
+		catalog.tags = append(catalog.tags, tag)
+		catalog.messages[tag] = make(map[string]string, len(messages))
+	}
+	for reason, message := range messages {
+		catalog.messages[tag][reason] = message
+	}
+}
+
+// LocalizedMessages 返回错误原因为 reason 的所有本地化错误信息，键为语言.
+func LocalizedMessages(reason string) map[string]string {
+	catalog.RLock()
+	defer catalog.RUnlock()
+
+	messages := make(map[string]string)
+	for _, tag := range catalog.tags {
+		if message, ok := catalog.messages[tag][reason]; ok {
+			messages[tag.String()] = message
+		}
+	}
+	return messages
+}
+
+// Localize 根据 acceptLanguage（HTTP Accept-Language 请求头的格式，例如 zh-CN,zh;q=0.9,en;q=0.8）
+// 返回一个设置了本地化错误信息的错误副本. 已经设置了本地化错误信息，或者没有匹配的语言和错误信息时，返回 err 本身.
+func (err *ErrorX) Localize(acceptLanguage string) *ErrorX {
+	if err.LocalizedMessage != nil || acceptLanguage == "" {
+		return err
+	}
+
+	prefs, _, perr := language.ParseAcceptLanguage(acceptLanguage)
+	if perr != nil || len(prefs) == 0 {
+		return err
+	}
+
+	catalog.RLock()
+	defer catalog.RUnlock()
+
+	if len(catalog.tags) == 0 {
+		return err
+	}
+	_, index, confidence := language.NewMatcher(catalog.tags).Match(prefs...)
+	if confidence == language.No {
+		return err
+	}
+
+	tag := catalog.tags[index]
+	message, ok := catalog.messages[tag][err.Reason]
+	if !ok {
+		return err
+	}
+	return err.WithLocalizedMessage(tag.String(), message)
+}
+
+// details 返回 err 对应的 gRPC 错误详情.
+func (err *ErrorX) details() []protoadapt.MessageV1 {
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: err.Reason, Metadata: err.Metadata}}
+
+	if len(err.FieldViolations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, v := range err.FieldViolations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       v.Field,
+				Description: v.Description,
+				Reason:      v.Reason,
+			})
+		}
+		details = append(details, badRequest)
+	}
+	if err.RetryDelay > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(err.RetryDelay)})
+	}
+	if err.LocalizedMessage != nil {
+		details = append(details, &errdetails.LocalizedMessage{
+			Locale:  err.LocalizedMessage.Locale,
+			Message: err.LocalizedMessage.Message,
+		})
+	}
+	return details
+}
+
+// setDetail 将 gRPC 错误详情 detail 设置到 err 中. 返回 false 表示不支持该类型的错误详情.
+func (err *ErrorX) setDetail(detail any) bool {
+	switch typed := detail.(type) {
+	case *errdetails.ErrorInfo:
+		err.Reason = typed.Reason
+		err.Metadata = typed.Metadata
+	case *errdetails.BadRequest:
+		for _, v := range typed.GetFieldViolations() {
+			err.FieldViolations = append(err.FieldViolations, FieldViolation{Field: v.GetField(), Description: v.GetDescription(), Reason: v.GetReason()})
+		}
+	case *errdetails.RetryInfo:
+		err.RetryDelay = typed.GetRetryDelay().AsDuration()
+	case *errdetails.LocalizedMessage:
+		err.LocalizedMessage = &LocalizedMessage{Locale: typed.GetLocale(), Message: typed.GetMessage()}
+	default:
+		return false
+	}
+	return true
+}
+
+// cloneDetails 深度拷贝 src 中的错误详情到 dst.
+func cloneDetails(dst, src *ErrorX) {
+	dst.FieldViolations = slices.Clone(src.FieldViolations)
+	if src.LocalizedMessage != nil {
+		lm := *src.LocalizedMessage
+		dst.LocalizedMessage = &lm
+	}
+}
@@ -4,13 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"runtime"
+	"strings"
+	"time"
 
 	httpstatus "github.com/go-kratos/kratos/v2/transport/http/status"
-	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/status"
 )
 
 // ErrorX 定义了 OpsX 项目体系中使用的错误类型，用于描述错误的详细信息.
+// ErrorX 的 With* 和 KV 方法均返回修改后的副本，不会修改原错误，
+// 因此可以在并发请求中安全地基于预定义的全局错误（例如 errno.ErrUserNotFound）构造新的错误.
 type ErrorX struct {
 	// Code 表示错误的 HTTP 状态码，用于与客户端进行交互时标识错误的类型.
 	Code int `json:"code,omitempty"`
@@ -23,6 +27,21 @@ type ErrorX struct {
 
 	// Metadata 用于存储与该错误相关的额外元信息，可以包含上下文或调试信息.
 	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// FieldViolations 为请求中字段的校验错误，通过 gRPC 的 BadRequest 错误详情传递.
+	FieldViolations []FieldViolation `json:"fieldViolations,omitempty"`
+
+	// RetryDelay 为客户端重试前需要等待的时间，例如请求被限流时. 通过 gRPC 的 RetryInfo 错误详情传递.
+	RetryDelay time.Duration `json:"-"`
+
+	// LocalizedMessage 为本地化后的错误信息，通过 gRPC 的 LocalizedMessage 错误详情传递.
+	LocalizedMessage *LocalizedMessage `json:"localizedMessage,omitempty"`
+
+	// cause 为导致该错误的内部错误. 它只用于排查问题（例如记录到日志中），不会返回给客户端.
+	cause error
+
+	// stack 为调用 WithStack 时的调用栈.
+	stack []uintptr
 }
 
 // New 创建一个新的错误.
@@ -34,46 +53,128 @@ func New(code int, reason string, format string, args ...any) *ErrorX {
 	}
 }
 
+// Wrap 创建一个新的错误，并将 cause 作为该错误的内部原因.
+// 返回给客户端的只有 Code、Reason 和 Message，cause 只用于 errors.Is/errors.As 判断以及记录日志.
+func Wrap(cause error, code int, reason string, format string, args ...any) *ErrorX {
+	errx := New(code, reason, format, args...)
+	errx.cause = cause
+	return errx
+}
+
 // Error 实现 error 接口中的 `Error` 方法.
 func (err *ErrorX) Error() string {
 	return fmt.Sprintf("error: code = %d reason = %s message = %s metadata = %v", err.Code, err.Reason, err.Message, err.Metadata)
 }
 
-// WithMessage 设置错误的 Message 字段.
+// Unwrap 返回错误的内部原因，使 errors.Is 和 errors.As 可以匹配内部原因.
+func (err *ErrorX) Unwrap() error {
+	return err.cause
+}
+
+// WithCause 返回一个以 cause 作为内部原因的错误副本，例如：return errno.ErrDBRead.WithCause(err).
+func (err *ErrorX) WithCause(cause error) *ErrorX {
+	errx := err.Clone()
+	errx.cause = cause
+	return errx
+}
+
+// WithStack 返回一个记录了当前调用栈的错误副本，可以通过 StackTrace 获取调用栈.
+// 记录调用栈有一定的开销，建议只在需要定位错误发生位置时使用.
+func (err *ErrorX) WithStack() *ErrorX {
+	errx := err.Clone()
+	errx.stack = callers()
+	return errx
+}
+
+// StackTrace 返回 WithStack 记录的调用栈. 没有记录调用栈时返回空字符串.
+func (err *ErrorX) StackTrace() string {
+	if len(err.stack) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	frames := runtime.CallersFrames(err.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// callers 返回调用 WithStack 的位置开始的调用栈.
+func callers() []uintptr {
+	const depth = 32
+	pcs := make([]uintptr, depth)
+	// 跳过 runtime.Callers、callers 和 WithStack
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// Clone 返回错误的深拷贝，修改副本不会影响原错误.
+func (err *ErrorX) Clone() *ErrorX {
+	errx := *err
+	if err.Metadata != nil {
+		errx.Metadata = make(map[string]string, len(err.Metadata))
+		for k, v := range err.Metadata {
+			errx.Metadata[k] = v
+		}
+	}
+	cloneDetails(&errx, err)
+	return &errx
+}
+
+// WithMessage 返回一个设置了 Message 字段的错误副本.
 func (err *ErrorX) WithMessage(format string, args ...any) *ErrorX {
-	err.Message = fmt.Sprintf(format, args...)
-	return err
+	errx := err.Clone()
+	errx.Message = fmt.Sprintf(format, args...)
+	return errx
 }
 
-// WithMetadata 设置元数据.
+// WithMetadata 返回一个使用 md 作为元数据的错误副本. md 会被拷贝，之后修改 md 不会影响返回的错误.
 func (err *ErrorX) WithMetadata(md map[string]string) *ErrorX {
-	err.Metadata = md
-	return err
+	errx := *err
+	errx.Metadata = nil
+	return errx.KV(flatten(md)...)
 }
 
-// KV 使用 key-value 对设置元数据.
+// KV 返回一个使用 key-value 对追加了元数据的错误副本.
 func (err *ErrorX) KV(kvs ...string) *ErrorX {
-	if err.Metadata == nil {
-		err.Metadata = make(map[string]string) // 初始化元数据映射
+	errx := err.Clone()
+	if len(kvs) < 2 {
+		return errx
 	}
 
+	if errx.Metadata == nil {
+		errx.Metadata = make(map[string]string, len(kvs)/2) // 初始化元数据映射
+	}
 	for i := 0; i < len(kvs); i += 2 {
 		// kvs 必须是成对的
 		if i+1 < len(kvs) {
-			err.Metadata[kvs[i]] = kvs[i+1]
+			errx.Metadata[kvs[i]] = kvs[i+1]
 		}
 	}
-	return err
+	return errx
+}
+
+// flatten 将 md 转换为 key-value 对.
+func flatten(md map[string]string) []string {
+	kvs := make([]string, 0, len(md)*2)
+	for k, v := range md {
+		kvs = append(kvs, k, v)
+	}
+	return kvs
 }
 
 // GRPCStatus 返回 gRPC 状态表示.
 func (err *ErrorX) GRPCStatus() *status.Status {
-	details := errdetails.ErrorInfo{Reason: err.Reason, Metadata: err.Metadata}
-	s, _ := status.New(httpstatus.ToGRPCCode(err.Code), err.Message).WithDetails(&details)
+	s, _ := status.New(httpstatus.ToGRPCCode(err.Code), err.Message).WithDetails(err.details()...)
 	return s
 }
 
-// WithRequestID 设置请求 ID.
+// WithRequestID 返回一个在元数据中设置了请求 ID 的错误副本.
 func (err *ErrorX) WithRequestID(requestID string) *ErrorX {
 	return err.KV("X-Request-ID", requestID) // 设置请求 ID
 }
@@ -121,21 +222,19 @@ func FromError(err error) *ErrorX {
 	gs, ok := status.FromError(err)
 
 	// 如果 err 不能转换为 gRPC 错误（即不是 gRPC 的 status 错误），
-	// 则返回一个带有默认值的 ErrorX，表示是一个未知类型的错误.
+	// 则返回 ErrInternal，表示是一个未知类型的错误. 原始错误可能包含内部实现细节，
+	// 因此只作为内部原因保存，不作为返回给客户端的错误信息.
 	if !ok {
-		return New(ErrInternal.Code, ErrInternal.Reason, "%s", err.Error())
+		return ErrInternal.WithCause(err)
 	}
 
 	// 如果 err 是 gRPC 的错误类型，会成功返回一个 gRPC status 对象（gs）.
 	// 使用 gRPC 状态中的错误代码和消息创建一个 ErrorX.
 	ret := New(httpstatus.FromGRPCCode(gs.Code()), ErrInternal.Reason, "%s", gs.Message())
 
-	// 遍历 gRPC 错误详情中的所有附加信息（Details）.
+	// 遍历 gRPC 错误详情中的所有附加信息（Details），不支持的错误详情会被忽略.
 	for _, detail := range gs.Details() {
-		if typed, ok := detail.(*errdetails.ErrorInfo); ok {
-			ret.Reason = typed.Reason
-			return ret.WithMetadata(typed.Metadata)
-		}
+		ret.setDetail(detail)
 	}
 
 	return ret
@@ -2,7 +2,12 @@ package errorsx_test
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
@@ -30,12 +35,13 @@ func TestErrorX_WithMessage(t *testing.T) {
 	errx := errorsx.New(400, "BadRequest.InvalidInput", "Invalid input for field %s", "username")
 
 	// 更新错误的消息
-	errx.WithMessage("New error message: %s", "retry failed")
+	updated := errx.WithMessage("New error message: %s", "retry failed")
 
 	// 验证变更
-	assert.Equal(t, "New error message: retry failed", errx.Message)
-	assert.Equal(t, 400, errx.Code)                         // Code 不变
-	assert.Equal(t, "BadRequest.InvalidInput", errx.Reason) // Reason 不变
+	assert.Equal(t, "New error message: retry failed", updated.Message)
+	assert.Equal(t, 400, updated.Code)                                // Code 不变
+	assert.Equal(t, "BadRequest.InvalidInput", updated.Reason)        // Reason 不变
+	assert.Equal(t, "Invalid input for field username", errx.Message) // 原错误不变
 }
 
 func TestErrorX_WithMetadata(t *testing.T) {
@@ -43,19 +49,80 @@ func TestErrorX_WithMetadata(t *testing.T) {
 	errx := errorsx.New(400, "BadRequest.InvalidInput", "Invalid input")
 
 	// 添加元数据
-	errx.WithMetadata(map[string]string{
+	md := map[string]string{
 		"field": "username",
 		"type":  "empty",
-	})
+	}
+	withMD := errx.WithMetadata(md)
 
 	// 验证元数据
-	assert.Equal(t, "username", errx.Metadata["field"])
-	assert.Equal(t, "empty", errx.Metadata["type"])
+	assert.Equal(t, "username", withMD.Metadata["field"])
+	assert.Equal(t, "empty", withMD.Metadata["type"])
+	assert.Nil(t, errx.Metadata)
+
+	// 之后修改 md 不会影响错误
+	md["field"] = "password"
+	assert.Equal(t, "username", withMD.Metadata["field"])
 
 	// 动态添加更多元数据
-	errx.KV("user_id", "12345", "trace_id", "xyz-789")
-	assert.Equal(t, "12345", errx.Metadata["user_id"])
-	assert.Equal(t, "xyz-789", errx.Metadata["trace_id"])
+	withKV := withMD.KV("user_id", "12345", "trace_id", "xyz-789")
+	assert.Equal(t, "12345", withKV.Metadata["user_id"])
+	assert.Equal(t, "xyz-789", withKV.Metadata["trace_id"])
+	assert.Equal(t, "username", withKV.Metadata["field"])
+	assert.Len(t, withMD.Metadata, 2)
+}
+
+func TestErrorX_Clone(t *testing.T) {
+	cause := errors.New("cause")
+	errx := errorsx.New(500, "InternalError", "Internal error").KV("key", "value").WithCause(cause)
+
+	clone := errx.Clone()
+	assert.Equal(t, errx, clone)
+	assert.NotSame(t, errx, clone)
+	assert.ErrorIs(t, clone, cause)
+
+	// 修改副本的元数据不会影响原错误
+	clone.Metadata["key"] = "changed"
+	assert.Equal(t, "value", errx.Metadata["key"])
+}
+
+// TestErrorX_PredefinedImmutable 在并发场景下基于预定义错误构造新错误，验证预定义错误不会被修改.
+// 需要使用 go test -race 运行以检测数据竞争.
+func TestErrorX_PredefinedImmutable(t *testing.T) {
+	predefined := []*errorsx.ErrorX{
+		errorsx.OK,
+		errorsx.ErrInternal,
+		errorsx.ErrNotFound,
+		errorsx.ErrBind,
+		errorsx.ErrInvalidArgument,
+		errorsx.ErrUnauthenticated,
+		errorsx.ErrPermissionDenied,
+		errorsx.ErrOperationFailed,
+	}
+	snapshots := make([]errorsx.ErrorX, len(predefined))
+	for i, errx := range predefined {
+		snapshots[i] = *errx.Clone()
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for _, errx := range predefined {
+				id := fmt.Sprintf("request-%d", i)
+				derived := errx.WithRequestID(id).KV("goroutine", id).WithMessage("message %d", i).WithCause(io.EOF).WithStack()
+				assert.Equal(t, id, derived.Metadata["X-Request-ID"])
+				_ = errorsx.FromError(errx).WithMetadata(map[string]string{"key": id})
+				_ = errx.GRPCStatus()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	for i, errx := range predefined {
+		assert.Equal(t, snapshots[i], *errx, "predefined error %s was modified", errx.Reason)
+	}
 }
 
 func TestErrorX_Is(t *testing.T) {
@@ -77,9 +144,50 @@ func TestErrorX_FromError_WithPlainError(t *testing.T) {
 	errx := errorsx.FromError(plainErr)
 
 	// 检查转换后的 ErrorX
-	assert.Equal(t, errorsx.ErrInternal.Code, errx.Code)     // 默认 500
-	assert.Equal(t, errorsx.ErrInternal.Reason, errx.Reason) // 默认 ""
-	assert.Equal(t, "Something went wrong", errx.Message)    // 转换时保留原始错误消息
+	assert.Equal(t, errorsx.ErrInternal.Code, errx.Code)       // 默认 500
+	assert.Equal(t, errorsx.ErrInternal.Reason, errx.Reason)   // 默认 InternalError
+	assert.Equal(t, errorsx.ErrInternal.Message, errx.Message) // 原始错误消息不会暴露给客户端
+	assert.Equal(t, plainErr, errx.Unwrap())                   // 原始错误作为内部原因保留
+	assert.ErrorIs(t, errx, plainErr)
+	assert.Nil(t, errorsx.ErrInternal.Unwrap()) // 全局错误不受影响
+}
+
+func TestErrorX_WithCause(t *testing.T) {
+	base := errorsx.New(500, "InternalError.DBRead", "Database read failure.")
+	cause := fmt.Errorf("query users: %w", io.ErrUnexpectedEOF)
+
+	errx := base.WithCause(cause)
+
+	// 返回副本，不修改原错误
+	assert.Nil(t, base.Unwrap())
+	assert.Equal(t, cause, errx.Unwrap())
+
+	// errors.Is 既可以匹配错误类型，也可以匹配内部原因
+	assert.ErrorIs(t, errx, base)
+	assert.ErrorIs(t, errx, io.ErrUnexpectedEOF)
+	assert.ErrorIs(t, fmt.Errorf("wrapped: %w", errx), base)
+
+	// 内部原因不会出现在返回给客户端的信息中
+	assert.Equal(t, "Database read failure.", errx.Message)
+	assert.NotContains(t, errx.GRPCStatus().Message(), "query users")
+	assert.Equal(t, base.Error(), errx.Error())
+
+	// errors.As 可以获取内部原因中的错误
+	var pathErr *os.PathError
+	errx = errorsx.Wrap(&os.PathError{Op: "open", Path: "/etc/opsx", Err: os.ErrNotExist}, 500, "InternalError", "Internal server error.")
+	assert.True(t, errors.As(errx, &pathErr))
+	assert.Equal(t, "/etc/opsx", pathErr.Path)
+	assert.Equal(t, "InternalError", errx.Reason)
+}
+
+func TestErrorX_WithStack(t *testing.T) {
+	base := errorsx.New(500, "InternalError", "Internal server error.")
+	assert.Empty(t, base.StackTrace())
+
+	errx := base.WithStack()
+	assert.Empty(t, base.StackTrace())
+	assert.Contains(t, errx.StackTrace(), "errorsx_test.TestErrorX_WithStack")
+	assert.Contains(t, errx.StackTrace(), "errorsx_test.go:")
 }
 
 func TestErrorX_FromError_WithGRPCError(t *testing.T) {
@@ -118,3 +226,69 @@ func TestErrorX_FromError_WithGRPCErrorDetails(t *testing.T) {
 	assert.Equal(t, "name", errx.Metadata["field"])
 	assert.Equal(t, "required", errx.Metadata["type"])
 }
+
+func TestErrorX_Details_RoundTrip(t *testing.T) {
+	errx := errorsx.New(400, "InvalidArgument", "Invalid argument").
+		WithFieldViolations(errorsx.FieldViolation{Field: "username", Description: "must be 3 to 20 characters", Reason: "InvalidArgument.UsernameInvalid"}).
+		WithRetryDelay(1500*time.Millisecond).
+		WithLocalizedMessage("zh-CN", "参数校验失败。")
+
+	// 经过 gRPC 传输后，错误详情保持不变
+	got := errorsx.FromError(errx.GRPCStatus().Err())
+	assert.Equal(t, errx.Reason, got.Reason)
+	assert.Equal(t, errx.FieldViolations, got.FieldViolations)
+	assert.Equal(t, 1500*time.Millisecond, got.RetryDelay)
+	assert.Equal(t, errx.LocalizedMessage, got.LocalizedMessage)
+
+	// 没有错误详情时只附加 ErrorInfo
+	assert.Len(t, errorsx.ErrInternal.GRPCStatus().Details(), 1)
+
+	// 构造函数返回副本，不修改原错误
+	clone := errx.WithFieldViolations(errorsx.FieldViolation{Field: "password"})
+	assert.Len(t, errx.FieldViolations, 1)
+	assert.Len(t, clone.FieldViolations, 2)
+	clone.LocalizedMessage.Message = "changed"
+	assert.Equal(t, "参数校验失败。", errx.LocalizedMessage.Message)
+}
+
+func TestErrorX_Localize(t *testing.T) {
+	errorsx.RegisterLocalizedMessages("zh-CN", map[string]string{"Test.Localize": "中文错误"})
+	errorsx.RegisterLocalizedMessages("ja", map[string]string{"Test.Localize": "日本語のエラー"})
+	errx := errorsx.New(400, "Test.Localize", "English error")
+
+	got := errx.Localize("zh-CN,zh;q=0.9,en;q=0.8")
+	assert.Equal(t, &errorsx.LocalizedMessage{Locale: "zh-CN", Message: "中文错误"}, got.LocalizedMessage)
+	assert.Nil(t, errx.LocalizedMessage)
+
+	// 按语言优先级匹配
+	assert.Equal(t, "日本語のエラー", errx.Localize("fr;q=0.9, ja-JP;q=0.8, zh;q=0.1").LocalizedMessage.Message)
+
+	// 没有匹配的语言、没有对应的错误信息或者已经本地化时保持不变
+	assert.Same(t, errx, errx.Localize("fr"))
+	assert.Same(t, errx, errx.Localize(""))
+	assert.Nil(t, errorsx.ErrInternal.Localize("ja").LocalizedMessage)
+	assert.Same(t, got, got.Localize("ja"))
+}
+
+func TestRegister(t *testing.T) {
+	errx := errorsx.Register(404, "NotFound.TestRegister", "Test not found.")
+	assert.Equal(t, "NotFound.TestRegister", errx.Reason)
+
+	got, ok := errorsx.Lookup("NotFound.TestRegister")
+	assert.True(t, ok)
+	assert.Equal(t, errx, got)
+	assert.NotSame(t, errx, got)
+	assert.Contains(t, errorsx.Registered(), errx)
+
+	_, ok = errorsx.Lookup("NotFound.TestUnknown")
+	assert.False(t, ok)
+
+	// 重复的错误原因、与同一分类的 HTTP 状态码不一致以及空的错误原因均会 panic
+	assert.PanicsWithValue(t, `errorsx: reason "NotFound.TestRegister" is already registered`, func() {
+		errorsx.Register(404, "NotFound.TestRegister", "Duplicated.")
+	})
+	assert.PanicsWithValue(t, `errorsx: reason "NotFound.TestMismatch" has code 400, but category "NotFound" uses code 404`, func() {
+		errorsx.Register(400, "NotFound.TestMismatch", "Mismatched.")
+	})
+	assert.Panics(t, func() { errorsx.Register(400, "", "Empty.") })
+}
@@ -0,0 +1,75 @@
+package errorsx
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// registry 保存通过 Register 注册的所有错误.
+var registry = struct {
+	sync.RWMutex
+	// errors 的键为错误原因.
+	errors map[string]*ErrorX
+	// categories 的键为错误原因的分类（第一个 . 之前的部分，例如 NotFound），值为该分类的 HTTP 状态码.
+	categories map[string]int
+}{errors: make(map[string]*ErrorX), categories: make(map[string]int)}
+
+// Register 创建并注册一个错误，通常用于定义包级别的错误变量，例如：
+//
+//	var ErrUserNotFound = errorsx.Register(http.StatusNotFound, "NotFound.UserNotFound", "User not found.")
+//
+// 每个错误原因只能注册一次，同一分类（例如 NotFound 和 NotFound.UserNotFound）的错误必须使用相同的 HTTP 状态码，
+// 否则 Register 会 panic，从而在程序启动时发现重复或不一致的错误定义.
+func Register(code int, reason string, message string) *ErrorX {
+	if reason == "" {
+		panic("errorsx: reason must not be empty")
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.errors[reason]; ok {
+		panic(fmt.Sprintf("errorsx: reason %q is already registered", reason))
+	}
+	category, _, _ := strings.Cut(reason, ".")
+	if categoryCode, ok := registry.categories[category]; ok && categoryCode != code {
+		panic(fmt.Sprintf("errorsx: reason %q has code %d, but category %q uses code %d", reason, code, category, categoryCode))
+	}
+
+	errx := &ErrorX{Code: code, Reason: reason, Message: message}
+	registry.errors[reason] = errx
+	registry.categories[category] = code
+	return errx
+}
+
+// Lookup 返回错误原因为 reason 的已注册错误的副本.
+func Lookup(reason string) (*ErrorX, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	errx, ok := registry.errors[reason]
+	if !ok {
+		return nil, false
+	}
+	return errx.Clone(), true
+}
+
+// Registered 返回所有已注册错误的副本，按照 HTTP 状态码和错误原因排序.
+func Registered() []*ErrorX {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	errs := make([]*ErrorX, 0, len(registry.errors))
+	for _, errx := range registry.errors {
+		errs = append(errs, errx.Clone())
+	}
+	slices.SortFunc(errs, func(a, b *ErrorX) int {
+		if a.Code != b.Code {
+			return a.Code - b.Code
+		}
+		return strings.Compare(a.Reason, b.Reason)
+	})
+	return errs
+}
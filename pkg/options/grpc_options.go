@@ -1,6 +1,8 @@
 package options
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -11,23 +13,81 @@ var _ IOptions = (*GRPCOptions)(nil)
 // GRPCOptions are for creating an unauthenticated, unauthorized, insecure port.
 // No one should be using these anymore.
 type GRPCOptions struct {
-	// Network with server network.
+	// Network with server network, one of tcp, tcp4, tcp6 or unix.
 	Network string `json:"network" mapstructure:"network"`
 
-	// Address with server address.
+	// Address with server address. It is the socket file path when Network is unix.
 	Addr string `json:"addr" mapstructure:"addr"`
 
-	// Timeout with server timeout. Used by grpc client side.
+	// Timeout is the default timeout of a single call made by gRPC clients. The server does not use it.
 	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// ConnectionTimeout is the deadline for the server to establish new connections, including the
+	// TLS handshake. It does not limit the duration of requests. 0 means the gRPC default (120s).
+	ConnectionTimeout time.Duration `json:"connection-timeout" mapstructure:"connection-timeout"`
+
+	// MaxRecvMsgSize is the max message size in bytes the server can receive.
+	MaxRecvMsgSize int `json:"max-recv-msg-size" mapstructure:"max-recv-msg-size"`
+
+	// MaxSendMsgSize is the max message size in bytes the server can send.
+	MaxSendMsgSize int `json:"max-send-msg-size" mapstructure:"max-send-msg-size"`
+
+	// MaxConcurrentStreams is the max number of concurrent streams per connection. 0 means no limit.
+	MaxConcurrentStreams uint32 `json:"max-concurrent-streams" mapstructure:"max-concurrent-streams"`
+
+	// MaxHeaderListSize is the max size in bytes of the header list the server accepts.
+	// 0 means the gRPC default (16MiB).
+	MaxHeaderListSize uint32 `json:"max-header-list-size" mapstructure:"max-header-list-size"`
+
+	// Keepalive with keepalive configuration of the gRPC server.
+	Keepalive GRPCKeepaliveOptions `json:"keepalive" mapstructure:"keepalive"`
+
+	// TLSOptions with TLS configuration of the gRPC server.
+	TLSOptions *TLSOptions `json:"tls" mapstructure:"tls"`
+}
+
+// GRPCKeepaliveOptions contains keepalive configuration items of the gRPC server.
+type GRPCKeepaliveOptions struct {
+	// Time is the duration after which the server pings an idle client to see if the transport is still alive.
+	Time time.Duration `json:"time" mapstructure:"time"`
+
+	// Timeout is the duration the server waits for a ping ack before closing the connection.
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// MinTime is the minimum duration clients should wait before sending a keepalive ping.
+	// Clients pinging more frequently are disconnected.
+	MinTime time.Duration `json:"min-time" mapstructure:"min-time"`
+
+	// PermitWithoutStream allows clients to send keepalive pings when there are no active streams.
+	PermitWithoutStream bool `json:"permit-without-stream" mapstructure:"permit-without-stream"`
+
+	// MaxConnectionIdle is the duration after which an idle connection is closed. 0 means infinity.
+	MaxConnectionIdle time.Duration `json:"max-connection-idle" mapstructure:"max-connection-idle"`
+
+	// MaxConnectionAge is the maximum duration a connection may exist before it is gracefully closed.
+	// 0 means infinity.
+	MaxConnectionAge time.Duration `json:"max-connection-age" mapstructure:"max-connection-age"`
+
+	// MaxConnectionAgeGrace is the additional period after MaxConnectionAge after which the connection is
+	// forcibly closed. 0 means infinity.
+	MaxConnectionAgeGrace time.Duration `json:"max-connection-age-grace" mapstructure:"max-connection-age-grace"`
 }
 
 // NewGRPCOptions is for creating an unauthenticated, unauthorized, insecure port.
 // No one should be using these anymore.
 func NewGRPCOptions() *GRPCOptions {
 	return &GRPCOptions{
-		Network: "tcp",
-		Addr:    "0.0.0.0:39090",
-		Timeout: 30 * time.Second,
+		Network:        "tcp",
+		Addr:           "0.0.0.0:39090",
+		Timeout:        30 * time.Second,
+		MaxRecvMsgSize: 4 * MiB,
+		MaxSendMsgSize: math.MaxInt32,
+		Keepalive: GRPCKeepaliveOptions{
+			Time:    2 * time.Hour,
+			Timeout: 20 * time.Second,
+			MinTime: 5 * time.Minute,
+		},
+		TLSOptions: NewTLSOptions(),
 	}
 }
 
@@ -36,17 +96,60 @@ func NewGRPCOptions() *GRPCOptions {
 func (o *GRPCOptions) Validate() []error {
 	var errors []error
 
-	if err := ValidateAddress(o.Addr); err != nil {
+	if err := ValidateNetworkAddress(o.Network, o.Addr); err != nil {
 		errors = append(errors, err)
 	}
 
+	if o.Timeout < 0 || o.ConnectionTimeout < 0 {
+		errors = append(errors, fmt.Errorf("grpc timeout and connection-timeout must not be negative"))
+	}
+
+	if o.MaxRecvMsgSize <= 0 || o.MaxSendMsgSize <= 0 {
+		errors = append(errors, fmt.Errorf("grpc max-recv-msg-size and max-send-msg-size must be greater than 0"))
+	}
+
+	for _, d := range []time.Duration{
+		o.Keepalive.Time, o.Keepalive.Timeout, o.Keepalive.MinTime,
+		o.Keepalive.MaxConnectionIdle, o.Keepalive.MaxConnectionAge, o.Keepalive.MaxConnectionAgeGrace,
+	} {
+		if d < 0 {
+			errors = append(errors, fmt.Errorf("grpc keepalive durations must not be negative"))
+			break
+		}
+	}
+
+	errors = append(errors, o.TLSOptions.Validate()...)
+
 	return errors
 }
 
 // AddFlags adds flags related to features for a specific api server to the
 // specified FlagSet.
 func (o *GRPCOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
-	fs.StringVar(&o.Network, "grpc.network", o.Network, "Specify the network for the gRPC server.")
-	fs.StringVar(&o.Addr, "grpc.addr", o.Addr, "Specify the gRPC server bind address and port.")
-	fs.DurationVar(&o.Timeout, "grpc.timeout", o.Timeout, "Timeout for server connections.")
+	fs.StringVar(&o.Network, "grpc.network", o.Network, "Specify the network for the gRPC server, available options: tcp, tcp4, tcp6, unix.")
+	fs.StringVar(&o.Addr, "grpc.addr", o.Addr, "Specify the gRPC server bind address and port, or the socket file path when network is unix.")
+	fs.DurationVar(&o.Timeout, "grpc.timeout", o.Timeout, "Default timeout of a single call made by gRPC clients. Not used by the server.")
+	fs.DurationVar(&o.ConnectionTimeout, "grpc.connection-timeout", o.ConnectionTimeout,
+		"Timeout for the gRPC server to establish new connections, including the TLS handshake. 0 means the gRPC default.")
+	fs.IntVar(&o.MaxRecvMsgSize, "grpc.max-recv-msg-size", o.MaxRecvMsgSize, "Max message size in bytes the gRPC server can receive.")
+	fs.IntVar(&o.MaxSendMsgSize, "grpc.max-send-msg-size", o.MaxSendMsgSize, "Max message size in bytes the gRPC server can send.")
+	fs.Uint32Var(&o.MaxConcurrentStreams, "grpc.max-concurrent-streams", o.MaxConcurrentStreams,
+		"Max number of concurrent streams per connection. 0 means no limit.")
+	fs.Uint32Var(&o.MaxHeaderListSize, "grpc.max-header-list-size", o.MaxHeaderListSize,
+		"Max size in bytes of the header list the gRPC server accepts. 0 means the gRPC default.")
+	fs.DurationVar(&o.Keepalive.Time, "grpc.keepalive.time", o.Keepalive.Time,
+		"Duration after which the server pings an idle client to see if the transport is still alive.")
+	fs.DurationVar(&o.Keepalive.Timeout, "grpc.keepalive.timeout", o.Keepalive.Timeout,
+		"Duration the server waits for a keepalive ping ack before closing the connection.")
+	fs.DurationVar(&o.Keepalive.MinTime, "grpc.keepalive.min-time", o.Keepalive.MinTime,
+		"Minimum duration clients should wait before sending a keepalive ping.")
+	fs.BoolVar(&o.Keepalive.PermitWithoutStream, "grpc.keepalive.permit-without-stream", o.Keepalive.PermitWithoutStream,
+		"Allow clients to send keepalive pings when there are no active streams.")
+	fs.DurationVar(&o.Keepalive.MaxConnectionIdle, "grpc.keepalive.max-connection-idle", o.Keepalive.MaxConnectionIdle,
+		"Duration after which an idle connection is closed. 0 means infinity.")
+	fs.DurationVar(&o.Keepalive.MaxConnectionAge, "grpc.keepalive.max-connection-age", o.Keepalive.MaxConnectionAge,
+		"Maximum duration a connection may exist before it is gracefully closed. 0 means infinity.")
+	fs.DurationVar(&o.Keepalive.MaxConnectionAgeGrace, "grpc.keepalive.max-connection-age-grace", o.Keepalive.MaxConnectionAgeGrace,
+		"Additional period after max-connection-age after which the connection is forcibly closed. 0 means infinity.")
+	o.TLSOptions.AddFlags(fs, "grpc")
 }
@@ -44,6 +44,22 @@ func ValidateAddress(addr string) error {
 	return nil
 }
 
+// ValidateNetworkAddress validates addr for the given network. For unix networks addr is the
+// socket file path, for tcp, tcp4 and tcp6 networks it is validated by ValidateAddress.
+func ValidateNetworkAddress(network, addr string) error {
+	switch network {
+	case "unix":
+		if addr == "" {
+			return fmt.Errorf("socket file path must be specified when network is unix")
+		}
+		return nil
+	case "tcp", "tcp4", "tcp6":
+		return ValidateAddress(addr)
+	default:
+		return fmt.Errorf("unsupported network %q: must be one of tcp, tcp4, tcp6, unix", network)
+	}
+}
+
 // CreateListener create net listener by given address and returns it and port.
 func CreateListener(addr string) (net.Listener, int, error) {
 	network := "tcp"
@@ -1,6 +1,7 @@
 package options
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -10,22 +11,48 @@ var _ IOptions = (*HTTPOptions)(nil)
 
 // HTTPOptions contains configuration items related to HTTP server startup.
 type HTTPOptions struct {
-	// Network with server network.
+	// Network with server network, one of tcp, tcp4, tcp6 or unix.
 	Network string `json:"network" mapstructure:"network"`
 
-	// Address with server address.
+	// Address with server address. It is the socket file path when Network is unix.
 	Addr string `json:"addr" mapstructure:"addr"`
 
-	// Timeout with server timeout. Used by http client side.
+	// Timeout is the default for ReadTimeout and WriteTimeout when they are not set.
 	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// ReadTimeout is the maximum duration for reading the entire request, including the body.
+	// 0 means Timeout is used.
+	ReadTimeout time.Duration `json:"read-timeout" mapstructure:"read-timeout"`
+
+	// ReadHeaderTimeout is the amount of time allowed to read request headers.
+	// 0 means ReadTimeout is used.
+	ReadHeaderTimeout time.Duration `json:"read-header-timeout" mapstructure:"read-header-timeout"`
+
+	// WriteTimeout is the maximum duration before timing out writes of the response.
+	// 0 means Timeout is used.
+	WriteTimeout time.Duration `json:"write-timeout" mapstructure:"write-timeout"`
+
+	// IdleTimeout is the maximum amount of time to wait for the next request when keep-alives are enabled.
+	// 0 means ReadTimeout is used.
+	IdleTimeout time.Duration `json:"idle-timeout" mapstructure:"idle-timeout"`
+
+	// MaxHeaderBytes is the maximum number of bytes the server reads parsing the request headers.
+	MaxHeaderBytes int `json:"max-header-bytes" mapstructure:"max-header-bytes"`
+
+	// TLSOptions with TLS configuration of the HTTP server.
+	TLSOptions *TLSOptions `json:"tls" mapstructure:"tls"`
 }
 
 // NewHTTPOptions creates a HTTPOptions object with default parameters.
 func NewHTTPOptions() *HTTPOptions {
 	return &HTTPOptions{
-		Network: "tcp",
-		Addr:    "0.0.0.0:38443",
-		Timeout: 30 * time.Second,
+		Network:           "tcp",
+		Addr:              "0.0.0.0:38443",
+		Timeout:           30 * time.Second,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    1 * MiB,
+		TLSOptions:        NewTLSOptions(),
 	}
 }
 
@@ -38,19 +65,59 @@ func (o *HTTPOptions) Validate() []error {
 
 	errors := []error{}
 
-	if err := ValidateAddress(o.Addr); err != nil {
+	if err := ValidateNetworkAddress(o.Network, o.Addr); err != nil {
 		errors = append(errors, err)
 	}
 
+	for _, d := range []time.Duration{o.Timeout, o.ReadTimeout, o.ReadHeaderTimeout, o.WriteTimeout, o.IdleTimeout} {
+		if d < 0 {
+			errors = append(errors, fmt.Errorf("http timeouts must not be negative"))
+			break
+		}
+	}
+
+	if o.MaxHeaderBytes < 0 {
+		errors = append(errors, fmt.Errorf("http max-header-bytes must not be negative"))
+	}
+
+	errors = append(errors, o.TLSOptions.Validate()...)
+
 	return errors
 }
 
 // AddFlags adds flags related to HTTPS server for a specific APIServer to the
 // specified FlagSet.
 func (o *HTTPOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
-	fs.StringVar(&o.Network, "http.network", o.Network, "Specify the network for the HTTP server.")
-	fs.StringVar(&o.Addr, "http.addr", o.Addr, "Specify the HTTP server bind address and port.")
-	fs.DurationVar(&o.Timeout, "http.timeout", o.Timeout, "Timeout for server connections.")
+	fs.StringVar(&o.Network, "http.network", o.Network, "Specify the network for the HTTP server, available options: tcp, tcp4, tcp6, unix.")
+	fs.StringVar(&o.Addr, "http.addr", o.Addr, "Specify the HTTP server bind address and port, or the socket file path when network is unix.")
+	fs.DurationVar(&o.Timeout, "http.timeout", o.Timeout, "Default for http.read-timeout and http.write-timeout when they are not set.")
+	fs.DurationVar(&o.ReadTimeout, "http.read-timeout", o.ReadTimeout,
+		"Maximum duration for reading the entire request, including the body. 0 means http.timeout is used.")
+	fs.DurationVar(&o.ReadHeaderTimeout, "http.read-header-timeout", o.ReadHeaderTimeout,
+		"Amount of time allowed to read request headers. 0 means the read timeout is used.")
+	fs.DurationVar(&o.WriteTimeout, "http.write-timeout", o.WriteTimeout,
+		"Maximum duration before timing out writes of the response. 0 means http.timeout is used.")
+	fs.DurationVar(&o.IdleTimeout, "http.idle-timeout", o.IdleTimeout,
+		"Maximum amount of time to wait for the next request when keep-alives are enabled. 0 means the read timeout is used.")
+	fs.IntVar(&o.MaxHeaderBytes, "http.max-header-bytes", o.MaxHeaderBytes,
+		"Maximum number of bytes the server reads parsing the request headers.")
+	o.TLSOptions.AddFlags(fs, "http")
+}
+
+// EffectiveReadTimeout returns ReadTimeout, or Timeout if ReadTimeout is not set.
+func (o *HTTPOptions) EffectiveReadTimeout() time.Duration {
+	if o.ReadTimeout > 0 {
+		return o.ReadTimeout
+	}
+	return o.Timeout
+}
+
+// EffectiveWriteTimeout returns WriteTimeout, or Timeout if WriteTimeout is not set.
+func (o *HTTPOptions) EffectiveWriteTimeout() time.Duration {
+	if o.WriteTimeout > 0 {
+		return o.WriteTimeout
+	}
+	return o.Timeout
 }
 
 // Complete fills in any fields not set that are required to have valid data.
@@ -0,0 +1,44 @@
+package options_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ra1n6ow/opsx/pkg/options"
+)
+
+func TestHTTPOptionsValidate(t *testing.T) {
+	opts := options.NewHTTPOptions()
+	assert.Empty(t, opts.Validate())
+
+	opts.Network = "unix"
+	opts.Addr = "/tmp/opsx.sock"
+	assert.Empty(t, opts.Validate())
+
+	opts.Network = "udp"
+	opts.IdleTimeout = -time.Second
+	assert.Len(t, opts.Validate(), 2)
+}
+
+func TestHTTPOptionsEffectiveTimeouts(t *testing.T) {
+	opts := options.NewHTTPOptions()
+	assert.Equal(t, opts.Timeout, opts.EffectiveReadTimeout())
+	assert.Equal(t, opts.Timeout, opts.EffectiveWriteTimeout())
+
+	opts.ReadTimeout, opts.WriteTimeout = time.Second, time.Minute
+	assert.Equal(t, time.Second, opts.EffectiveReadTimeout())
+	assert.Equal(t, time.Minute, opts.EffectiveWriteTimeout())
+}
+
+func TestGRPCOptionsValidate(t *testing.T) {
+	opts := options.NewGRPCOptions()
+	assert.Empty(t, opts.Validate())
+
+	opts.Network = "unix"
+	opts.Addr = ""
+	opts.MaxRecvMsgSize = 0
+	opts.Keepalive.Time = -time.Second
+	assert.Len(t, opts.Validate(), 3)
+}
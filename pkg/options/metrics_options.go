@@ -0,0 +1,61 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+var _ IOptions = (*MetricsOptions)(nil)
+
+// MetricsOptions contains configuration items related to Prometheus metrics.
+type MetricsOptions struct {
+	// Enabled specifies whether metrics are collected and exposed.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Path is the HTTP path the metrics are served on.
+	Path string `json:"path" mapstructure:"path"`
+
+	// Addr is the address of a dedicated metrics HTTP server. Metrics are only served on
+	// this server and never on the HTTP server of the application. If empty, metrics are disabled.
+	Addr string `json:"addr" mapstructure:"addr"`
+}
+
+// NewMetricsOptions creates a MetricsOptions object with default parameters.
+func NewMetricsOptions() *MetricsOptions {
+	return &MetricsOptions{
+		Enabled: true,
+		Path:    "/metrics",
+	}
+}
+
+// Validate is used to parse and validate the parameters entered by the user at
+// the command line when the program starts.
+func (o *MetricsOptions) Validate() []error {
+	if o == nil || !o.Enabled {
+		return nil
+	}
+
+	errs := []error{}
+
+	if !strings.HasPrefix(o.Path, "/") {
+		errs = append(errs, fmt.Errorf("metrics path %q must start with '/'", o.Path))
+	}
+
+	if o.Addr != "" {
+		if err := ValidateAddress(o.Addr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to metrics to the specified FlagSet.
+func (o *MetricsOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	fs.BoolVar(&o.Enabled, "metrics.enabled", o.Enabled, "Enable collecting and exposing Prometheus metrics.")
+	fs.StringVar(&o.Path, "metrics.path", o.Path, "The HTTP path the Prometheus metrics are served on.")
+	fs.StringVar(&o.Addr, "metrics.addr", o.Addr, "The address of the dedicated metrics HTTP server, the only place metrics are served. "+
+		"If empty, metrics are disabled.")
+}
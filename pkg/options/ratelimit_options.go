@@ -0,0 +1,57 @@
+package options
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+var _ IOptions = (*RateLimitOptions)(nil)
+
+// RateLimitOptions contains configuration items related to server side rate limiting.
+type RateLimitOptions struct {
+	// Enabled specifies whether requests are rate limited.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// QPS is the number of requests allowed per second.
+	QPS float64 `json:"qps" mapstructure:"qps"`
+
+	// Burst is the maximum number of requests allowed to exceed QPS at once.
+	Burst int `json:"burst" mapstructure:"burst"`
+}
+
+// NewRateLimitOptions creates a RateLimitOptions object with default parameters.
+func NewRateLimitOptions() *RateLimitOptions {
+	return &RateLimitOptions{
+		Enabled: false,
+		QPS:     100,
+		Burst:   200,
+	}
+}
+
+// Validate is used to parse and validate the parameters entered by the user at
+// the command line when the program starts.
+func (o *RateLimitOptions) Validate() []error {
+	if o == nil || !o.Enabled {
+		return nil
+	}
+
+	errs := []error{}
+
+	if o.QPS <= 0 {
+		errs = append(errs, fmt.Errorf("rate limit qps %v must be greater than 0", o.QPS))
+	}
+
+	if o.Burst <= 0 {
+		errs = append(errs, fmt.Errorf("rate limit burst %d must be greater than 0", o.Burst))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to rate limiting to the specified FlagSet.
+func (o *RateLimitOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	fs.BoolVar(&o.Enabled, "rate-limit.enabled", o.Enabled, "Enable server side rate limiting.")
+	fs.Float64Var(&o.QPS, "rate-limit.qps", o.QPS, "Number of requests allowed per second.")
+	fs.IntVar(&o.Burst, "rate-limit.burst", o.Burst, "Maximum number of requests allowed to exceed qps at once.")
+}
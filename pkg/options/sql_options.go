@@ -0,0 +1,147 @@
+package options
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/spf13/pflag"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var _ IOptions = (*SQLOptions)(nil)
+
+// Define supported sql drivers.
+const (
+	SQLiteDriver = "sqlite"
+	MySQLDriver  = "mysql"
+)
+
+var availableSQLDrivers = sets.New(SQLiteDriver, MySQLDriver)
+
+// SQLOptions defines options for sql database.
+type SQLOptions struct {
+	// Driver with database driver, available options: sqlite, mysql.
+	Driver string `json:"driver" mapstructure:"driver"`
+
+	// DSN with data source name. For sqlite it is the database file path (or `:memory:`),
+	// for mysql it is in the form of `user:password@tcp(host:port)/dbname?params`.
+	DSN string `json:"-" mapstructure:"dsn"`
+
+	// MaxIdleConnections with maximum idle connections allowed to connect to database.
+	MaxIdleConnections int `json:"max-idle-connections,omitempty" mapstructure:"max-idle-connections"`
+
+	// MaxOpenConnections with maximum open connections allowed to connect to database.
+	MaxOpenConnections int `json:"max-open-connections,omitempty" mapstructure:"max-open-connections"`
+
+	// MaxConnectionLifeTime with maximum connection life time allowed to connect to database.
+	MaxConnectionLifeTime time.Duration `json:"max-connection-life-time,omitempty" mapstructure:"max-connection-life-time"`
+
+	// LogLevel with gorm log level, 1 = silent, 2 = error, 3 = warn, 4 = info.
+	LogLevel int `json:"log-level" mapstructure:"log-level"`
+}
+
+// NewSQLOptions create a `zero` value instance.
+func NewSQLOptions() *SQLOptions {
+	return &SQLOptions{
+		Driver:                SQLiteDriver,
+		DSN:                   defaultSQLiteDSN(),
+		MaxIdleConnections:    100,
+		MaxOpenConnections:    100,
+		MaxConnectionLifeTime: 10 * time.Second,
+		LogLevel:              1, // Silent
+	}
+}
+
+// defaultSQLiteDSN returns the default sqlite database file path, `$HOME/.opsx/opsx.db`,
+// or `/var/lib/opsx/opsx.db` if the home directory is unknown. An absolute path is used so that
+// the database file does not depend on the directory the server is started from.
+func defaultSQLiteDSN() string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return "/var/lib/opsx/opsx.db"
+	}
+	return filepath.Join(home, ".opsx", "opsx.db")
+}
+
+// Validate verifies flags passed to SQLOptions.
+func (o *SQLOptions) Validate() []error {
+	if o == nil {
+		return nil
+	}
+
+	errs := []error{}
+
+	if !availableSQLDrivers.Has(o.Driver) {
+		errs = append(errs, fmt.Errorf("invalid sql driver %q: must be one of %v", o.Driver, sets.List(availableSQLDrivers)))
+	}
+
+	if o.DSN == "" {
+		errs = append(errs, fmt.Errorf("sql dsn can not be empty"))
+	}
+
+	return errs
+}
+
+// AddFlags adds command line flags for the options.
+func (o *SQLOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	fs.StringVar(&o.Driver, "sql.driver", o.Driver, fmt.Sprintf("SQL database driver, available options: %v.", sets.List(availableSQLDrivers)))
+	fs.StringVar(&o.DSN, "sql.dsn", o.DSN, "SQL data source name, e.g. `/var/lib/opsx/opsx.db` for sqlite or `user:pass@tcp(127.0.0.1:3306)/opsx` for mysql.")
+	fs.IntVar(&o.MaxIdleConnections, "sql.max-idle-connections", o.MaxIdleConnections, "Maximum idle connections allowed to connect to database.")
+	fs.IntVar(&o.MaxOpenConnections, "sql.max-open-connections", o.MaxOpenConnections, "Maximum open connections allowed to connect to database.")
+	fs.DurationVar(&o.MaxConnectionLifeTime, "sql.max-connection-life-time", o.MaxConnectionLifeTime, "Maximum connection life time allowed to connect to database.")
+	fs.IntVar(&o.LogLevel, "sql.log-level", o.LogLevel, "Specify gorm log level.")
+}
+
+// Dialector returns the gorm dialector of the configured driver.
+func (o *SQLOptions) Dialector() gorm.Dialector {
+	if o.Driver == MySQLDriver {
+		return mysql.Open(o.DSN)
+	}
+	return sqlite.Open(o.DSN)
+}
+
+// NewDB create sql store with the given config.
+func (o *SQLOptions) NewDB() (*gorm.DB, error) {
+	// Create the parent directory of the sqlite database file, e.g. `$HOME/.opsx`.
+	if o.Driver != MySQLDriver && o.DSN != ":memory:" && !strings.HasPrefix(o.DSN, "file:") {
+		if err := os.MkdirAll(filepath.Dir(o.DSN), 0o700); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := gorm.Open(o.Dialector(), &gorm.Config{
+		// PrepareStmt executes the given query in cached statement.
+		// This can improve performance.
+		PrepareStmt: true,
+		// TranslateError translates dialect specific errors into gorm errors,
+		// e.g. gorm.ErrDuplicatedKey.
+		TranslateError: true,
+		Logger:         logger.Default.LogMode(logger.LogLevel(o.LogLevel)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	// SetMaxOpenConns sets the maximum number of open connections to the database.
+	sqlDB.SetMaxOpenConns(o.MaxOpenConnections)
+
+	// SetConnMaxLifetime sets the maximum amount of time a connection may be reused.
+	sqlDB.SetConnMaxLifetime(o.MaxConnectionLifeTime)
+
+	// SetMaxIdleConns sets the maximum number of connections in the idle connection pool.
+	sqlDB.SetMaxIdleConns(o.MaxIdleConnections)
+
+	return db, nil
+}
@@ -0,0 +1,34 @@
+package options_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/pkg/options"
+)
+
+func TestSQLOptionsDefaultDSN(t *testing.T) {
+	opts := options.NewSQLOptions()
+	assert.True(t, filepath.IsAbs(opts.DSN), "default sqlite dsn %q should be an absolute path", opts.DSN)
+	assert.Empty(t, opts.Validate())
+}
+
+func TestSQLOptionsNewDBCreatesDirectory(t *testing.T) {
+	opts := options.NewSQLOptions()
+	opts.DSN = filepath.Join(t.TempDir(), "data", "opsx.db")
+
+	db, err := opts.NewDB()
+	require.NoError(t, err)
+	require.NoError(t, db.Exec("CREATE TABLE t (id INTEGER)").Error)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+
+	_, err = os.Stat(opts.DSN)
+	assert.NoError(t, err)
+}
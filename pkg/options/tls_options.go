@@ -0,0 +1,208 @@
+package options
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var _ IOptions = (*TLSOptions)(nil)
+
+// Supported client authentication modes.
+const (
+	ClientAuthNone             = "none"
+	ClientAuthRequest          = "request"
+	ClientAuthRequireAny       = "require-any"
+	ClientAuthVerifyIfGiven    = "verify-if-given"
+	ClientAuthRequireAndVerify = "require-and-verify"
+)
+
+// clientAuthTypes maps the client authentication modes to tls.ClientAuthType.
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	ClientAuthNone:             tls.NoClientCert,
+	ClientAuthRequest:          tls.RequestClientCert,
+	ClientAuthRequireAny:       tls.RequireAnyClientCert,
+	ClientAuthVerifyIfGiven:    tls.VerifyClientCertIfGiven,
+	ClientAuthRequireAndVerify: tls.RequireAndVerifyClientCert,
+}
+
+// TLSOptions contains configuration items related to TLS and mutual TLS.
+//
+// On the server side, Cert/Key is the serving certificate and CACert is used to
+// verify client certificates according to ClientAuth. On the client side (for
+// example the gateway dialing the gRPC backend), CACert is used to verify the
+// server and Cert/Key is presented as the client certificate when set.
+type TLSOptions struct {
+	// UseTLS specifies whether TLS is enabled.
+	UseTLS bool `json:"use-tls" mapstructure:"use-tls"`
+
+	// Cert is the path of the PEM encoded certificate file.
+	Cert string `json:"cert" mapstructure:"cert"`
+
+	// Key is the path of the PEM encoded private key file.
+	Key string `json:"key" mapstructure:"key"`
+
+	// CACert is the path of the PEM encoded CA certificate file.
+	CACert string `json:"ca-cert" mapstructure:"ca-cert"`
+
+	// ClientAuth is the client authentication mode of the server.
+	ClientAuth string `json:"client-auth" mapstructure:"client-auth"`
+
+	// ServerName is used by clients to verify the hostname of the server certificate.
+	// If empty, the host of the dialed address is used.
+	ServerName string `json:"server-name" mapstructure:"server-name"`
+}
+
+// NewTLSOptions creates a TLSOptions object with default parameters.
+func NewTLSOptions() *TLSOptions {
+	return &TLSOptions{
+		UseTLS:     false,
+		ClientAuth: ClientAuthNone,
+	}
+}
+
+// Validate is used to parse and validate the parameters entered by the user at
+// the command line when the program starts.
+func (o *TLSOptions) Validate() []error {
+	if o == nil || !o.UseTLS {
+		return nil
+	}
+
+	errs := []error{}
+
+	if o.Cert == "" || o.Key == "" {
+		errs = append(errs, fmt.Errorf("both cert and key must be specified when TLS is enabled"))
+	}
+
+	authType, ok := clientAuthTypes[o.ClientAuth]
+	if !ok {
+		errs = append(errs, fmt.Errorf("invalid client auth mode %q: must be one of %v", o.ClientAuth, sets.List(sets.KeySet(clientAuthTypes))))
+	}
+	if ok && (authType == tls.VerifyClientCertIfGiven || authType == tls.RequireAndVerifyClientCert) && o.CACert == "" {
+		errs = append(errs, fmt.Errorf("ca-cert must be specified when client auth mode is %q", o.ClientAuth))
+	}
+
+	for _, file := range []string{o.Cert, o.Key, o.CACert} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			errs = append(errs, fmt.Errorf("unable to read TLS file: %w", err))
+		}
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to TLS to the specified FlagSet.
+func (o *TLSOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	prefix := join(prefixes...)
+
+	fs.BoolVar(&o.UseTLS, prefix+"tls.use-tls", o.UseTLS, "Use TLS transport.")
+	fs.StringVar(&o.Cert, prefix+"tls.cert", o.Cert, "Path of the PEM encoded certificate file.")
+	fs.StringVar(&o.Key, prefix+"tls.key", o.Key, "Path of the PEM encoded private key file.")
+	fs.StringVar(&o.CACert, prefix+"tls.ca-cert", o.CACert, "Path of the PEM encoded CA certificate file. "+
+		"Used by the server to verify client certificates and by clients to verify the server.")
+	fs.StringVar(&o.ClientAuth, prefix+"tls.client-auth", o.ClientAuth, fmt.Sprintf("Client authentication mode of the server, "+
+		"available options: %v", sets.List(sets.KeySet(clientAuthTypes))))
+	fs.StringVar(&o.ServerName, prefix+"tls.server-name", o.ServerName, "Server name used by clients to verify the server certificate.")
+}
+
+// ServerTLSConfig returns the tls.Config used by servers. It returns nil if TLS is disabled.
+func (o *TLSOptions) ServerTLSConfig() (*tls.Config, error) {
+	if o == nil || !o.UseTLS {
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(o.Cert, o.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key pair: %w", err)
+	}
+
+	authType, ok := clientAuthTypes[o.ClientAuth]
+	if !ok {
+		authType = tls.NoClientCert
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   authType,
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if o.CACert != "" {
+		pool, err := loadCertPool(o.CACert)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+	}
+
+	return cfg, nil
+}
+
+// ClientTLSConfig returns the tls.Config used by clients dialing addr. It returns nil if TLS is disabled.
+func (o *TLSOptions) ClientTLSConfig(addr string) (*tls.Config, error) {
+	if o == nil || !o.UseTLS {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{
+		ServerName: o.serverName(addr),
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if o.Cert != "" && o.Key != "" {
+		cert, err := tls.LoadX509KeyPair(o.Cert, o.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key pair: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	if o.CACert != "" {
+		pool, err := loadCertPool(o.CACert)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
+
+// serverName returns the server name used to verify the server certificate.
+func (o *TLSOptions) serverName(addr string) string {
+	if o.ServerName != "" {
+		return o.ServerName
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	// Servers listening on all interfaces are dialed through the loopback interface.
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		return "localhost"
+	}
+	return host
+}
+
+// loadCertPool loads the PEM encoded certificates in file into a new cert pool.
+func loadCertPool(file string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("failed to parse CA certificate %q", file)
+	}
+	return pool, nil
+}
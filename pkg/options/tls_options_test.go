@@ -0,0 +1,115 @@
+package options_test
+
+import (
+	"crypto/tls"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/internal/pkg/testutil"
+	"github.com/ra1n6ow/opsx/pkg/options"
+)
+
+func TestTLSOptionsValidate(t *testing.T) {
+	certs := testutil.GenerateCerts(t)
+
+	tests := []struct {
+		name    string
+		opts    *options.TLSOptions
+		wantErr bool
+	}{
+		{name: "disabled", opts: options.NewTLSOptions()},
+		{name: "tls", opts: &options.TLSOptions{UseTLS: true, Cert: certs.ServerCert, Key: certs.ServerKey, ClientAuth: options.ClientAuthNone}},
+		{name: "mtls", opts: &options.TLSOptions{UseTLS: true, Cert: certs.ServerCert, Key: certs.ServerKey, CACert: certs.CACert, ClientAuth: options.ClientAuthRequireAndVerify}},
+		{name: "missing key", opts: &options.TLSOptions{UseTLS: true, Cert: certs.ServerCert, ClientAuth: options.ClientAuthNone}, wantErr: true},
+		{name: "missing file", opts: &options.TLSOptions{UseTLS: true, Cert: certs.ServerCert, Key: "/nonexistent.key", ClientAuth: options.ClientAuthNone}, wantErr: true},
+		{name: "invalid client auth", opts: &options.TLSOptions{UseTLS: true, Cert: certs.ServerCert, Key: certs.ServerKey, ClientAuth: "always"}, wantErr: true},
+		{name: "verify without ca", opts: &options.TLSOptions{UseTLS: true, Cert: certs.ServerCert, Key: certs.ServerKey, ClientAuth: options.ClientAuthRequireAndVerify}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.opts.Validate()
+			if tt.wantErr {
+				assert.NotEmpty(t, errs)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func TestTLSOptionsHandshake(t *testing.T) {
+	certs := testutil.GenerateCerts(t)
+
+	serverOpts := &options.TLSOptions{
+		UseTLS:     true,
+		Cert:       certs.ServerCert,
+		Key:        certs.ServerKey,
+		CACert:     certs.CACert,
+		ClientAuth: options.ClientAuthRequireAndVerify,
+	}
+	serverConfig, err := serverOpts.ServerTLSConfig()
+	require.NoError(t, err)
+
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+	defer lis.Close()
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				_, _ = conn.Write([]byte("ok"))
+			}()
+		}
+	}()
+
+	tests := []struct {
+		name    string
+		opts    *options.TLSOptions
+		wantErr bool
+	}{
+		{name: "trusted client certificate", opts: &options.TLSOptions{UseTLS: true, Cert: certs.ClientCert, Key: certs.ClientKey, CACert: certs.CACert}},
+		{name: "no client certificate", opts: &options.TLSOptions{UseTLS: true, CACert: certs.CACert}, wantErr: true},
+		{name: "untrusted client certificate", opts: &options.TLSOptions{UseTLS: true, Cert: certs.UntrustedCert, Key: certs.UntrustedKey, CACert: certs.CACert}, wantErr: true},
+		{name: "wrong server name", opts: &options.TLSOptions{UseTLS: true, Cert: certs.ClientCert, Key: certs.ClientKey, CACert: certs.CACert, ServerName: "example.com"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientConfig, err := tt.opts.ClientTLSConfig(lis.Addr().String())
+			require.NoError(t, err)
+
+			conn, err := tls.Dial("tcp", lis.Addr().String(), clientConfig)
+			if err == nil {
+				defer conn.Close()
+				// TLS 1.3 中，服务端对客户端证书的校验结果在读取数据时才能感知到
+				_, err = io.ReadAll(conn)
+			}
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTLSOptionsDisabled(t *testing.T) {
+	opts := options.NewTLSOptions()
+
+	serverConfig, err := opts.ServerTLSConfig()
+	require.NoError(t, err)
+	assert.Nil(t, serverConfig)
+
+	clientConfig, err := opts.ClientTLSConfig("127.0.0.1:443")
+	require.NoError(t, err)
+	assert.Nil(t, clientConfig)
+}
@@ -0,0 +1,123 @@
+package options
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+var _ IOptions = (*TracingOptions)(nil)
+
+// Supported trace exporters.
+const (
+	TracingExporterStdout = "stdout"
+	TracingExporterOTLP   = "otlp"
+)
+
+var availableTracingExporters = sets.New(TracingExporterStdout, TracingExporterOTLP)
+
+// TracingOptions contains configuration items related to OpenTelemetry tracing.
+type TracingOptions struct {
+	// Enabled specifies whether tracing is enabled.
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+
+	// Exporter is the trace exporter, available options: stdout, otlp.
+	Exporter string `json:"exporter" mapstructure:"exporter"`
+
+	// Endpoint is the address of the OTLP gRPC collector, for example localhost:4317.
+	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
+
+	// Insecure specifies whether to disable TLS when connecting to the OTLP collector.
+	Insecure bool `json:"insecure" mapstructure:"insecure"`
+
+	// SampleRatio is the ratio of traces to sample, in the range [0, 1].
+	SampleRatio float64 `json:"sample-ratio" mapstructure:"sample-ratio"`
+}
+
+// NewTracingOptions creates a TracingOptions object with default parameters.
+func NewTracingOptions() *TracingOptions {
+	return &TracingOptions{
+		Enabled:     false,
+		Exporter:    TracingExporterOTLP,
+		Endpoint:    "127.0.0.1:4317",
+		Insecure:    true,
+		SampleRatio: 1,
+	}
+}
+
+// Validate is used to parse and validate the parameters entered by the user at
+// the command line when the program starts.
+func (o *TracingOptions) Validate() []error {
+	if o == nil || !o.Enabled {
+		return nil
+	}
+
+	errs := []error{}
+
+	if !availableTracingExporters.Has(o.Exporter) {
+		errs = append(errs, fmt.Errorf("invalid tracing exporter %q: must be one of %v", o.Exporter, sets.List(availableTracingExporters)))
+	}
+
+	if o.Exporter == TracingExporterOTLP && o.Endpoint == "" {
+		errs = append(errs, fmt.Errorf("tracing endpoint must be specified when exporter is %q", TracingExporterOTLP))
+	}
+
+	if o.SampleRatio < 0 || o.SampleRatio > 1 {
+		errs = append(errs, fmt.Errorf("tracing sample ratio %v must be in the range [0, 1]", o.SampleRatio))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to tracing to the specified FlagSet.
+func (o *TracingOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	fs.BoolVar(&o.Enabled, "tracing.enabled", o.Enabled, "Enable OpenTelemetry tracing.")
+	fs.StringVar(&o.Exporter, "tracing.exporter", o.Exporter, fmt.Sprintf("Trace exporter, available options: %v", sets.List(availableTracingExporters)))
+	fs.StringVar(&o.Endpoint, "tracing.endpoint", o.Endpoint, "Address of the OTLP gRPC collector.")
+	fs.BoolVar(&o.Insecure, "tracing.insecure", o.Insecure, "Disable TLS when connecting to the OTLP collector.")
+	fs.Float64Var(&o.SampleRatio, "tracing.sample-ratio", o.SampleRatio, "Ratio of traces to sample, in the range [0, 1].")
+}
+
+// NewTracerProvider creates a tracer provider that exports spans of serviceName
+// with the configured exporter. The caller is responsible for shutting it down.
+func (o *TracingOptions) NewTracerProvider(ctx context.Context, serviceName string) (*sdktrace.TracerProvider, error) {
+	exporter, err := o.newExporter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, err
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithBatchTimeout(5*time.Second)),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.SampleRatio))),
+	), nil
+}
+
+// newExporter creates the configured span exporter.
+func (o *TracingOptions) newExporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	switch o.Exporter {
+	case TracingExporterStdout:
+		return stdouttrace.New()
+	case TracingExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(o.Endpoint)}
+		if o.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter %q", o.Exporter)
+	}
+}
@@ -0,0 +1,33 @@
+package options_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ra1n6ow/opsx/pkg/options"
+)
+
+func TestTracingOptionsValidate(t *testing.T) {
+	opts := options.NewTracingOptions()
+	assert.Empty(t, opts.Validate())
+
+	opts.Enabled = true
+	assert.Empty(t, opts.Validate())
+
+	opts.Exporter = "zipkin"
+	opts.SampleRatio = 2
+	assert.Len(t, opts.Validate(), 2)
+}
+
+func TestTracingOptionsNewTracerProvider(t *testing.T) {
+	opts := options.NewTracingOptions()
+	opts.Enabled = true
+	opts.Exporter = options.TracingExporterStdout
+
+	tp, err := opts.NewTracerProvider(context.Background(), "opsx-test")
+	require.NoError(t, err)
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package token 提供 JWT Token 的签发和解析功能.
+// 各服务使用相同的密钥调用 Init 初始化后，即可相互校验对方签发的 Token.
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc/metadata"
+)
+
+// 定义 token 包返回的错误.
+var (
+	// ErrMissingHeader 表示请求中没有携带 Authorization 头.
+	ErrMissingHeader = errors.New("the length of the `Authorization` header is zero")
+	// ErrInvalidToken 表示 Token 无效或已过期.
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// authorizationKey 定义了携带 Token 的 HTTP Header 和 gRPC Metadata 的键.
+const authorizationKey = "authorization"
+
+// Config 包括 token 包的配置选项.
+type Config struct {
+	// key 用于签发和解析 token 的密钥.
+	key string
+	// expiration 是签发的 token 过期时间.
+	expiration time.Duration
+}
+
+var (
+	mu sync.RWMutex
+
+	// config 是默认的 token 配置.
+	config = Config{key: "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iB31", expiration: 2 * time.Hour}
+)
+
+// Init 设置 token 包签发和解析 token 使用的密钥以及 token 的过期时间.
+func Init(key string, expiration time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	config = Config{key: key, expiration: expiration}
+}
+
+// SetExpiration 修改签发 token 使用的过期时间，已经签发的 token 不受影响.
+func SetExpiration(expiration time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	config.expiration = expiration
+}
+
+// Expiration 返回当前签发 token 使用的过期时间.
+func Expiration() time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return config.expiration
+}
+
+// Sign 使用默认配置为 subject（通常为用户 ID）签发 token，返回 token 字符串和过期时间.
+func Sign(subject string) (string, time.Time, error) {
+	mu.RLock()
+	cfg := config
+	mu.RUnlock()
+
+	return SignWithKey(subject, cfg.key, cfg.expiration)
+}
+
+// SignWithKey 使用指定的密钥和过期时间为 subject 签发 token.
+func SignWithKey(subject string, key string, expiration time.Duration) (string, time.Time, error) {
+	now := time.Now()
+	expireAt := now.Add(expiration)
+
+	// Token 的内容
+	claims := jwt.RegisteredClaims{
+		// 主体，即 token 所属的用户
+		Subject: subject,
+		// 签发时间
+		IssuedAt: jwt.NewNumericDate(now),
+		// 生效时间
+		NotBefore: jwt.NewNumericDate(now),
+		// 过期时间
+		ExpiresAt: jwt.NewNumericDate(expireAt),
+	}
+
+	// 签发 token
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(key))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return tokenString, expireAt, nil
+}
+
+// Parse 使用默认配置中的密钥解析 token，返回 token 中的 subject.
+func Parse(tokenString string) (string, error) {
+	mu.RLock()
+	key := config.key
+	mu.RUnlock()
+
+	return ParseWithKey(tokenString, key)
+}
+
+// ParseWithKey 使用指定的密钥解析 token，校验签名和有效期，并返回 token 中的 subject.
+func ParseWithKey(tokenString string, key string) (string, error) {
+	claims := &jwt.RegisteredClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (any, error) {
+		// 确保 token 加密算法是预期的加密算法
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, jwt.ErrSignatureInvalid
+		}
+		return []byte(key), nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+	if !token.Valid || claims.Subject == "" {
+		return "", ErrInvalidToken
+	}
+
+	return claims.Subject, nil
+}
+
+// ParseRequest 从请求中获取 `Authorization: Bearer <token>` 格式的 token，并解析出其中的 subject.
+// ctx 为 *gin.Context 时从 HTTP 请求头中获取，否则从 gRPC 的 incoming metadata 中获取.
+func ParseRequest(ctx context.Context) (string, error) {
+	tokenString, err := FromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return Parse(tokenString)
+}
+
+// FromContext 从请求中获取 Bearer token 字符串.
+func FromContext(ctx context.Context) (string, error) {
+	var header string
+	switch typed := ctx.(type) {
+	case *gin.Context:
+		header = typed.Request.Header.Get(authorizationKey)
+	default:
+		md, _ := metadata.FromIncomingContext(ctx)
+		if values := md.Get(authorizationKey); len(values) > 0 {
+			header = values[0]
+		}
+	}
+
+	if len(header) == 0 {
+		return "", ErrMissingHeader
+	}
+
+	// 从请求头中取出 token
+	scheme, tokenString, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || tokenString == "" {
+		return "", ErrInvalidToken
+	}
+
+	return tokenString, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package token_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ra1n6ow/opsx/pkg/token"
+)
+
+func TestSignAndParse(t *testing.T) {
+	token.Init("test-secret-key", time.Hour)
+
+	tokenString, expireAt, err := token.Sign("user-abc")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), expireAt, time.Second)
+
+	subject, err := token.Parse(tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, "user-abc", subject)
+
+	// 使用其他密钥签发的 token 无法通过校验
+	_, err = token.ParseWithKey(tokenString, "another-key")
+	assert.ErrorIs(t, err, token.ErrInvalidToken)
+
+	// 过期的 token 无法通过校验
+	expired, _, err := token.SignWithKey("user-abc", "test-secret-key", -time.Minute)
+	require.NoError(t, err)
+	_, err = token.Parse(expired)
+	assert.ErrorIs(t, err, token.ErrInvalidToken)
+}
+
+func TestParseRequest(t *testing.T) {
+	token.Init("test-secret-key", time.Hour)
+	tokenString, _, err := token.Sign("user-abc")
+	require.NoError(t, err)
+
+	// 没有携带 Authorization
+	_, err = token.ParseRequest(context.Background())
+	assert.ErrorIs(t, err, token.ErrMissingHeader)
+
+	// 格式错误的 Authorization
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", tokenString))
+	_, err = token.ParseRequest(ctx)
+	assert.ErrorIs(t, err, token.ErrInvalidToken)
+
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+tokenString))
+	subject, err := token.ParseRequest(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "user-abc", subject)
+}
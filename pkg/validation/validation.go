@@ -0,0 +1,82 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+// Package validation 提供按请求类型分发的请求校验器，以及收集字段校验错误的工具.
+package validation
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var (
+	contextType = reflect.TypeFor[context.Context]()
+	errorType   = reflect.TypeFor[error]()
+)
+
+// Validator 根据请求的类型调用对应的校验函数. 没有注册校验函数的请求不做校验.
+// Validator 创建后只读，可以在多个 goroutine 中并发使用.
+type Validator struct {
+	// validators 的键为请求的类型（例如 *v1.CreateUserRequest），值为对应的校验函数.
+	validators map[reflect.Type]func(ctx context.Context, rq any) error
+}
+
+// New 创建一个 Validator，并注册 custom 中所有形如以下签名的方法作为校验函数：
+//
+//	func (v *T) Validate<MessageName>(ctx context.Context, rq *<MessageName>) error
+//
+// 例如 ValidateCreateUserRequest 用于校验 *v1.CreateUserRequest. 方法名与请求类型不匹配时会 panic，
+// 以便尽早发现拼写错误. custom 可以为 nil.
+func New(custom any) *Validator {
+	v := &Validator{validators: make(map[reflect.Type]func(ctx context.Context, rq any) error)}
+	if custom == nil {
+		return v
+	}
+
+	value := reflect.ValueOf(custom)
+	typ := value.Type()
+	for i := range typ.NumMethod() {
+		method := typ.Method(i)
+		if !strings.HasPrefix(method.Name, "Validate") {
+			continue
+		}
+
+		// method.Type 的第一个参数为接收者
+		mt := method.Type
+		if mt.NumIn() != 3 || mt.In(1) != contextType || mt.In(2).Kind() != reflect.Pointer ||
+			mt.NumOut() != 1 || mt.Out(0) != errorType {
+			continue
+		}
+		rqType := mt.In(2)
+		if method.Name != "Validate"+rqType.Elem().Name() {
+			panic(fmt.Sprintf("validation: method %s does not match request type %s", method.Name, rqType))
+		}
+
+		fn := value.Method(i)
+		v.validators[rqType] = func(ctx context.Context, rq any) error {
+			out := fn.Call([]reflect.Value{reflect.ValueOf(ctx), reflect.ValueOf(rq)})
+			err, _ := out[0].Interface().(error)
+			return err
+		}
+	}
+	return v
+}
+
+// Register 为 *T 类型的请求注册校验函数 fn，已有的校验函数会被替换. 需要在使用 v 之前调用.
+func Register[T any](v *Validator, fn func(ctx context.Context, rq *T) error) {
+	v.validators[reflect.TypeFor[*T]()] = func(ctx context.Context, rq any) error {
+		return fn(ctx, rq.(*T))
+	}
+}
+
+// Validate 使用 rq 类型对应的校验函数校验 rq. 没有对应的校验函数时返回 nil.
+func (v *Validator) Validate(ctx context.Context, rq any) error {
+	if fn, ok := v.validators[reflect.TypeOf(rq)]; ok {
+		return fn(ctx, rq)
+	}
+	return nil
+}
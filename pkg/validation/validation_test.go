@@ -0,0 +1,84 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package validation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+type CreateThingRequest struct {
+	Name string
+	Size int
+}
+
+type DeleteThingRequest struct {
+	ID string
+}
+
+type thingValidator struct{}
+
+func (thingValidator) ValidateCreateThingRequest(ctx context.Context, rq *CreateThingRequest) error {
+	var violations Violations
+	if rq.Name == "" {
+		violations.AddError("name", errorsx.New(400, "InvalidArgument.NameInvalid", "Name is required."))
+	}
+	if rq.Size < 0 {
+		violations.Add("size", "must not be less than %d", 0)
+	}
+	return violations.Err()
+}
+
+// ValidateAll 的签名不是校验方法的签名，不会被注册.
+func (thingValidator) ValidateAll(rq any) error {
+	return nil
+}
+
+type mismatchedValidator struct{}
+
+func (mismatchedValidator) ValidateCreateThing(ctx context.Context, rq *CreateThingRequest) error {
+	return nil
+}
+
+func TestValidator(t *testing.T) {
+	v := New(thingValidator{})
+	ctx := context.Background()
+
+	assert.NoError(t, v.Validate(ctx, &CreateThingRequest{Name: "thing"}))
+
+	err := v.Validate(ctx, &CreateThingRequest{Size: -1})
+	assert.ErrorIs(t, err, errorsx.ErrInvalidArgument)
+	assert.Equal(t, []errorsx.FieldViolation{
+		{Field: "name", Description: "Name is required.", Reason: "InvalidArgument.NameInvalid"},
+		{Field: "size", Description: "must not be less than 0"},
+	}, errorsx.FromError(err).FieldViolations)
+
+	// 没有注册校验函数的请求不做校验
+	assert.NoError(t, v.Validate(ctx, &DeleteThingRequest{}))
+
+	// 手动注册校验函数
+	Register(v, func(ctx context.Context, rq *DeleteThingRequest) error {
+		var violations Violations
+		if rq.ID == "" {
+			violations.Add("id", "must not be empty")
+		}
+		return violations.Err()
+	})
+	assert.ErrorIs(t, v.Validate(ctx, &DeleteThingRequest{}), errorsx.ErrInvalidArgument)
+	assert.NoError(t, v.Validate(ctx, &DeleteThingRequest{ID: "thing-1"}))
+
+	assert.NoError(t, New(nil).Validate(ctx, &CreateThingRequest{}))
+}
+
+func TestValidatorMismatchedMethod(t *testing.T) {
+	assert.PanicsWithValue(t, "validation: method ValidateCreateThing does not match request type *validation.CreateThingRequest", func() {
+		New(mismatchedValidator{})
+	})
+}
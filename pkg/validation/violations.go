@@ -0,0 +1,39 @@
+// Copyright 2025 JingFeng Du <jeffduuu@gmail.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/Ra1n6ow/opsx.
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/ra1n6ow/opsx/pkg/errorsx"
+)
+
+// Violations 用于收集请求中所有的字段校验错误，以便一次性返回给客户端，例如：
+//
+//	var violations validation.Violations
+//	if !IsValidUsername(rq.GetUsername()) {
+//		violations.AddError("username", errno.ErrUsernameInvalid)
+//	}
+//	return violations.Err()
+type Violations []errorsx.FieldViolation
+
+// Add 添加字段 field 的校验错误，description 为校验失败的原因.
+func (vs *Violations) Add(field string, format string, args ...any) {
+	*vs = append(*vs, errorsx.FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+// AddError 使用预定义的错误 errx 添加字段 field 的校验错误，错误原因和错误信息分别作为字段校验错误的原因和描述.
+func (vs *Violations) AddError(field string, errx *errorsx.ErrorX) {
+	*vs = append(*vs, errorsx.FieldViolation{Field: field, Description: errx.Message, Reason: errx.Reason})
+}
+
+// Err 返回包含所有字段校验错误的 errorsx.ErrInvalidArgument 错误. 没有字段校验错误时返回 nil.
+func (vs Violations) Err() error {
+	if len(vs) == 0 {
+		return nil
+	}
+	return errorsx.ErrInvalidArgument.WithFieldViolations(vs...)
+}